@@ -70,7 +70,7 @@ func (x ThrottleModifier_IntervalType) String() string {
 	return proto.EnumName(ThrottleModifier_IntervalType_name, int32(x))
 }
 func (ThrottleModifier_IntervalType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{12, 0}
+	return fileDescriptor3, []int{21, 0}
 }
 
 //
@@ -89,13 +89,29 @@ type Subscription struct {
 	// the specified relative duration subtracted from the current
 	// time (recorder time). If the resulting time is in the past, then the
 	// subscription will search for historic events before streaming
-	// live ones.
+	// live ones. Historic events are only available if the sensor's
+	// event history is enabled, and only include events that the sensor
+	// collected for subscriptions that were active at the time.
 	SinceDuration *google_protobuf1.Int64Value `protobuf:"bytes,10,opt,name=since_duration,json=sinceDuration" json:"since_duration,omitempty"`
 	// If not empty, then only return events that occurred before
 	// the specified relative duration added to `since_duration`.
 	// If `since_duration` is not supplied, return events from now and until
-	// the specified relative duration is hit.
+	// the specified relative duration is hit. When the duration ends,
+	// the stream of events is ended without an error.
 	ForDuration *google_protobuf1.Int64Value `protobuf:"bytes,11,opt,name=for_duration,json=forDuration" json:"for_duration,omitempty"`
+	// If not zero, populate the process_lineage of each event with
+	// up to the specified number of processes, starting with the
+	// process that generated the event and ending with the init
+	// process of its container or of the host. The Sensor may
+	// impose a lower limit.
+	ProcessLineageDepth uint32 `protobuf:"varint,12,opt,name=process_lineage_depth,json=processLineageDepth" json:"process_lineage_depth,omitempty"`
+	// If greater than one, then up to the specified number of events
+	// may be sent together in each response.
+	MaxBatchSize uint32 `protobuf:"varint,13,opt,name=max_batch_size,json=maxBatchSize" json:"max_batch_size,omitempty"`
+	// If not zero, then wait up to the specified relative duration
+	// for a batch of events to fill before sending it. Otherwise,
+	// batches only contain the events that are ready to be sent.
+	MaxBatchLatency int64 `protobuf:"varint,14,opt,name=max_batch_latency,json=maxBatchLatency" json:"max_batch_latency,omitempty"`
 	// If not empty, apply the specified modifier to the subscription.
 	Modifier *Modifier `protobuf:"bytes,20,opt,name=modifier" json:"modifier,omitempty"`
 }
@@ -133,6 +149,27 @@ func (m *Subscription) GetForDuration() *google_protobuf1.Int64Value {
 	return nil
 }
 
+func (m *Subscription) GetProcessLineageDepth() uint32 {
+	if m != nil {
+		return m.ProcessLineageDepth
+	}
+	return 0
+}
+
+func (m *Subscription) GetMaxBatchSize() uint32 {
+	if m != nil {
+		return m.MaxBatchSize
+	}
+	return 0
+}
+
+func (m *Subscription) GetMaxBatchLatency() int64 {
+	if m != nil {
+		return m.MaxBatchLatency
+	}
+	return 0
+}
+
 func (m *Subscription) GetModifier() *Modifier {
 	if m != nil {
 		return m.Modifier
@@ -140,6 +177,75 @@ func (m *Subscription) GetModifier() *Modifier {
 	return nil
 }
 
+// The SubscriptionModification message changes an active subscription
+// without interrupting its stream of telemetry events.
+type SubscriptionModification struct {
+	// If not empty, then add the specified event filters to the
+	// subscription. Historic events are not replayed for them.
+	AddEventFilter *EventFilter `protobuf:"bytes,1,opt,name=add_event_filter,json=addEventFilter" json:"add_event_filter,omitempty"`
+	// If not empty, then remove the specified event filters from the
+	// subscription. Each filter must be equal to one that is in the
+	// subscription.
+	RemoveEventFilter *EventFilter `protobuf:"bytes,2,opt,name=remove_event_filter,json=removeEventFilter" json:"remove_event_filter,omitempty"`
+	// If not empty, then replace the ContainerFilter of the
+	// subscription.
+	ContainerFilter *ContainerFilter `protobuf:"bytes,3,opt,name=container_filter,json=containerFilter" json:"container_filter,omitempty"`
+	// If true, then remove the ContainerFilter of the subscription.
+	ClearContainerFilter bool `protobuf:"varint,4,opt,name=clear_container_filter,json=clearContainerFilter" json:"clear_container_filter,omitempty"`
+	// If not empty, then replace the Modifier of the subscription.
+	// The count of events for a LimitModifier starts again from zero.
+	Modifier *Modifier `protobuf:"bytes,5,opt,name=modifier" json:"modifier,omitempty"`
+	// If true, then remove the Modifier of the subscription.
+	ClearModifier bool `protobuf:"varint,6,opt,name=clear_modifier,json=clearModifier" json:"clear_modifier,omitempty"`
+}
+
+func (m *SubscriptionModification) Reset()                    { *m = SubscriptionModification{} }
+func (m *SubscriptionModification) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionModification) ProtoMessage()               {}
+func (*SubscriptionModification) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+
+func (m *SubscriptionModification) GetAddEventFilter() *EventFilter {
+	if m != nil {
+		return m.AddEventFilter
+	}
+	return nil
+}
+
+func (m *SubscriptionModification) GetRemoveEventFilter() *EventFilter {
+	if m != nil {
+		return m.RemoveEventFilter
+	}
+	return nil
+}
+
+func (m *SubscriptionModification) GetContainerFilter() *ContainerFilter {
+	if m != nil {
+		return m.ContainerFilter
+	}
+	return nil
+}
+
+func (m *SubscriptionModification) GetClearContainerFilter() bool {
+	if m != nil {
+		return m.ClearContainerFilter
+	}
+	return false
+}
+
+func (m *SubscriptionModification) GetModifier() *Modifier {
+	if m != nil {
+		return m.Modifier
+	}
+	return nil
+}
+
+func (m *SubscriptionModification) GetClearModifier() bool {
+	if m != nil {
+		return m.ClearModifier
+	}
+	return false
+}
+
 // The ContainerFilter restricts events in the Subscription to the
 // running containers indicated. All of the fields in this message are
 // effectively "ORed" together to create the list of containers to
@@ -162,7 +268,7 @@ type ContainerFilter struct {
 func (m *ContainerFilter) Reset()                    { *m = ContainerFilter{} }
 func (m *ContainerFilter) String() string            { return proto.CompactTextString(m) }
 func (*ContainerFilter) ProtoMessage()               {}
-func (*ContainerFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+func (*ContainerFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
 
 func (m *ContainerFilter) GetIds() []string {
 	if m != nil {
@@ -206,6 +312,23 @@ type EventFilter struct {
 	KernelEvents []*KernelFunctionCallFilter `protobuf:"bytes,4,rep,name=kernel_events,json=kernelEvents" json:"kernel_events,omitempty"`
 	// Zero or more network events to include
 	NetworkEvents []*NetworkEventFilter `protobuf:"bytes,5,rep,name=network_events,json=networkEvents" json:"network_events,omitempty"`
+	// Zero or more credentials events to include
+	CredentialsEvents []*CredentialsEventFilter `protobuf:"bytes,6,rep,name=credentials_events,json=credentialsEvents" json:"credentials_events,omitempty"`
+	// Zero or more signal events to include
+	SignalEvents []*SignalEventFilter `protobuf:"bytes,7,rep,name=signal_events,json=signalEvents" json:"signal_events,omitempty"`
+	// Zero or more namespace events to include
+	NamespaceEvents []*NamespaceEventFilter `protobuf:"bytes,8,rep,name=namespace_events,json=namespaceEvents" json:"namespace_events,omitempty"`
+	// Zero or more kernel module events to include
+	KernelModuleEvents []*KernelModuleEventFilter `protobuf:"bytes,9,rep,name=kernel_module_events,json=kernelModuleEvents" json:"kernel_module_events,omitempty"`
+	// Zero or more process access events to include
+	ProcessAccessEvents []*ProcessAccessEventFilter `protobuf:"bytes,11,rep,name=process_access_events,json=processAccessEvents" json:"process_access_events,omitempty"`
+	// Zero or more memory events to include
+	MemoryEvents []*MemoryEventFilter `protobuf:"bytes,12,rep,name=memory_events,json=memoryEvents" json:"memory_events,omitempty"`
+	// Zero or more flow record generators to configure and return
+	// events from
+	FlowEvents []*FlowEventFilter `protobuf:"bytes,13,rep,name=flow_events,json=flowEvents" json:"flow_events,omitempty"`
+	// Zero or more kernel tracepoints to include
+	TracepointEvents []*TracepointEventFilter `protobuf:"bytes,14,rep,name=tracepoint_events,json=tracepointEvents" json:"tracepoint_events,omitempty"`
 	// Zero or more container events to include
 	ContainerEvents []*ContainerEventFilter `protobuf:"bytes,10,rep,name=container_events,json=containerEvents" json:"container_events,omitempty"`
 	// Zero or more character generators to configure and return events from
@@ -219,7 +342,7 @@ type EventFilter struct {
 func (m *EventFilter) Reset()                    { *m = EventFilter{} }
 func (m *EventFilter) String() string            { return proto.CompactTextString(m) }
 func (*EventFilter) ProtoMessage()               {}
-func (*EventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*EventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
 
 func (m *EventFilter) GetSyscallEvents() []*SyscallEventFilter {
 	if m != nil {
@@ -256,6 +379,62 @@ func (m *EventFilter) GetNetworkEvents() []*NetworkEventFilter {
 	return nil
 }
 
+func (m *EventFilter) GetCredentialsEvents() []*CredentialsEventFilter {
+	if m != nil {
+		return m.CredentialsEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetSignalEvents() []*SignalEventFilter {
+	if m != nil {
+		return m.SignalEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetNamespaceEvents() []*NamespaceEventFilter {
+	if m != nil {
+		return m.NamespaceEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetKernelModuleEvents() []*KernelModuleEventFilter {
+	if m != nil {
+		return m.KernelModuleEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetProcessAccessEvents() []*ProcessAccessEventFilter {
+	if m != nil {
+		return m.ProcessAccessEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetMemoryEvents() []*MemoryEventFilter {
+	if m != nil {
+		return m.MemoryEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetFlowEvents() []*FlowEventFilter {
+	if m != nil {
+		return m.FlowEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetTracepointEvents() []*TracepointEventFilter {
+	if m != nil {
+		return m.TracepointEvents
+	}
+	return nil
+}
+
 func (m *EventFilter) GetContainerEvents() []*ContainerEventFilter {
 	if m != nil {
 		return m.ContainerEvents
@@ -301,7 +480,7 @@ type SyscallEventFilter struct {
 func (m *SyscallEventFilter) Reset()                    { *m = SyscallEventFilter{} }
 func (m *SyscallEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*SyscallEventFilter) ProtoMessage()               {}
-func (*SyscallEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+func (*SyscallEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
 
 func (m *SyscallEventFilter) GetType() SyscallEventType {
 	if m != nil {
@@ -391,7 +570,7 @@ type ProcessEventFilter struct {
 func (m *ProcessEventFilter) Reset()                    { *m = ProcessEventFilter{} }
 func (m *ProcessEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ProcessEventFilter) ProtoMessage()               {}
-func (*ProcessEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+func (*ProcessEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
 
 func (m *ProcessEventFilter) GetType() ProcessEventType {
 	if m != nil {
@@ -450,7 +629,7 @@ type FileEventFilter struct {
 func (m *FileEventFilter) Reset()                    { *m = FileEventFilter{} }
 func (m *FileEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*FileEventFilter) ProtoMessage()               {}
-func (*FileEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+func (*FileEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
 
 func (m *FileEventFilter) GetType() FileEventType {
 	if m != nil {
@@ -494,6 +673,204 @@ func (m *FileEventFilter) GetCreateModeMask() *google_protobuf1.Int32Value {
 	return nil
 }
 
+// The CredentialsEventFilter specifies which credentials events to include
+// in the Subscription. The filter expression may refer to the new uid, gid,
+// euid, egid, and fsuid.
+type CredentialsEventFilter struct {
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *CredentialsEventFilter) Reset()                    { *m = CredentialsEventFilter{} }
+func (m *CredentialsEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*CredentialsEventFilter) ProtoMessage()               {}
+func (*CredentialsEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+
+func (m *CredentialsEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The SignalEventFilter specifies which signal events to include in the
+// Subscription. For generate events, the filter expression may refer to sig,
+// errno, code, pid (the target), group, and result. For deliver events, it
+// may refer to sig, errno, code, sa_handler, and sa_flags.
+type SignalEventFilter struct {
+	// Required; the signal event type to match
+	Type SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *SignalEventFilter) Reset()                    { *m = SignalEventFilter{} }
+func (m *SignalEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*SignalEventFilter) ProtoMessage()               {}
+func (*SignalEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+
+func (m *SignalEventFilter) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SignalEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The NamespaceEventFilter specifies which namespace events to include in
+// the Subscription. For clone events, the filter expression may refer to
+// clone_flags. For unshare events, it may refer to unshare_flags. For setns
+// events, it may refer to fd and nstype.
+type NamespaceEventFilter struct {
+	// Required; the namespace event type to match
+	Type NamespaceEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.NamespaceEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *NamespaceEventFilter) Reset()                    { *m = NamespaceEventFilter{} }
+func (m *NamespaceEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*NamespaceEventFilter) ProtoMessage()               {}
+func (*NamespaceEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+
+func (m *NamespaceEventFilter) GetType() NamespaceEventType {
+	if m != nil {
+		return m.Type
+	}
+	return NamespaceEventType_NAMESPACE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *NamespaceEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The KernelModuleEventFilter specifies which kernel module events to
+// include in the Subscription. The filter expression may refer to name, and
+// for load events, to taints.
+type KernelModuleEventFilter struct {
+	// Required; the kernel module event type to match
+	Type KernelModuleEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.KernelModuleEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *KernelModuleEventFilter) Reset()                    { *m = KernelModuleEventFilter{} }
+func (m *KernelModuleEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*KernelModuleEventFilter) ProtoMessage()               {}
+func (*KernelModuleEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+
+func (m *KernelModuleEventFilter) GetType() KernelModuleEventType {
+	if m != nil {
+		return m.Type
+	}
+	return KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *KernelModuleEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The ProcessAccessEventFilter specifies which process access events to
+// include in the Subscription. The filter expression may refer to pid (the
+// target, as seen by the calling process), and for ptrace events, to request.
+type ProcessAccessEventFilter struct {
+	// Required; the process access event type to match
+	Type ProcessAccessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessAccessEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *ProcessAccessEventFilter) Reset()                    { *m = ProcessAccessEventFilter{} }
+func (m *ProcessAccessEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*ProcessAccessEventFilter) ProtoMessage()               {}
+func (*ProcessAccessEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+
+func (m *ProcessAccessEventFilter) GetType() ProcessAccessEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ProcessAccessEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The MemoryEventFilter specifies which memory events to include in the
+// Subscription. Only calls requesting PROT_EXEC are included. For mmap
+// events, the filter expression may refer to addr, len, prot, flags, fd,
+// and off. For mprotect events, it may refer to start, len, and prot. For
+// example, "prot & 2" limits events to memory that is writable and
+// executable.
+type MemoryEventFilter struct {
+	// Required; the memory event type to match
+	Type MemoryEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MemoryEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *MemoryEventFilter) Reset()                    { *m = MemoryEventFilter{} }
+func (m *MemoryEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*MemoryEventFilter) ProtoMessage()               {}
+func (*MemoryEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *MemoryEventFilter) GetType() MemoryEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MemoryEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The FlowEventFilter configures the tracking of data sent and received on
+// sockets, and includes summaries of the flows in the Subscription instead
+// of an event for each system call. Flows are tracked once for all of the
+// Subscriptions that ask for them, but while they are, every read(2),
+// readv(2), write(2) and writev(2) on the host is sampled by the kernel,
+// whether or not it is on a socket, which has a cost on hosts doing a lot
+// of I/O.
+type FlowEventFilter struct {
+	// Required; the interval at which flows are summarized
+	Interval int64 `protobuf:"varint,1,opt,name=interval" json:"interval,omitempty"`
+}
+
+func (m *FlowEventFilter) Reset()                    { *m = FlowEventFilter{} }
+func (m *FlowEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*FlowEventFilter) ProtoMessage()               {}
+func (*FlowEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+
+func (m *FlowEventFilter) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
 // The KernelFunctionCallFilter specifies which kernel function call
 // events to include in the Subscription. The arguments map defines
 // values that will be fetched at each call and returned along with
@@ -522,7 +899,7 @@ type KernelFunctionCallFilter struct {
 func (m *KernelFunctionCallFilter) Reset()                    { *m = KernelFunctionCallFilter{} }
 func (m *KernelFunctionCallFilter) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallFilter) ProtoMessage()               {}
-func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
 
 func (m *KernelFunctionCallFilter) GetType() KernelFunctionCallEventType {
 	if m != nil {
@@ -552,9 +929,46 @@ func (m *KernelFunctionCallFilter) GetFilterExpression() *Expression {
 	return nil
 }
 
+// The TracepointEventFilter specifies a kernel tracepoint whose events to
+// include in the Subscription. Every field of the tracepoint's format is
+// decoded and returned along with the event. In order to minimize event
+// volume, a filter may be included that filters the tracepoint events based
+// on the values of those fields. Subscriptions are rejected if the
+// tracepoint does not exist in the running kernel, if the filter refers to
+// fields that are not in its format, or if it can't be registered.
+type TracepointEventFilter struct {
+	// Required; the name of the tracepoint, including its subsystem
+	// (e.g., "sched/sched_switch")
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Optional; a filter to apply to the tracepoint
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *TracepointEventFilter) Reset()                    { *m = TracepointEventFilter{} }
+func (m *TracepointEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*TracepointEventFilter) ProtoMessage()               {}
+func (*TracepointEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
+
+func (m *TracepointEventFilter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TracepointEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
 // The NetworkEventFilter specifies which network events to include in
 // the Subscription. The included filter can be used to specify
-// precisely which network events should be included.
+// precisely which network events should be included. For TCP connection
+// events, the filter expression may refer only to oldstate, newstate, sport,
+// and dport, with ports in host byte order. For example, "dport == 443"
+// limits events to connections to port 443.
 type NetworkEventFilter struct {
 	// Required; the network event type to match
 	Type NetworkEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.NetworkEventType" json:"type,omitempty"`
@@ -566,7 +980,7 @@ type NetworkEventFilter struct {
 func (m *NetworkEventFilter) Reset()                    { *m = NetworkEventFilter{} }
 func (m *NetworkEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEventFilter) ProtoMessage()               {}
-func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{16} }
 
 func (m *NetworkEventFilter) GetType() NetworkEventType {
 	if m != nil {
@@ -598,7 +1012,7 @@ type ContainerEventFilter struct {
 func (m *ContainerEventFilter) Reset()                    { *m = ContainerEventFilter{} }
 func (m *ContainerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ContainerEventFilter) ProtoMessage()               {}
-func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{17} }
 
 func (m *ContainerEventFilter) GetType() ContainerEventType {
 	if m != nil {
@@ -631,7 +1045,7 @@ type ChargenEventFilter struct {
 func (m *ChargenEventFilter) Reset()                    { *m = ChargenEventFilter{} }
 func (m *ChargenEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ChargenEventFilter) ProtoMessage()               {}
-func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{18} }
 
 func (m *ChargenEventFilter) GetLength() uint64 {
 	if m != nil {
@@ -650,7 +1064,7 @@ type TickerEventFilter struct {
 func (m *TickerEventFilter) Reset()                    { *m = TickerEventFilter{} }
 func (m *TickerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*TickerEventFilter) ProtoMessage()               {}
-func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{19} }
 
 func (m *TickerEventFilter) GetInterval() int64 {
 	if m != nil {
@@ -670,7 +1084,7 @@ type Modifier struct {
 func (m *Modifier) Reset()                    { *m = Modifier{} }
 func (m *Modifier) String() string            { return proto.CompactTextString(m) }
 func (*Modifier) ProtoMessage()               {}
-func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{20} }
 
 func (m *Modifier) GetThrottle() *ThrottleModifier {
 	if m != nil {
@@ -698,7 +1112,7 @@ type ThrottleModifier struct {
 func (m *ThrottleModifier) Reset()                    { *m = ThrottleModifier{} }
 func (m *ThrottleModifier) String() string            { return proto.CompactTextString(m) }
 func (*ThrottleModifier) ProtoMessage()               {}
-func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{21} }
 
 func (m *ThrottleModifier) GetInterval() int64 {
 	if m != nil {
@@ -725,7 +1139,7 @@ type LimitModifier struct {
 func (m *LimitModifier) Reset()                    { *m = LimitModifier{} }
 func (m *LimitModifier) String() string            { return proto.CompactTextString(m) }
 func (*LimitModifier) ProtoMessage()               {}
-func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{22} }
 
 func (m *LimitModifier) GetLimit() int64 {
 	if m != nil {
@@ -736,12 +1150,21 @@ func (m *LimitModifier) GetLimit() int64 {
 
 func init() {
 	proto.RegisterType((*Subscription)(nil), "capsule8.api.v0.Subscription")
+	proto.RegisterType((*SubscriptionModification)(nil), "capsule8.api.v0.SubscriptionModification")
 	proto.RegisterType((*ContainerFilter)(nil), "capsule8.api.v0.ContainerFilter")
 	proto.RegisterType((*EventFilter)(nil), "capsule8.api.v0.EventFilter")
 	proto.RegisterType((*SyscallEventFilter)(nil), "capsule8.api.v0.SyscallEventFilter")
 	proto.RegisterType((*ProcessEventFilter)(nil), "capsule8.api.v0.ProcessEventFilter")
 	proto.RegisterType((*FileEventFilter)(nil), "capsule8.api.v0.FileEventFilter")
+	proto.RegisterType((*CredentialsEventFilter)(nil), "capsule8.api.v0.CredentialsEventFilter")
+	proto.RegisterType((*SignalEventFilter)(nil), "capsule8.api.v0.SignalEventFilter")
+	proto.RegisterType((*NamespaceEventFilter)(nil), "capsule8.api.v0.NamespaceEventFilter")
+	proto.RegisterType((*KernelModuleEventFilter)(nil), "capsule8.api.v0.KernelModuleEventFilter")
+	proto.RegisterType((*ProcessAccessEventFilter)(nil), "capsule8.api.v0.ProcessAccessEventFilter")
+	proto.RegisterType((*MemoryEventFilter)(nil), "capsule8.api.v0.MemoryEventFilter")
+	proto.RegisterType((*FlowEventFilter)(nil), "capsule8.api.v0.FlowEventFilter")
 	proto.RegisterType((*KernelFunctionCallFilter)(nil), "capsule8.api.v0.KernelFunctionCallFilter")
+	proto.RegisterType((*TracepointEventFilter)(nil), "capsule8.api.v0.TracepointEventFilter")
 	proto.RegisterType((*NetworkEventFilter)(nil), "capsule8.api.v0.NetworkEventFilter")
 	proto.RegisterType((*ContainerEventFilter)(nil), "capsule8.api.v0.ContainerEventFilter")
 	proto.RegisterType((*ChargenEventFilter)(nil), "capsule8.api.v0.ChargenEventFilter")
@@ -756,80 +1179,109 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/subscription.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 1194 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdb, 0x6e, 0x1a, 0x47,
-	0x18, 0x0e, 0x07, 0x47, 0xf0, 0x73, 0xcc, 0xd4, 0xad, 0xa8, 0x13, 0xa5, 0xee, 0x46, 0x96, 0x92,
-	0x36, 0x05, 0x07, 0xdb, 0x8d, 0x55, 0xf5, 0xe4, 0x10, 0x93, 0xd0, 0xd8, 0x8e, 0xb5, 0xd8, 0xbe,
-	0x45, 0xeb, 0xdd, 0x01, 0x8f, 0x58, 0x76, 0x57, 0x33, 0x03, 0x36, 0x57, 0x7d, 0x8a, 0x5e, 0xb6,
-	0x8f, 0xd3, 0x07, 0xa8, 0x2a, 0xf5, 0x05, 0x7a, 0xd7, 0x97, 0xa8, 0xe6, 0xb0, 0xb0, 0xb0, 0xc1,
-	0x70, 0x11, 0xdf, 0xed, 0xfc, 0xf3, 0x7d, 0x1f, 0xff, 0x69, 0xfe, 0x19, 0xc0, 0xb0, 0xad, 0x80,
-	0x0d, 0x5d, 0xbc, 0x5f, 0xb3, 0x02, 0x52, 0x1b, 0x6d, 0xd7, 0xd8, 0xf0, 0x92, 0xd9, 0x94, 0x04,
-	0x9c, 0xf8, 0x5e, 0x35, 0xa0, 0x3e, 0xf7, 0x51, 0x29, 0xc4, 0x54, 0xad, 0x80, 0x54, 0x47, 0xdb,
-	0x1b, 0x5b, 0xf3, 0x24, 0x8e, 0x5d, 0x3c, 0xc0, 0x9c, 0x8e, 0x3b, 0x78, 0x84, 0x3d, 0xae, 0x78,
-	0x1b, 0x9b, 0xf3, 0x30, 0x7c, 0x13, 0x50, 0xcc, 0xd8, 0x44, 0x79, 0xe3, 0x71, 0xcf, 0xf7, 0x7b,
-	0x2e, 0xae, 0xc9, 0xd5, 0xe5, 0xb0, 0x5b, 0xbb, 0xa6, 0x56, 0x10, 0x60, 0xca, 0xd4, 0xbe, 0xf1,
-	0x4f, 0x12, 0xf2, 0xed, 0x88, 0x43, 0xe8, 0x27, 0xc8, 0xcb, 0x5f, 0xe8, 0x74, 0x89, 0xcb, 0x31,
-	0xad, 0x24, 0x36, 0x13, 0x4f, 0x73, 0xf5, 0x47, 0xd5, 0x39, 0x0f, 0xab, 0x87, 0x02, 0xd4, 0x94,
-	0x18, 0x33, 0x87, 0xa7, 0x0b, 0xf4, 0x0e, 0xca, 0xb6, 0xef, 0x71, 0x8b, 0x78, 0x98, 0x86, 0x22,
-	0x49, 0x29, 0xb2, 0x19, 0x13, 0x69, 0x84, 0x40, 0x2d, 0x54, 0xb2, 0x67, 0x0d, 0xe8, 0x15, 0x14,
-	0x19, 0xf1, 0x6c, 0xdc, 0x71, 0x86, 0xd4, 0x12, 0xfe, 0x55, 0x40, 0x4a, 0x3d, 0xac, 0xaa, 0xb8,
-	0xaa, 0x61, 0x5c, 0xd5, 0x96, 0xc7, 0xbf, 0xdd, 0xbd, 0xb0, 0xdc, 0x21, 0x36, 0x0b, 0x92, 0xf2,
-	0x5a, 0x33, 0xd0, 0x8f, 0x90, 0xef, 0xfa, 0x74, 0xaa, 0x90, 0x5b, 0xae, 0x90, 0xeb, 0xfa, 0x74,
-	0xc2, 0xdf, 0x83, 0xcc, 0xc0, 0x77, 0x48, 0x97, 0x60, 0x5a, 0x59, 0x97, 0xdc, 0xcf, 0x63, 0x81,
-	0x1c, 0x6b, 0x80, 0x39, 0x81, 0x1a, 0xd7, 0x50, 0x9a, 0x0b, 0x0f, 0x95, 0x21, 0x45, 0x1c, 0x56,
-	0x49, 0x6c, 0xa6, 0x9e, 0x66, 0x4d, 0xf1, 0x89, 0xd6, 0x61, 0xcd, 0xb3, 0x06, 0x98, 0x55, 0x92,
-	0xd2, 0xa6, 0x16, 0xe8, 0x21, 0x64, 0xc9, 0xc0, 0xea, 0xe1, 0x8e, 0x40, 0xa7, 0xe4, 0x4e, 0x46,
-	0x1a, 0x5a, 0x0e, 0x43, 0x5f, 0x40, 0x4e, 0x6d, 0x2a, 0x62, 0x5a, 0x6e, 0x83, 0x34, 0x9d, 0x08,
-	0x8b, 0xf1, 0x5f, 0x1a, 0x72, 0x91, 0xea, 0xa0, 0x5f, 0xa0, 0xc8, 0xc6, 0xcc, 0xb6, 0x5c, 0x57,
-	0xf5, 0x8e, 0x72, 0x20, 0x57, 0x7f, 0x12, 0x8b, 0xa2, 0xad, 0x60, 0xd1, 0xd2, 0x16, 0x58, 0xc4,
-	0xc6, 0x84, 0x56, 0x40, 0x7d, 0x1b, 0x33, 0x16, 0x6a, 0x25, 0x17, 0x68, 0x9d, 0x2a, 0xd8, 0x8c,
-	0x56, 0x10, 0xb1, 0x31, 0x74, 0x00, 0xb9, 0x2e, 0x71, 0x71, 0x28, 0x94, 0x92, 0x42, 0xf1, 0x1e,
-	0x69, 0x12, 0x17, 0x47, 0x55, 0xa0, 0x1b, 0x1a, 0x18, 0x3a, 0x81, 0x42, 0x1f, 0x53, 0x0f, 0x4f,
-	0x22, 0x4b, 0x4b, 0x91, 0x67, 0x31, 0x91, 0x77, 0x12, 0xd5, 0x1c, 0x7a, 0xb6, 0x28, 0x69, 0xc3,
-	0x72, 0x5d, 0xad, 0x96, 0x57, 0xfc, 0x69, 0x78, 0x1e, 0xe6, 0xd7, 0x3e, 0xed, 0x87, 0x82, 0x6b,
-	0x0b, 0xc2, 0x3b, 0x51, 0xb0, 0x99, 0xf0, 0xbc, 0x88, 0x8d, 0xa1, 0xd3, 0xe8, 0x39, 0xd0, 0x6a,
-	0x20, 0xd5, 0xb6, 0x16, 0x9f, 0x83, 0xa8, 0xde, 0xf4, 0x30, 0x4c, 0xbd, 0xb3, 0xaf, 0x2c, 0xda,
-	0xc3, 0x5e, 0xa8, 0xe7, 0x2c, 0xf0, 0xae, 0xa1, 0x60, 0x33, 0xde, 0xd9, 0x11, 0x1b, 0x43, 0x6f,
-	0xa0, 0xc0, 0x89, 0xdd, 0x9f, 0xba, 0x86, 0xa5, 0x94, 0x11, 0x93, 0x3a, 0x93, 0xa8, 0xa8, 0x52,
-	0x9e, 0x4f, 0x4d, 0xcc, 0xf8, 0x3d, 0x0d, 0x28, 0xde, 0x37, 0x68, 0x0f, 0xd2, 0x7c, 0x1c, 0x60,
-	0x39, 0x3e, 0x8a, 0xf5, 0x2f, 0x6f, 0x6d, 0xb5, 0xb3, 0x71, 0x80, 0x4d, 0x09, 0x47, 0x6f, 0xe1,
-	0x81, 0x1a, 0x19, 0x9d, 0xe9, 0x24, 0xab, 0x38, 0xfa, 0xc0, 0xc6, 0x46, 0xd0, 0x04, 0x62, 0x96,
-	0x15, 0x6b, 0x6a, 0x41, 0x5f, 0x43, 0x92, 0x38, 0x7a, 0xf0, 0xdc, 0x7a, 0xd6, 0x93, 0xc4, 0x41,
-	0xdb, 0x90, 0xb6, 0x68, 0x6f, 0x5b, 0x0f, 0x97, 0x47, 0x31, 0xf8, 0x79, 0x04, 0x2f, 0x91, 0x9a,
-	0xf1, 0x42, 0x0f, 0x93, 0xe5, 0x8c, 0x17, 0x9a, 0x51, 0xaf, 0xe4, 0x57, 0x64, 0xd4, 0x35, 0x63,
-	0xa7, 0x52, 0x58, 0x91, 0xb1, 0xa3, 0x19, 0xbb, 0x95, 0xe2, 0x8a, 0x8c, 0x5d, 0xcd, 0xd8, 0xab,
-	0x94, 0x56, 0x64, 0xec, 0xa1, 0x6f, 0x20, 0x45, 0x31, 0xd7, 0x93, 0xf0, 0xd6, 0xcc, 0x0a, 0x9c,
-	0xf1, 0x6f, 0x12, 0x50, 0x7c, 0x16, 0x2c, 0xed, 0x8f, 0x28, 0xe5, 0x4e, 0xfa, 0xe3, 0x00, 0x0a,
-	0xf8, 0x06, 0xdb, 0xe2, 0x86, 0xc2, 0x62, 0x92, 0x2e, 0xac, 0x4b, 0x9b, 0x53, 0xe2, 0xf5, 0x54,
-	0x44, 0x79, 0x41, 0x69, 0x6a, 0x06, 0x3a, 0x85, 0x4f, 0x67, 0x24, 0x3a, 0x81, 0xc5, 0x39, 0xa6,
-	0xde, 0xc2, 0x82, 0x45, 0xa5, 0x3e, 0x89, 0x4a, 0x9d, 0x2a, 0x22, 0xda, 0x87, 0x2c, 0xbe, 0x21,
-	0xbc, 0x63, 0xfb, 0x0e, 0xd6, 0x45, 0xfc, 0x60, 0x86, 0x77, 0xea, 0x4a, 0x24, 0x23, 0xd0, 0x0d,
-	0xdf, 0xc1, 0xc6, 0x1f, 0x29, 0x28, 0xcd, 0x4d, 0x4a, 0x54, 0x9f, 0xc9, 0xf1, 0xe3, 0xc5, 0x93,
-	0xf5, 0x4e, 0x12, 0xbc, 0x0f, 0x99, 0x49, 0x6e, 0x61, 0x85, 0x84, 0x4c, 0xd0, 0xe8, 0x0d, 0x94,
-	0x63, 0x29, 0xcd, 0xad, 0xa0, 0x50, 0xea, 0xce, 0xa5, 0xb3, 0x01, 0x25, 0x3f, 0xc0, 0x5e, 0xa7,
-	0xeb, 0x5a, 0x3d, 0xd6, 0x19, 0x58, 0xac, 0xaf, 0xab, 0x7c, 0x6b, 0x52, 0x0b, 0x82, 0xd3, 0x14,
-	0x94, 0x63, 0x8b, 0xf5, 0xd1, 0x21, 0x94, 0x6d, 0x8a, 0x2d, 0x8e, 0x3b, 0x03, 0xdf, 0xc1, 0x4a,
-	0xa5, 0xb0, 0x5c, 0xa5, 0xa8, 0x48, 0xc7, 0xbe, 0x83, 0x85, 0x8c, 0xf1, 0x77, 0x12, 0x2a, 0x8b,
-	0x6e, 0x21, 0xf4, 0xf3, 0x4c, 0xa5, 0x9e, 0xaf, 0x70, 0x7d, 0xcd, 0xd7, 0xed, 0x33, 0xb8, 0xcf,
-	0xc6, 0x83, 0x4b, 0xdf, 0x95, 0xb9, 0xce, 0x9a, 0x7a, 0x85, 0x2e, 0x20, 0x6b, 0xd1, 0xde, 0x70,
-	0x20, 0x67, 0x7c, 0x4e, 0xce, 0xf8, 0xfd, 0x95, 0x6f, 0xc7, 0xea, 0x41, 0x48, 0x3d, 0xf4, 0x38,
-	0x1d, 0x9b, 0x53, 0xa9, 0x8f, 0xd7, 0x27, 0x1b, 0xdf, 0x43, 0x71, 0xf6, 0x67, 0xc4, 0x33, 0xa9,
-	0x8f, 0xc7, 0x32, 0x19, 0x59, 0x53, 0x7c, 0x8a, 0x67, 0xd2, 0x48, 0x64, 0x55, 0xce, 0xf3, 0xac,
-	0xa9, 0x16, 0xdf, 0x25, 0xf7, 0x13, 0xc6, 0x6f, 0x09, 0x40, 0xf1, 0xbb, 0x78, 0xe9, 0x78, 0x89,
-	0x52, 0xee, 0xa2, 0xfb, 0x8d, 0xbf, 0x12, 0xb0, 0xfe, 0xa1, 0x5b, 0x1d, 0xbd, 0x9c, 0xf1, 0xec,
-	0xc9, 0x92, 0xa7, 0x40, 0xc4, 0xb7, 0x97, 0x90, 0x1e, 0x11, 0x7c, 0x2d, 0x53, 0xb0, 0x9c, 0x78,
-	0x41, 0xf0, 0xb5, 0x29, 0x09, 0x1f, 0x31, 0xa8, 0xe7, 0x80, 0xe2, 0x2f, 0x0b, 0xd1, 0x7a, 0x2e,
-	0xf6, 0x7a, 0xfc, 0x4a, 0xc6, 0x94, 0x36, 0xf5, 0xca, 0xa8, 0xc1, 0x83, 0xd8, 0xe3, 0x01, 0x6d,
-	0x40, 0x86, 0x78, 0x1c, 0xd3, 0x91, 0xe5, 0x4a, 0x78, 0xca, 0x9c, 0xac, 0x8d, 0x5f, 0x21, 0x13,
-	0xbe, 0xa3, 0xd1, 0x0f, 0x90, 0xe1, 0x57, 0xd4, 0xe7, 0xdc, 0xc5, 0xfa, 0x2f, 0x48, 0xbc, 0x88,
-	0x67, 0x1a, 0x30, 0x7d, 0x7c, 0x87, 0x14, 0xb4, 0x0b, 0x6b, 0x2e, 0x19, 0x10, 0xae, 0x1f, 0x00,
-	0xf1, 0xd9, 0x77, 0x24, 0x76, 0x27, 0x44, 0x05, 0x36, 0xfe, 0x4c, 0x40, 0x79, 0x5e, 0xf4, 0x36,
-	0x8f, 0x51, 0x1b, 0x0a, 0xe1, 0x77, 0x47, 0x56, 0x55, 0x15, 0xa7, 0xba, 0xd4, 0x55, 0x31, 0x29,
-	0x24, 0x4d, 0x16, 0x38, 0x4f, 0x22, 0x2b, 0xe3, 0x00, 0xf2, 0xd1, 0x5d, 0x54, 0x82, 0xdc, 0x71,
-	0xeb, 0xe8, 0xa8, 0xd5, 0x3e, 0x6c, 0xbc, 0x3f, 0x79, 0x5d, 0xbe, 0x87, 0x00, 0xee, 0xeb, 0xef,
-	0x84, 0xf8, 0x3e, 0x6e, 0x9d, 0x9c, 0x9f, 0x1d, 0x96, 0x93, 0x28, 0x03, 0xe9, 0xb7, 0xef, 0xcf,
-	0xcd, 0x72, 0xca, 0xd8, 0x82, 0xc2, 0x4c, 0x80, 0xe2, 0x00, 0xa9, 0x7c, 0xa8, 0x08, 0xd4, 0xe2,
-	0xab, 0x67, 0x80, 0xe2, 0x5d, 0x83, 0xb2, 0xb0, 0xf6, 0xea, 0xa0, 0xdd, 0x6a, 0x94, 0xef, 0x09,
-	0xc5, 0xe6, 0xf9, 0xd1, 0x51, 0x39, 0x71, 0x79, 0x5f, 0xce, 0xb8, 0x9d, 0xff, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0xf7, 0x49, 0x8a, 0x4a, 0xce, 0x0e, 0x00, 0x00,
+	// 1660 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4d, 0x53, 0xdb, 0x48,
+	0x1a, 0x8e, 0x6c, 0xc3, 0xda, 0xaf, 0x3f, 0x69, 0x48, 0x56, 0x4b, 0x52, 0x59, 0xaf, 0xb2, 0x24,
+	0x24, 0x9b, 0x18, 0x62, 0x60, 0x43, 0x65, 0x3f, 0x09, 0x81, 0x84, 0x8d, 0x21, 0x94, 0x0c, 0x1c,
+	0xb6, 0x6a, 0xcb, 0xd5, 0x48, 0x6d, 0xa3, 0x42, 0x96, 0x54, 0xea, 0x36, 0xe0, 0x5c, 0xf6, 0x37,
+	0x4c, 0x4d, 0xcd, 0x61, 0x0e, 0x33, 0x53, 0xf3, 0x67, 0xe6, 0x07, 0x4c, 0xcd, 0x75, 0x6e, 0xf3,
+	0x2b, 0xe6, 0x34, 0xa5, 0x6e, 0xc9, 0x92, 0x2c, 0x8c, 0x3d, 0x53, 0xf8, 0xa6, 0x7e, 0xfb, 0x79,
+	0x1e, 0xf7, 0xfb, 0xd1, 0xdd, 0x6f, 0x1b, 0x14, 0x0d, 0x3b, 0xb4, 0x67, 0x92, 0xcd, 0x15, 0xec,
+	0x18, 0x2b, 0x17, 0xab, 0x2b, 0xb4, 0x77, 0x4a, 0x35, 0xd7, 0x70, 0x98, 0x61, 0x5b, 0x35, 0xc7,
+	0xb5, 0x99, 0x8d, 0xca, 0x01, 0xa6, 0x86, 0x1d, 0xa3, 0x76, 0xb1, 0xba, 0xb8, 0x34, 0x4c, 0x62,
+	0xc4, 0x24, 0x5d, 0xc2, 0xdc, 0x7e, 0x8b, 0x5c, 0x10, 0x8b, 0x09, 0xde, 0x62, 0x75, 0x18, 0x46,
+	0xae, 0x1c, 0x97, 0x50, 0x3a, 0x50, 0x5e, 0x7c, 0xd8, 0xb1, 0xed, 0x8e, 0x49, 0x56, 0xf8, 0xe8,
+	0xb4, 0xd7, 0x5e, 0xb9, 0x74, 0xb1, 0xe3, 0x10, 0x97, 0x8a, 0x79, 0xe5, 0xc7, 0x34, 0x14, 0x9a,
+	0x91, 0x05, 0xa1, 0x7f, 0x41, 0x81, 0xff, 0x42, 0xab, 0x6d, 0x98, 0x8c, 0xb8, 0xb2, 0x54, 0x95,
+	0x96, 0xf3, 0xf5, 0x07, 0xb5, 0xa1, 0x15, 0xd6, 0x76, 0x3c, 0xd0, 0x2e, 0xc7, 0xa8, 0x79, 0x12,
+	0x0e, 0xd0, 0x07, 0xa8, 0x68, 0xb6, 0xc5, 0xb0, 0x61, 0x11, 0x37, 0x10, 0x49, 0x71, 0x91, 0x6a,
+	0x42, 0x64, 0x3b, 0x00, 0xfa, 0x42, 0x65, 0x2d, 0x6e, 0x40, 0x6f, 0xa0, 0x44, 0x0d, 0x4b, 0x23,
+	0x2d, 0xbd, 0xe7, 0x62, 0x6f, 0x7d, 0x32, 0x70, 0xa9, 0xfb, 0x35, 0xe1, 0x57, 0x2d, 0xf0, 0xab,
+	0xb6, 0x67, 0xb1, 0xbf, 0xae, 0x9f, 0x60, 0xb3, 0x47, 0xd4, 0x22, 0xa7, 0xbc, 0xf5, 0x19, 0xe8,
+	0x9f, 0x50, 0x68, 0xdb, 0x6e, 0xa8, 0x90, 0x1f, 0xaf, 0x90, 0x6f, 0xdb, 0xee, 0x80, 0x5f, 0x87,
+	0xbb, 0x8e, 0x6b, 0x6b, 0x84, 0xd2, 0x96, 0x69, 0x58, 0x04, 0x77, 0x48, 0x4b, 0x27, 0x0e, 0x3b,
+	0x93, 0x0b, 0x55, 0x69, 0xb9, 0xa8, 0xce, 0xfb, 0x93, 0x0d, 0x31, 0xf7, 0xd6, 0x9b, 0x42, 0x7f,
+	0x86, 0x52, 0x17, 0x5f, 0xb5, 0x4e, 0x31, 0xd3, 0xce, 0x5a, 0xd4, 0xf8, 0x44, 0xe4, 0x22, 0x07,
+	0x17, 0xba, 0xf8, 0xea, 0x8d, 0x67, 0x6c, 0x1a, 0x9f, 0x08, 0x7a, 0x06, 0x73, 0x21, 0xca, 0xc4,
+	0x8c, 0x58, 0x5a, 0x5f, 0x2e, 0x55, 0xa5, 0xe5, 0xb4, 0x5a, 0x0e, 0x80, 0x0d, 0x61, 0x46, 0x1b,
+	0x90, 0xed, 0xda, 0xba, 0xd1, 0x36, 0x88, 0x2b, 0x2f, 0x70, 0x0f, 0xfe, 0x90, 0x08, 0xe7, 0xbe,
+	0x0f, 0x50, 0x07, 0x50, 0xe5, 0xb3, 0x34, 0xc8, 0xd1, 0xfc, 0x0a, 0x88, 0x26, 0x3c, 0xdb, 0x85,
+	0x0a, 0xd6, 0xf5, 0xd6, 0xaf, 0xce, 0x77, 0x09, 0xeb, 0x7a, 0x64, 0x8c, 0x1a, 0x30, 0xef, 0x92,
+	0xae, 0x7d, 0x41, 0xe2, 0x52, 0xa9, 0x09, 0xa4, 0xe6, 0x04, 0x71, 0x67, 0x4c, 0x01, 0xa5, 0x7f,
+	0x6b, 0x01, 0xad, 0xc3, 0x3d, 0xcd, 0x24, 0xd8, 0x6d, 0x25, 0x24, 0x33, 0x55, 0x69, 0x39, 0xab,
+	0x2e, 0xf0, 0xd9, 0x21, 0x99, 0x58, 0xb0, 0x67, 0x26, 0x0e, 0x36, 0x5a, 0x82, 0x92, 0xf8, 0xb1,
+	0x01, 0x79, 0x96, 0xff, 0x48, 0x91, 0x5b, 0x03, 0x82, 0x72, 0x09, 0xe5, 0xe1, 0x1f, 0xac, 0x40,
+	0xda, 0xd0, 0xa9, 0x2c, 0x55, 0xd3, 0xcb, 0x39, 0xd5, 0xfb, 0x44, 0x0b, 0x30, 0x63, 0xe1, 0x2e,
+	0xa1, 0x72, 0x8a, 0xdb, 0xc4, 0x00, 0xdd, 0x87, 0x9c, 0xd1, 0xf5, 0x2a, 0xd0, 0x43, 0xa7, 0xf9,
+	0x4c, 0x96, 0x1b, 0xf6, 0x74, 0x8a, 0xfe, 0x08, 0x79, 0x31, 0x29, 0x88, 0x19, 0x3e, 0x0d, 0xdc,
+	0x74, 0xe0, 0x59, 0x94, 0x9f, 0x73, 0x90, 0x8f, 0x46, 0xfa, 0x3f, 0x50, 0xa2, 0x7d, 0xaa, 0x61,
+	0xd3, 0x14, 0x89, 0x13, 0x0b, 0xc8, 0xd7, 0x1f, 0x25, 0x9c, 0x6d, 0x0a, 0x58, 0x34, 0x73, 0x45,
+	0x1a, 0xb1, 0x51, 0x4f, 0x2b, 0xd8, 0x25, 0xbe, 0x56, 0x6a, 0x84, 0xd6, 0xa1, 0x80, 0xc5, 0xb4,
+	0x9c, 0x88, 0x8d, 0xa2, 0x2d, 0xc8, 0xb7, 0x0d, 0x93, 0x04, 0x42, 0xe9, 0x6a, 0xfa, 0xda, 0xe4,
+	0xef, 0x1a, 0x66, 0xb4, 0x70, 0x54, 0x68, 0x07, 0x06, 0x8a, 0x0e, 0xa0, 0x78, 0x4e, 0x5c, 0x8b,
+	0x0c, 0x3c, 0xcb, 0x70, 0x91, 0xa7, 0x09, 0x91, 0x0f, 0x1c, 0xb5, 0xdb, 0xb3, 0x34, 0x6f, 0x4b,
+	0x6c, 0x63, 0xd3, 0xf4, 0xd5, 0x0a, 0x82, 0x1f, 0xba, 0x67, 0x11, 0x76, 0x69, 0xbb, 0xe7, 0x81,
+	0xe0, 0xcc, 0x08, 0xf7, 0x0e, 0x04, 0x2c, 0xe6, 0x9e, 0x15, 0xb1, 0x51, 0x74, 0x02, 0x48, 0x73,
+	0x89, 0x4e, 0x2c, 0x66, 0x60, 0x73, 0x10, 0xae, 0x59, 0xae, 0xf7, 0x24, 0x59, 0xe2, 0x21, 0x34,
+	0xb6, 0x71, 0xb4, 0x21, 0x3b, 0x45, 0xef, 0xa0, 0x48, 0x8d, 0x8e, 0x85, 0x07, 0x3e, 0xff, 0x8e,
+	0x4b, 0x2a, 0xc9, 0x6c, 0x72, 0x54, 0x54, 0xad, 0x40, 0x43, 0x13, 0x45, 0x87, 0x50, 0xe1, 0x25,
+	0xe4, 0x60, 0x6d, 0x90, 0x84, 0x2c, 0xd7, 0x5a, 0x4a, 0xba, 0x1b, 0x00, 0xa3, 0x72, 0x65, 0x2b,
+	0x66, 0xa5, 0xe8, 0xbf, 0xb0, 0xe0, 0xa7, 0xa3, 0x6b, 0xeb, 0xbd, 0x30, 0xb5, 0x39, 0xae, 0xba,
+	0x3c, 0x22, 0x2b, 0xfb, 0x1c, 0x1b, 0x15, 0x46, 0xe7, 0xc3, 0x13, 0x14, 0xfd, 0x2f, 0x3c, 0x9f,
+	0xb1, 0x16, 0x2d, 0xc0, 0xfc, 0x88, 0x94, 0xfb, 0x05, 0xb8, 0xa5, 0x0d, 0x97, 0xe1, 0xbc, 0x93,
+	0x98, 0xe1, 0x51, 0xed, 0x92, 0xae, 0x1d, 0xdc, 0xbc, 0x54, 0x2e, 0x8c, 0x88, 0xea, 0x3e, 0x47,
+	0xc5, 0xa2, 0xda, 0x0d, 0x4d, 0xa2, 0xaa, 0x4d, 0xfb, 0x32, 0x90, 0x29, 0x8e, 0xaa, 0x6a, 0xd3,
+	0xbe, 0x8c, 0x57, 0x75, 0x60, 0xa0, 0xa8, 0x09, 0x73, 0xcc, 0xc5, 0x1a, 0x71, 0x6c, 0xc3, 0x62,
+	0x81, 0x50, 0x89, 0x0b, 0x3d, 0x4e, 0x08, 0x1d, 0x0d, 0x90, 0x51, 0xb9, 0x0a, 0x8b, 0x9b, 0x79,
+	0xb6, 0xc3, 0xc3, 0xd1, 0xd7, 0x84, 0x11, 0xd9, 0x1e, 0x9c, 0x5b, 0xb1, 0x6c, 0x6b, 0x31, 0x2b,
+	0xdf, 0x2c, 0xda, 0x19, 0x76, 0x3b, 0xc4, 0x0a, 0xf4, 0xf4, 0x11, 0x9b, 0x65, 0x5b, 0xc0, 0x62,
+	0x9b, 0x45, 0x8b, 0xd8, 0x78, 0xf8, 0x99, 0xa1, 0x9d, 0x87, 0x4b, 0x23, 0x23, 0xc2, 0x7f, 0xc4,
+	0x51, 0xb1, 0xf0, 0xb3, 0xd0, 0x44, 0x95, 0xaf, 0x32, 0x80, 0x92, 0xc7, 0x18, 0xda, 0x80, 0x0c,
+	0xeb, 0x3b, 0x84, 0xdf, 0x7b, 0xa5, 0xfa, 0x9f, 0x6e, 0x3c, 0xf9, 0x8e, 0xfa, 0x0e, 0x51, 0x39,
+	0x1c, 0xbd, 0x87, 0x39, 0x71, 0x8f, 0xb4, 0xc2, 0x96, 0x4b, 0xd6, 0xfd, 0xce, 0x22, 0x71, 0xe1,
+	0x0d, 0x20, 0x6a, 0x45, 0xb0, 0x42, 0x0b, 0xfa, 0x0b, 0xa4, 0x0c, 0x5d, 0x4e, 0x8d, 0x6f, 0x4a,
+	0x52, 0x86, 0x8e, 0x56, 0x21, 0x83, 0xdd, 0xce, 0xaa, 0xdf, 0x05, 0x3d, 0x48, 0xc0, 0x8f, 0x23,
+	0x78, 0x8e, 0xf4, 0x19, 0x2f, 0xe5, 0xfc, 0x84, 0x8c, 0x97, 0x3e, 0xa3, 0x2e, 0x17, 0x26, 0x64,
+	0xd4, 0x7d, 0xc6, 0x9a, 0x5c, 0x9c, 0x90, 0xb1, 0xe6, 0x33, 0xd6, 0xe5, 0xd2, 0x84, 0x8c, 0x75,
+	0x9f, 0xb1, 0x21, 0x97, 0x27, 0x64, 0x6c, 0xa0, 0x17, 0x90, 0x76, 0x09, 0x93, 0x17, 0xc6, 0x47,
+	0xd6, 0xc3, 0x29, 0x3f, 0xa5, 0x00, 0x25, 0xaf, 0xa6, 0xb1, 0xf5, 0x11, 0xa5, 0x4c, 0xa5, 0x3e,
+	0xb6, 0xa0, 0x48, 0xae, 0x88, 0xe6, 0xb5, 0x2d, 0xc4, 0x3b, 0x56, 0x47, 0xe6, 0xa5, 0xc9, 0x5c,
+	0xc3, 0xea, 0x08, 0x8f, 0x0a, 0x1e, 0x65, 0xd7, 0x67, 0xa0, 0x43, 0xb8, 0x1b, 0x93, 0x68, 0x39,
+	0x98, 0x31, 0xe2, 0x5a, 0x72, 0x71, 0x02, 0xa9, 0xf9, 0xa8, 0xd4, 0xa1, 0x20, 0xa2, 0x4d, 0xc8,
+	0x91, 0x2b, 0x83, 0xb5, 0x34, 0x5b, 0x27, 0x72, 0x69, 0x74, 0x84, 0xd7, 0xea, 0x42, 0x24, 0xeb,
+	0xa1, 0xb7, 0x6d, 0x9d, 0x28, 0x5f, 0xa7, 0xa1, 0x3c, 0x74, 0x71, 0xa3, 0x7a, 0x2c, 0xc6, 0x0f,
+	0x47, 0x5f, 0xf4, 0x53, 0x09, 0xf0, 0x26, 0x64, 0x07, 0xb1, 0x85, 0x09, 0x02, 0x32, 0x40, 0xa3,
+	0x77, 0x50, 0x49, 0x84, 0x34, 0x3f, 0x81, 0x42, 0xb9, 0x3d, 0x14, 0xce, 0x6d, 0x28, 0xdb, 0x0e,
+	0xb1, 0x5a, 0x6d, 0x13, 0x77, 0x68, 0xab, 0x8b, 0xe9, 0xb9, 0x5c, 0x18, 0x1f, 0xd4, 0xa2, 0xc7,
+	0xd9, 0xf5, 0x28, 0xfb, 0x98, 0x9e, 0xa3, 0x1d, 0xa8, 0x68, 0x2e, 0xc1, 0x8c, 0x78, 0x77, 0x2c,
+	0x11, 0x2a, 0xc5, 0xf1, 0x2a, 0x25, 0x41, 0xda, 0xb7, 0x75, 0xe2, 0xc9, 0x28, 0xa7, 0x70, 0xef,
+	0xfa, 0x96, 0xe3, 0xf6, 0x42, 0xae, 0x7c, 0x2e, 0xc1, 0x5c, 0xa2, 0x09, 0x41, 0xeb, 0xb1, 0x32,
+	0xa8, 0xde, 0xd4, 0xb6, 0x4c, 0xa3, 0x10, 0x94, 0x2f, 0x25, 0x58, 0xb8, 0xae, 0x9d, 0x41, 0xaf,
+	0x62, 0x0b, 0x7b, 0x34, 0xa6, 0x07, 0x9a, 0xca, 0xda, 0xbe, 0x91, 0xe0, 0xf7, 0x23, 0x9a, 0x22,
+	0xf4, 0x3a, 0xb6, 0xbc, 0xc7, 0xe3, 0x9b, 0xa9, 0xa9, 0xac, 0xf0, 0x5b, 0x09, 0xe4, 0x51, 0x9d,
+	0x15, 0xfa, 0x5b, 0x6c, 0x89, 0x4f, 0x26, 0x68, 0xc9, 0xa6, 0xb2, 0x46, 0xaf, 0xee, 0x12, 0x6d,
+	0xda, 0xd8, 0xba, 0x8b, 0x30, 0xa6, 0xb2, 0xaa, 0x17, 0x50, 0x1e, 0x6a, 0xfa, 0xd0, 0x22, 0x64,
+	0x0d, 0x8b, 0x11, 0xf7, 0x02, 0x9b, 0x7c, 0x59, 0x69, 0x75, 0x30, 0x56, 0x7e, 0x48, 0x81, 0x3c,
+	0xea, 0xd5, 0x82, 0xfe, 0x1d, 0xf3, 0xe5, 0xf9, 0x04, 0xcf, 0x9d, 0x61, 0xbf, 0xee, 0xc1, 0x2c,
+	0xed, 0x77, 0x4f, 0x6d, 0x93, 0x1f, 0x86, 0x39, 0xd5, 0x1f, 0xa1, 0x13, 0xc8, 0x61, 0xb7, 0xd3,
+	0xeb, 0x46, 0x5a, 0xeb, 0xcd, 0x89, 0x5f, 0x53, 0xb5, 0xad, 0x80, 0xba, 0x63, 0x31, 0xb7, 0xaf,
+	0x86, 0x52, 0xb7, 0x17, 0xc7, 0xc5, 0xbf, 0x43, 0x29, 0xfe, 0x33, 0xde, 0xb3, 0xfa, 0x9c, 0xf4,
+	0x79, 0x30, 0x72, 0xaa, 0xf7, 0xe9, 0x3d, 0xab, 0x2f, 0xbc, 0x63, 0x8f, 0x37, 0x5c, 0x39, 0x55,
+	0x0c, 0x5e, 0xa7, 0x36, 0x25, 0xa5, 0x07, 0x77, 0xaf, 0xed, 0x98, 0x11, 0x82, 0x0c, 0xbf, 0x1b,
+	0x84, 0x0a, 0xff, 0xbe, 0xc5, 0xe4, 0x7f, 0x21, 0x01, 0x4a, 0x3e, 0x19, 0xc7, 0xb6, 0x1d, 0x51,
+	0xca, 0x54, 0x8a, 0xf2, 0x7b, 0x09, 0x16, 0xae, 0xeb, 0xf6, 0xc7, 0x1e, 0x86, 0x71, 0x52, 0x64,
+	0x6d, 0xaf, 0x20, 0x73, 0x61, 0x90, 0x4b, 0x39, 0x35, 0x11, 0xf1, 0xc4, 0x20, 0x97, 0x2a, 0x27,
+	0xdc, 0xa2, 0x53, 0xcf, 0x01, 0x25, 0x5f, 0x1c, 0x5e, 0xc5, 0x9b, 0xc4, 0xea, 0xb0, 0x33, 0xee,
+	0x53, 0x46, 0xf5, 0x47, 0xca, 0x0a, 0xcc, 0x25, 0x1e, 0x15, 0x37, 0xee, 0xcc, 0xff, 0x43, 0x36,
+	0xf8, 0x93, 0x07, 0xfd, 0x03, 0xb2, 0xec, 0xcc, 0xb5, 0x19, 0x33, 0x89, 0xff, 0x9f, 0x5a, 0x32,
+	0x89, 0x47, 0x3e, 0x20, 0xfc, 0x2b, 0x29, 0xa0, 0xa0, 0x75, 0x98, 0x31, 0x8d, 0xae, 0xc1, 0xfc,
+	0x87, 0x41, 0xb2, 0x27, 0x6a, 0x78, 0xb3, 0x03, 0xa2, 0x00, 0x2b, 0xdf, 0x49, 0x50, 0x19, 0x16,
+	0xbd, 0x69, 0xc5, 0xa8, 0x09, 0xc5, 0xe0, 0xbb, 0xc5, 0xb3, 0x2a, 0x92, 0x53, 0x1b, 0xbb, 0xd4,
+	0xda, 0x9e, 0x4f, 0xe3, 0x09, 0x2e, 0x18, 0x91, 0x91, 0xb2, 0x05, 0x85, 0xe8, 0x2c, 0x2a, 0x43,
+	0x7e, 0x7f, 0xaf, 0xd1, 0xd8, 0x6b, 0xee, 0x6c, 0x7f, 0x3c, 0x78, 0x5b, 0xb9, 0x83, 0x00, 0x66,
+	0xfd, 0x6f, 0xc9, 0xfb, 0xde, 0xdf, 0x3b, 0x38, 0x3e, 0xda, 0xa9, 0xa4, 0x50, 0x16, 0x32, 0xef,
+	0x3f, 0x1e, 0xab, 0x95, 0xb4, 0xb2, 0x04, 0xc5, 0x98, 0x83, 0xde, 0xbe, 0x15, 0xf1, 0x10, 0x1e,
+	0x88, 0xc1, 0xb3, 0xa7, 0x80, 0x92, 0x55, 0x83, 0x72, 0x30, 0xf3, 0x66, 0xab, 0xb9, 0xb7, 0x5d,
+	0xb9, 0xe3, 0x29, 0xee, 0x1e, 0x37, 0x1a, 0x15, 0xe9, 0x74, 0x96, 0xf7, 0x3e, 0x6b, 0xbf, 0x04,
+	0x00, 0x00, 0xff, 0xff, 0x44, 0x35, 0x38, 0xb5, 0x8f, 0x17, 0x00, 0x00,
 }
@@ -12,6 +12,33 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+type LostEventType int32
+
+const (
+	LostEventType_LOST_EVENT_TYPE_UNKNOWN LostEventType = 0
+	// The kernel discarded events because a sensor ring buffer was full
+	LostEventType_LOST_EVENT_TYPE_RING_BUFFER LostEventType = 1
+	// The sensor discarded unacknowledged events because the delivery
+	// spool of a reliable delivery subscription was full
+	LostEventType_LOST_EVENT_TYPE_DELIVERY_SPOOL LostEventType = 2
+)
+
+var LostEventType_name = map[int32]string{
+	0: "LOST_EVENT_TYPE_UNKNOWN",
+	1: "LOST_EVENT_TYPE_RING_BUFFER",
+	2: "LOST_EVENT_TYPE_DELIVERY_SPOOL",
+}
+var LostEventType_value = map[string]int32{
+	"LOST_EVENT_TYPE_UNKNOWN":        0,
+	"LOST_EVENT_TYPE_RING_BUFFER":    1,
+	"LOST_EVENT_TYPE_DELIVERY_SPOOL": 2,
+}
+
+func (x LostEventType) String() string {
+	return proto.EnumName(LostEventType_name, int32(x))
+}
+func (LostEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
 type ContainerEventType int32
 
 const (
@@ -40,7 +67,7 @@ var ContainerEventType_value = map[string]int32{
 func (x ContainerEventType) String() string {
 	return proto.EnumName(ContainerEventType_name, int32(x))
 }
-func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
 
 // Possible ProcessEvent types
 type ProcessEventType int32
@@ -72,7 +99,7 @@ var ProcessEventType_value = map[string]int32{
 func (x ProcessEventType) String() string {
 	return proto.EnumName(ProcessEventType_name, int32(x))
 }
-func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
 // Possible SyscallEvent types
 type SyscallEventType int32
@@ -100,7 +127,7 @@ var SyscallEventType_value = map[string]int32{
 func (x SyscallEventType) String() string {
 	return proto.EnumName(SyscallEventType_name, int32(x))
 }
-func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
 
 // Possible FileEvent types
 type FileEventType int32
@@ -110,21 +137,234 @@ const (
 	FileEventType_FILE_EVENT_TYPE_UNKNOWN FileEventType = 0
 	// The event is a file open event
 	FileEventType_FILE_EVENT_TYPE_OPEN FileEventType = 1
+	// The event is a file or directory being removed by unlink(2),
+	// unlinkat(2), or rmdir(2)
+	FileEventType_FILE_EVENT_TYPE_UNLINK FileEventType = 2
+	// The event is a file being renamed by rename(2), renameat(2), or
+	// renameat2(2)
+	FileEventType_FILE_EVENT_TYPE_RENAME FileEventType = 3
+	// The event is a change of file permissions by chmod(2) or
+	// fchmodat(2). Changes made by fchmod(2) to an open file are not
+	// reported, because there is no filename to report.
+	FileEventType_FILE_EVENT_TYPE_CHMOD FileEventType = 4
+	// The event is a change of file ownership by chown(2), lchown(2), or
+	// fchownat(2). lchown(2) is reported with AT_SYMLINK_NOFOLLOW set in
+	// flags. Changes made by fchown(2) to an open file are not reported,
+	// because there is no filename to report.
+	FileEventType_FILE_EVENT_TYPE_CHOWN FileEventType = 5
+	// The event is a directory being created by mkdir(2) or mkdirat(2)
+	FileEventType_FILE_EVENT_TYPE_MKDIR FileEventType = 6
+	// The event is a hard link being created by link(2) or linkat(2)
+	FileEventType_FILE_EVENT_TYPE_LINK FileEventType = 7
+	// The event is a symbolic link being created by symlink(2) or
+	// symlinkat(2)
+	FileEventType_FILE_EVENT_TYPE_SYMLINK FileEventType = 8
 )
 
 var FileEventType_name = map[int32]string{
 	0: "FILE_EVENT_TYPE_UNKNOWN",
 	1: "FILE_EVENT_TYPE_OPEN",
+	2: "FILE_EVENT_TYPE_UNLINK",
+	3: "FILE_EVENT_TYPE_RENAME",
+	4: "FILE_EVENT_TYPE_CHMOD",
+	5: "FILE_EVENT_TYPE_CHOWN",
+	6: "FILE_EVENT_TYPE_MKDIR",
+	7: "FILE_EVENT_TYPE_LINK",
+	8: "FILE_EVENT_TYPE_SYMLINK",
 }
 var FileEventType_value = map[string]int32{
 	"FILE_EVENT_TYPE_UNKNOWN": 0,
 	"FILE_EVENT_TYPE_OPEN":    1,
+	"FILE_EVENT_TYPE_UNLINK":  2,
+	"FILE_EVENT_TYPE_RENAME":  3,
+	"FILE_EVENT_TYPE_CHMOD":   4,
+	"FILE_EVENT_TYPE_CHOWN":   5,
+	"FILE_EVENT_TYPE_MKDIR":   6,
+	"FILE_EVENT_TYPE_LINK":    7,
+	"FILE_EVENT_TYPE_SYMLINK": 8,
 }
 
 func (x FileEventType) String() string {
 	return proto.EnumName(FileEventType_name, int32(x))
 }
-func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+// Possible SignalEvent types
+type SignalEventType int32
+
+const (
+	SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN SignalEventType = 0
+	// A signal was sent to a process
+	SignalEventType_SIGNAL_EVENT_TYPE_GENERATE SignalEventType = 1
+	// A signal was delivered to a process
+	SignalEventType_SIGNAL_EVENT_TYPE_DELIVER SignalEventType = 2
+)
+
+var SignalEventType_name = map[int32]string{
+	0: "SIGNAL_EVENT_TYPE_UNKNOWN",
+	1: "SIGNAL_EVENT_TYPE_GENERATE",
+	2: "SIGNAL_EVENT_TYPE_DELIVER",
+}
+var SignalEventType_value = map[string]int32{
+	"SIGNAL_EVENT_TYPE_UNKNOWN":  0,
+	"SIGNAL_EVENT_TYPE_GENERATE": 1,
+	"SIGNAL_EVENT_TYPE_DELIVER":  2,
+}
+
+func (x SignalEventType) String() string {
+	return proto.EnumName(SignalEventType_name, int32(x))
+}
+func (SignalEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+// Possible NamespaceEvent types
+type NamespaceEventType int32
+
+const (
+	NamespaceEventType_NAMESPACE_EVENT_TYPE_UNKNOWN NamespaceEventType = 0
+	// A process was created in new namespaces by clone(2)
+	NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE NamespaceEventType = 1
+	// A call to unshare(2) moved a process into new namespaces. The
+	// event is reported when the call returns successfully.
+	NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE NamespaceEventType = 2
+	// A call to setns(2) joined an existing namespace. The event is
+	// reported when the call returns successfully.
+	NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS NamespaceEventType = 3
+)
+
+var NamespaceEventType_name = map[int32]string{
+	0: "NAMESPACE_EVENT_TYPE_UNKNOWN",
+	1: "NAMESPACE_EVENT_TYPE_CLONE",
+	2: "NAMESPACE_EVENT_TYPE_UNSHARE",
+	3: "NAMESPACE_EVENT_TYPE_SETNS",
+}
+var NamespaceEventType_value = map[string]int32{
+	"NAMESPACE_EVENT_TYPE_UNKNOWN": 0,
+	"NAMESPACE_EVENT_TYPE_CLONE":   1,
+	"NAMESPACE_EVENT_TYPE_UNSHARE": 2,
+	"NAMESPACE_EVENT_TYPE_SETNS":   3,
+}
+
+func (x NamespaceEventType) String() string {
+	return proto.EnumName(NamespaceEventType_name, int32(x))
+}
+func (NamespaceEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+// Possible KernelModuleEvent types
+type KernelModuleEventType int32
+
+const (
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN KernelModuleEventType = 0
+	// A kernel module was loaded (i.e. by insmod or modprobe)
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD KernelModuleEventType = 1
+	// A kernel module was unloaded (i.e. by rmmod), or it was freed
+	// after failing to load
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD KernelModuleEventType = 2
+)
+
+var KernelModuleEventType_name = map[int32]string{
+	0: "KERNEL_MODULE_EVENT_TYPE_UNKNOWN",
+	1: "KERNEL_MODULE_EVENT_TYPE_LOAD",
+	2: "KERNEL_MODULE_EVENT_TYPE_UNLOAD",
+}
+var KernelModuleEventType_value = map[string]int32{
+	"KERNEL_MODULE_EVENT_TYPE_UNKNOWN": 0,
+	"KERNEL_MODULE_EVENT_TYPE_LOAD":    1,
+	"KERNEL_MODULE_EVENT_TYPE_UNLOAD":  2,
+}
+
+func (x KernelModuleEventType) String() string {
+	return proto.EnumName(KernelModuleEventType_name, int32(x))
+}
+func (KernelModuleEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+// Possible ProcessAccessEvent types
+type ProcessAccessEventType int32
+
+const (
+	ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_UNKNOWN ProcessAccessEventType = 0
+	// A process called ptrace(2) on another process
+	ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE ProcessAccessEventType = 1
+	// A process called process_vm_readv(2) to read the memory of
+	// another process
+	ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ ProcessAccessEventType = 2
+	// A process called process_vm_writev(2) to write the memory of
+	// another process
+	ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE ProcessAccessEventType = 3
+)
+
+var ProcessAccessEventType_name = map[int32]string{
+	0: "PROCESS_ACCESS_EVENT_TYPE_UNKNOWN",
+	1: "PROCESS_ACCESS_EVENT_TYPE_PTRACE",
+	2: "PROCESS_ACCESS_EVENT_TYPE_VM_READ",
+	3: "PROCESS_ACCESS_EVENT_TYPE_VM_WRITE",
+}
+var ProcessAccessEventType_value = map[string]int32{
+	"PROCESS_ACCESS_EVENT_TYPE_UNKNOWN":  0,
+	"PROCESS_ACCESS_EVENT_TYPE_PTRACE":   1,
+	"PROCESS_ACCESS_EVENT_TYPE_VM_READ":  2,
+	"PROCESS_ACCESS_EVENT_TYPE_VM_WRITE": 3,
+}
+
+func (x ProcessAccessEventType) String() string {
+	return proto.EnumName(ProcessAccessEventType_name, int32(x))
+}
+func (ProcessAccessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+// Possible MemoryEvent types
+type MemoryEventType int32
+
+const (
+	MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN MemoryEventType = 0
+	// A process called mmap(2) to map executable memory
+	MemoryEventType_MEMORY_EVENT_TYPE_MMAP MemoryEventType = 1
+	// A process called mprotect(2) to make memory executable
+	MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT MemoryEventType = 2
+)
+
+var MemoryEventType_name = map[int32]string{
+	0: "MEMORY_EVENT_TYPE_UNKNOWN",
+	1: "MEMORY_EVENT_TYPE_MMAP",
+	2: "MEMORY_EVENT_TYPE_MPROTECT",
+}
+var MemoryEventType_value = map[string]int32{
+	"MEMORY_EVENT_TYPE_UNKNOWN":  0,
+	"MEMORY_EVENT_TYPE_MMAP":     1,
+	"MEMORY_EVENT_TYPE_MPROTECT": 2,
+}
+
+func (x MemoryEventType) String() string {
+	return proto.EnumName(MemoryEventType_name, int32(x))
+}
+func (MemoryEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+// Possible FlowEvent types
+type FlowEventType int32
+
+const (
+	FlowEventType_FLOW_EVENT_TYPE_UNKNOWN FlowEventType = 0
+	// A periodic summary of a flow whose socket is still open. Flows
+	// are only summarized if data has been sent or received since the
+	// previous summary.
+	FlowEventType_FLOW_EVENT_TYPE_UPDATE FlowEventType = 1
+	// The final summary of a flow whose socket has been closed
+	FlowEventType_FLOW_EVENT_TYPE_CLOSE FlowEventType = 2
+)
+
+var FlowEventType_name = map[int32]string{
+	0: "FLOW_EVENT_TYPE_UNKNOWN",
+	1: "FLOW_EVENT_TYPE_UPDATE",
+	2: "FLOW_EVENT_TYPE_CLOSE",
+}
+var FlowEventType_value = map[string]int32{
+	"FLOW_EVENT_TYPE_UNKNOWN": 0,
+	"FLOW_EVENT_TYPE_UPDATE":  1,
+	"FLOW_EVENT_TYPE_CLOSE":   2,
+}
+
+func (x FlowEventType) String() string {
+	return proto.EnumName(FlowEventType_name, int32(x))
+}
+func (FlowEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
 
 // Possible KernelFunctionCallEvent types
 type KernelFunctionCallEventType int32
@@ -152,7 +392,7 @@ var KernelFunctionCallEventType_value = map[string]int32{
 func (x KernelFunctionCallEventType) String() string {
 	return proto.EnumName(KernelFunctionCallEventType_name, int32(x))
 }
-func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
 
 // Possible network event types
 type NetworkEventType int32
@@ -187,6 +427,15 @@ const (
 	// The event is the result of an attempt to receive data from a
 	// specific address
 	NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT NetworkEventType = 12
+	// The event is a TCP connection becoming established, either by
+	// connecting to a remote address or by being accepted
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED NetworkEventType = 13
+	// The event is a TCP connection being closed
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED NetworkEventType = 14
+	// The event is any other TCP connection state transition
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_STATE_CHANGE NetworkEventType = 15
+	// The event is an attempt to create a socket
+	NetworkEventType_NETWORK_EVENT_TYPE_SOCKET NetworkEventType = 16
 )
 
 var NetworkEventType_name = map[int32]string{
@@ -203,6 +452,10 @@ var NetworkEventType_name = map[int32]string{
 	10: "NETWORK_EVENT_TYPE_SENDTO_RESULT",
 	11: "NETWORK_EVENT_TYPE_RECVFROM_ATTEMPT",
 	12: "NETWORK_EVENT_TYPE_RECVFROM_RESULT",
+	13: "NETWORK_EVENT_TYPE_TCP_ESTABLISHED",
+	14: "NETWORK_EVENT_TYPE_TCP_CLOSED",
+	15: "NETWORK_EVENT_TYPE_TCP_STATE_CHANGE",
+	16: "NETWORK_EVENT_TYPE_SOCKET",
 }
 var NetworkEventType_value = map[string]int32{
 	"NETWORK_EVENT_TYPE_UNKNOWN":          0,
@@ -218,12 +471,71 @@ var NetworkEventType_value = map[string]int32{
 	"NETWORK_EVENT_TYPE_SENDTO_RESULT":    10,
 	"NETWORK_EVENT_TYPE_RECVFROM_ATTEMPT": 11,
 	"NETWORK_EVENT_TYPE_RECVFROM_RESULT":  12,
+	"NETWORK_EVENT_TYPE_TCP_ESTABLISHED":  13,
+	"NETWORK_EVENT_TYPE_TCP_CLOSED":       14,
+	"NETWORK_EVENT_TYPE_TCP_STATE_CHANGE": 15,
+	"NETWORK_EVENT_TYPE_SOCKET":           16,
 }
 
 func (x NetworkEventType) String() string {
 	return proto.EnumName(NetworkEventType_name, int32(x))
 }
-func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+// The states of a TCP connection, as numbered by the kernel
+type TCPState int32
+
+const (
+	TCPState_TCP_STATE_UNKNOWN      TCPState = 0
+	TCPState_TCP_STATE_ESTABLISHED  TCPState = 1
+	TCPState_TCP_STATE_SYN_SENT     TCPState = 2
+	TCPState_TCP_STATE_SYN_RECV     TCPState = 3
+	TCPState_TCP_STATE_FIN_WAIT1    TCPState = 4
+	TCPState_TCP_STATE_FIN_WAIT2    TCPState = 5
+	TCPState_TCP_STATE_TIME_WAIT    TCPState = 6
+	TCPState_TCP_STATE_CLOSE        TCPState = 7
+	TCPState_TCP_STATE_CLOSE_WAIT   TCPState = 8
+	TCPState_TCP_STATE_LAST_ACK     TCPState = 9
+	TCPState_TCP_STATE_LISTEN       TCPState = 10
+	TCPState_TCP_STATE_CLOSING      TCPState = 11
+	TCPState_TCP_STATE_NEW_SYN_RECV TCPState = 12
+)
+
+var TCPState_name = map[int32]string{
+	0:  "TCP_STATE_UNKNOWN",
+	1:  "TCP_STATE_ESTABLISHED",
+	2:  "TCP_STATE_SYN_SENT",
+	3:  "TCP_STATE_SYN_RECV",
+	4:  "TCP_STATE_FIN_WAIT1",
+	5:  "TCP_STATE_FIN_WAIT2",
+	6:  "TCP_STATE_TIME_WAIT",
+	7:  "TCP_STATE_CLOSE",
+	8:  "TCP_STATE_CLOSE_WAIT",
+	9:  "TCP_STATE_LAST_ACK",
+	10: "TCP_STATE_LISTEN",
+	11: "TCP_STATE_CLOSING",
+	12: "TCP_STATE_NEW_SYN_RECV",
+}
+var TCPState_value = map[string]int32{
+	"TCP_STATE_UNKNOWN":      0,
+	"TCP_STATE_ESTABLISHED":  1,
+	"TCP_STATE_SYN_SENT":     2,
+	"TCP_STATE_SYN_RECV":     3,
+	"TCP_STATE_FIN_WAIT1":    4,
+	"TCP_STATE_FIN_WAIT2":    5,
+	"TCP_STATE_TIME_WAIT":    6,
+	"TCP_STATE_CLOSE":        7,
+	"TCP_STATE_CLOSE_WAIT":   8,
+	"TCP_STATE_LAST_ACK":     9,
+	"TCP_STATE_LISTEN":       10,
+	"TCP_STATE_CLOSING":      11,
+	"TCP_STATE_NEW_SYN_RECV": 12,
+}
+
+func (x TCPState) String() string {
+	return proto.EnumName(TCPState_name, int32(x))
+}
+func (TCPState) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
 
 // Possible field types
 type KernelFunctionCallEvent_FieldType int32
@@ -284,7 +596,7 @@ func (x KernelFunctionCallEvent_FieldType) String() string {
 	return proto.EnumName(KernelFunctionCallEvent_FieldType_name, int32(x))
 }
 func (KernelFunctionCallEvent_FieldType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{18, 0}
 }
 
 // An event observed by the Sensor.
@@ -330,7 +642,16 @@ type TelemetryEvent struct {
 	//	*TelemetryEvent_File
 	//	*TelemetryEvent_KernelCall
 	//	*TelemetryEvent_Network
+	//	*TelemetryEvent_Credentials
+	//	*TelemetryEvent_Signal
+	//	*TelemetryEvent_Namespace
+	//	*TelemetryEvent_KernelModule
+	//	*TelemetryEvent_ProcessAccess
+	//	*TelemetryEvent_Tracepoint
 	//	*TelemetryEvent_Container
+	//	*TelemetryEvent_Memory
+	//	*TelemetryEvent_Flow
+	//	*TelemetryEvent_Lost
 	//	*TelemetryEvent_Chargen
 	//	*TelemetryEvent_Ticker
 	Event isTelemetryEvent_Event `protobuf_oneof:"event"`
@@ -362,9 +683,36 @@ type TelemetryEvent_KernelCall struct {
 type TelemetryEvent_Network struct {
 	Network *NetworkEvent `protobuf:"bytes,14,opt,name=network,oneof"`
 }
+type TelemetryEvent_Credentials struct {
+	Credentials *CredentialsEvent `protobuf:"bytes,15,opt,name=credentials,oneof"`
+}
+type TelemetryEvent_Signal struct {
+	Signal *SignalEvent `protobuf:"bytes,16,opt,name=signal,oneof"`
+}
+type TelemetryEvent_Namespace struct {
+	Namespace *NamespaceEvent `protobuf:"bytes,17,opt,name=namespace,oneof"`
+}
+type TelemetryEvent_KernelModule struct {
+	KernelModule *KernelModuleEvent `protobuf:"bytes,18,opt,name=kernel_module,json=kernelModule,oneof"`
+}
+type TelemetryEvent_ProcessAccess struct {
+	ProcessAccess *ProcessAccessEvent `protobuf:"bytes,19,opt,name=process_access,json=processAccess,oneof"`
+}
+type TelemetryEvent_Tracepoint struct {
+	Tracepoint *TracepointEvent `protobuf:"bytes,23,opt,name=tracepoint,oneof"`
+}
 type TelemetryEvent_Container struct {
 	Container *ContainerEvent `protobuf:"bytes,20,opt,name=container,oneof"`
 }
+type TelemetryEvent_Memory struct {
+	Memory *MemoryEvent `protobuf:"bytes,21,opt,name=memory,oneof"`
+}
+type TelemetryEvent_Flow struct {
+	Flow *FlowEvent `protobuf:"bytes,22,opt,name=flow,oneof"`
+}
+type TelemetryEvent_Lost struct {
+	Lost *LostEvent `protobuf:"bytes,40,opt,name=lost,oneof"`
+}
 type TelemetryEvent_Chargen struct {
 	Chargen *ChargenEvent `protobuf:"bytes,100,opt,name=chargen,oneof"`
 }
@@ -372,14 +720,23 @@ type TelemetryEvent_Ticker struct {
 	Ticker *TickerEvent `protobuf:"bytes,101,opt,name=ticker,oneof"`
 }
 
-func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()    {}
-func (*TelemetryEvent_Process) isTelemetryEvent_Event()    {}
-func (*TelemetryEvent_File) isTelemetryEvent_Event()       {}
-func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event() {}
-func (*TelemetryEvent_Network) isTelemetryEvent_Event()    {}
-func (*TelemetryEvent_Container) isTelemetryEvent_Event()  {}
-func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()    {}
-func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()     {}
+func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Process) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_File) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event()    {}
+func (*TelemetryEvent_Network) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Credentials) isTelemetryEvent_Event()   {}
+func (*TelemetryEvent_Signal) isTelemetryEvent_Event()        {}
+func (*TelemetryEvent_Namespace) isTelemetryEvent_Event()     {}
+func (*TelemetryEvent_KernelModule) isTelemetryEvent_Event()  {}
+func (*TelemetryEvent_ProcessAccess) isTelemetryEvent_Event() {}
+func (*TelemetryEvent_Tracepoint) isTelemetryEvent_Event()    {}
+func (*TelemetryEvent_Container) isTelemetryEvent_Event()     {}
+func (*TelemetryEvent_Memory) isTelemetryEvent_Event()        {}
+func (*TelemetryEvent_Flow) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_Lost) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()        {}
 
 func (m *TelemetryEvent) GetEvent() isTelemetryEvent_Event {
 	if m != nil {
@@ -500,6 +857,48 @@ func (m *TelemetryEvent) GetNetwork() *NetworkEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetCredentials() *CredentialsEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Credentials); ok {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetSignal() *SignalEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Signal); ok {
+		return x.Signal
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetNamespace() *NamespaceEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Namespace); ok {
+		return x.Namespace
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetKernelModule() *KernelModuleEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_KernelModule); ok {
+		return x.KernelModule
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetProcessAccess() *ProcessAccessEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_ProcessAccess); ok {
+		return x.ProcessAccess
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetTracepoint() *TracepointEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Tracepoint); ok {
+		return x.Tracepoint
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Container); ok {
 		return x.Container
@@ -507,6 +906,27 @@ func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetMemory() *MemoryEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Memory); ok {
+		return x.Memory
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetFlow() *FlowEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Flow); ok {
+		return x.Flow
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetLost() *LostEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Lost); ok {
+		return x.Lost
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetChargen() *ChargenEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Chargen); ok {
 		return x.Chargen
@@ -536,7 +956,16 @@ func (*TelemetryEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer
 		(*TelemetryEvent_File)(nil),
 		(*TelemetryEvent_KernelCall)(nil),
 		(*TelemetryEvent_Network)(nil),
+		(*TelemetryEvent_Credentials)(nil),
+		(*TelemetryEvent_Signal)(nil),
+		(*TelemetryEvent_Namespace)(nil),
+		(*TelemetryEvent_KernelModule)(nil),
+		(*TelemetryEvent_ProcessAccess)(nil),
+		(*TelemetryEvent_Tracepoint)(nil),
 		(*TelemetryEvent_Container)(nil),
+		(*TelemetryEvent_Memory)(nil),
+		(*TelemetryEvent_Flow)(nil),
+		(*TelemetryEvent_Lost)(nil),
 		(*TelemetryEvent_Chargen)(nil),
 		(*TelemetryEvent_Ticker)(nil),
 	}
@@ -571,11 +1000,56 @@ func _TelemetryEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Network); err != nil {
 			return err
 		}
+	case *TelemetryEvent_Credentials:
+		b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Credentials); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Signal:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Signal); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Namespace:
+		b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Namespace); err != nil {
+			return err
+		}
+	case *TelemetryEvent_KernelModule:
+		b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.KernelModule); err != nil {
+			return err
+		}
+	case *TelemetryEvent_ProcessAccess:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ProcessAccess); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Tracepoint:
+		b.EncodeVarint(23<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Tracepoint); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Container:
 		b.EncodeVarint(20<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Container); err != nil {
 			return err
 		}
+	case *TelemetryEvent_Memory:
+		b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Memory); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Flow:
+		b.EncodeVarint(22<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Flow); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Lost:
+		b.EncodeVarint(40<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Lost); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Chargen:
 		b.EncodeVarint(100<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Chargen); err != nil {
@@ -636,6 +1110,54 @@ func _TelemetryEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Event = &TelemetryEvent_Network{msg}
 		return true, err
+	case 15: // event.credentials
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CredentialsEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Credentials{msg}
+		return true, err
+	case 16: // event.signal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignalEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Signal{msg}
+		return true, err
+	case 17: // event.namespace
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NamespaceEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Namespace{msg}
+		return true, err
+	case 18: // event.kernel_module
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(KernelModuleEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_KernelModule{msg}
+		return true, err
+	case 19: // event.process_access
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ProcessAccessEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_ProcessAccess{msg}
+		return true, err
+	case 23: // event.tracepoint
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TracepointEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Tracepoint{msg}
+		return true, err
 	case 20: // event.container
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -644,6 +1166,30 @@ func _TelemetryEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Event = &TelemetryEvent_Container{msg}
 		return true, err
+	case 21: // event.memory
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(MemoryEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Memory{msg}
+		return true, err
+	case 22: // event.flow
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FlowEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Flow{msg}
+		return true, err
+	case 40: // event.lost
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LostEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Lost{msg}
+		return true, err
 	case 100: // event.chargen
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -694,11 +1240,56 @@ func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(14<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_Credentials:
+		s := proto.Size(x.Credentials)
+		n += proto.SizeVarint(15<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Signal:
+		s := proto.Size(x.Signal)
+		n += proto.SizeVarint(16<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Namespace:
+		s := proto.Size(x.Namespace)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_KernelModule:
+		s := proto.Size(x.KernelModule)
+		n += proto.SizeVarint(18<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_ProcessAccess:
+		s := proto.Size(x.ProcessAccess)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Tracepoint:
+		s := proto.Size(x.Tracepoint)
+		n += proto.SizeVarint(23<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Container:
 		s := proto.Size(x.Container)
 		n += proto.SizeVarint(20<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_Memory:
+		s := proto.Size(x.Memory)
+		n += proto.SizeVarint(21<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Flow:
+		s := proto.Size(x.Flow)
+		n += proto.SizeVarint(22<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Lost:
+		s := proto.Size(x.Lost)
+		n += proto.SizeVarint(40<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Chargen:
 		s := proto.Size(x.Chargen)
 		n += proto.SizeVarint(100<<3 | proto.WireBytes)
@@ -716,6 +1307,83 @@ func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// Reports that events were discarded before they could be delivered.
+//
+// When the kernel discards events because a sensor ring buffer was full,
+// lost events are sent to every subscription for kernel-level events,
+// because all of them share the sensor's ring buffers. The CPU whose ring
+// buffer was full is given by the TelemetryEvent's cpu field.
+//
+// When events are discarded from the delivery spool of a reliable delivery
+// subscription, a lost event covering them is delivered before any other
+// event still in the spool. If more events are discarded before the client
+// acknowledges it, it is replaced by a lost event that covers those events as
+// well.
+type LostEvent struct {
+	// Number of events that were discarded
+	Lost uint64 `protobuf:"varint,1,opt,name=lost" json:"lost,omitempty"`
+	// The events were discarded at some point after the previous event
+	// from the same ring buffer, at start_monotime_nanos, and before
+	// end_monotime_nanos, at which the loss was reported. If there was
+	// no previous event, start_monotime_nanos is 0. For delivery spool
+	// losses, these are the sensor_monotime_nanos of the first and last
+	// events discarded.
+	StartMonotimeNanos int64         `protobuf:"varint,2,opt,name=start_monotime_nanos,json=startMonotimeNanos" json:"start_monotime_nanos,omitempty"`
+	EndMonotimeNanos   int64         `protobuf:"varint,3,opt,name=end_monotime_nanos,json=endMonotimeNanos" json:"end_monotime_nanos,omitempty"`
+	Type               LostEventType `protobuf:"varint,4,opt,name=type,enum=capsule8.api.v0.LostEventType" json:"type,omitempty"`
+	// For delivery spool losses, the sensor_sequence_number of the
+	// first and last events discarded
+	FirstSequenceNumber uint64 `protobuf:"varint,5,opt,name=first_sequence_number,json=firstSequenceNumber" json:"first_sequence_number,omitempty"`
+	LastSequenceNumber  uint64 `protobuf:"varint,6,opt,name=last_sequence_number,json=lastSequenceNumber" json:"last_sequence_number,omitempty"`
+}
+
+func (m *LostEvent) Reset()                    { *m = LostEvent{} }
+func (m *LostEvent) String() string            { return proto.CompactTextString(m) }
+func (*LostEvent) ProtoMessage()               {}
+func (*LostEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+func (m *LostEvent) GetLost() uint64 {
+	if m != nil {
+		return m.Lost
+	}
+	return 0
+}
+
+func (m *LostEvent) GetStartMonotimeNanos() int64 {
+	if m != nil {
+		return m.StartMonotimeNanos
+	}
+	return 0
+}
+
+func (m *LostEvent) GetEndMonotimeNanos() int64 {
+	if m != nil {
+		return m.EndMonotimeNanos
+	}
+	return 0
+}
+
+func (m *LostEvent) GetType() LostEventType {
+	if m != nil {
+		return m.Type
+	}
+	return LostEventType_LOST_EVENT_TYPE_UNKNOWN
+}
+
+func (m *LostEvent) GetFirstSequenceNumber() uint64 {
+	if m != nil {
+		return m.FirstSequenceNumber
+	}
+	return 0
+}
+
+func (m *LostEvent) GetLastSequenceNumber() uint64 {
+	if m != nil {
+		return m.LastSequenceNumber
+	}
+	return 0
+}
+
 type ChargenEvent struct {
 	// Index of the first character in this Event in relation to all of
 	// the characters that have been generated in this stream.
@@ -727,7 +1395,7 @@ type ChargenEvent struct {
 func (m *ChargenEvent) Reset()                    { *m = ChargenEvent{} }
 func (m *ChargenEvent) String() string            { return proto.CompactTextString(m) }
 func (*ChargenEvent) ProtoMessage()               {}
-func (*ChargenEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (*ChargenEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
 func (m *ChargenEvent) GetIndex() uint64 {
 	if m != nil {
@@ -757,7 +1425,7 @@ type TickerEvent struct {
 func (m *TickerEvent) Reset()                    { *m = TickerEvent{} }
 func (m *TickerEvent) String() string            { return proto.CompactTextString(m) }
 func (*TickerEvent) ProtoMessage()               {}
-func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
 
 func (m *TickerEvent) GetSeconds() int64 {
 	if m != nil {
@@ -806,7 +1474,7 @@ type ContainerEvent struct {
 func (m *ContainerEvent) Reset()                    { *m = ContainerEvent{} }
 func (m *ContainerEvent) String() string            { return proto.CompactTextString(m) }
 func (*ContainerEvent) ProtoMessage()               {}
-func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 func (m *ContainerEvent) GetType() ContainerEventType {
 	if m != nil {
@@ -902,6 +1570,12 @@ type ProcessEvent struct {
 	// Present when the event is an exec event. Repeated for each argument
 	// passed to the executable on the command-line.
 	ExecCommandLine []string `protobuf:"bytes,21,rep,name=exec_command_line,json=execCommandLine" json:"exec_command_line,omitempty"`
+	// Present when the event is an exec event and the Sensor is
+	// configured to capture the environment. Repeated for each
+	// environment variable passed to the executable in the form
+	// NAME=value, up to a configured number of variables. The values of
+	// variables that may hold secrets are redacted.
+	ExecEnvironment []string `protobuf:"bytes,22,rep,name=exec_environment,json=execEnvironment" json:"exec_environment,omitempty"`
 	// Present when the event is an exit event. This is the exit code that
 	// the process exited with.
 	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
@@ -920,7 +1594,7 @@ type ProcessEvent struct {
 func (m *ProcessEvent) Reset()                    { *m = ProcessEvent{} }
 func (m *ProcessEvent) String() string            { return proto.CompactTextString(m) }
 func (*ProcessEvent) ProtoMessage()               {}
-func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
 
 func (m *ProcessEvent) GetType() ProcessEventType {
 	if m != nil {
@@ -957,6 +1631,13 @@ func (m *ProcessEvent) GetExecCommandLine() []string {
 	return nil
 }
 
+func (m *ProcessEvent) GetExecEnvironment() []string {
+	if m != nil {
+		return m.ExecEnvironment
+	}
+	return nil
+}
+
 func (m *ProcessEvent) GetExitCode() int32 {
 	if m != nil {
 		return m.ExitCode
@@ -1018,7 +1699,7 @@ type SyscallEvent struct {
 func (m *SyscallEvent) Reset()                    { *m = SyscallEvent{} }
 func (m *SyscallEvent) String() string            { return proto.CompactTextString(m) }
 func (*SyscallEvent) ProtoMessage()               {}
-func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
 
 func (m *SyscallEvent) GetType() SyscallEventType {
 	if m != nil {
@@ -1084,12 +1765,16 @@ func (m *SyscallEvent) GetRet() int64 {
 }
 
 // FileEvent describes an event that occurred related to file operations
-// occurring as detected by the Sensor.
+// occurring as detected by the Sensor. File events are reported when the
+// system call is made, whether or not it succeeds, and filenames are given
+// as they were passed to the system call.
 type FileEvent struct {
 	// The type of event described by this FileEvent message
 	Type FileEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FileEventType" json:"type,omitempty"`
-	// Present when the event is a file open event. This is the filename of
-	// the file being opened.
+	// The filename of the file being acted upon. For rename events, this
+	// is the original filename; for link events, it is the existing file
+	// being linked to; and for symlink events, it is the target of the
+	// symbolic link.
 	Filename string `protobuf:"bytes,10,opt,name=filename" json:"filename,omitempty"`
 	// Present when the event is a file open event. This is the set of
 	// flags with which the file was opened (e.g., O_RDONLY, O_NONBLOCK,
@@ -1098,12 +1783,25 @@ type FileEvent struct {
 	// Present when the event is a file open event. This is the set of file
 	// permissions used in a creat(2) system call.
 	OpenMode int32 `protobuf:"zigzag32,12,opt,name=open_mode,json=openMode" json:"open_mode,omitempty"`
+	// Present for rename, link, and symlink events. This is the new
+	// filename of the file being renamed or of the link being created.
+	NewFilename string `protobuf:"bytes,13,opt,name=new_filename,json=newFilename" json:"new_filename,omitempty"`
+	// Present for chmod and mkdir events. This is the file permissions
+	// being set.
+	Mode int32 `protobuf:"zigzag32,14,opt,name=mode" json:"mode,omitempty"`
+	// Present for chown events. These are the user and group IDs being
+	// set, or -1 if they are not being changed.
+	Uid int32 `protobuf:"zigzag32,15,opt,name=uid" json:"uid,omitempty"`
+	Gid int32 `protobuf:"zigzag32,16,opt,name=gid" json:"gid,omitempty"`
+	// Present for rename, chown, and link events. This is the set of
+	// flags passed to renameat2(2), fchownat(2), or linkat(2).
+	Flags int32 `protobuf:"zigzag32,17,opt,name=flags" json:"flags,omitempty"`
 }
 
 func (m *FileEvent) Reset()                    { *m = FileEvent{} }
 func (m *FileEvent) String() string            { return proto.CompactTextString(m) }
 func (*FileEvent) ProtoMessage()               {}
-func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
 
 func (m *FileEvent) GetType() FileEventType {
 	if m != nil {
@@ -1133,6 +1831,41 @@ func (m *FileEvent) GetOpenMode() int32 {
 	return 0
 }
 
+func (m *FileEvent) GetNewFilename() string {
+	if m != nil {
+		return m.NewFilename
+	}
+	return ""
+}
+
+func (m *FileEvent) GetMode() int32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileEvent) GetUid() int32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *FileEvent) GetGid() int32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+func (m *FileEvent) GetFlags() int32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
 type Process struct {
 	Pid     int32  `protobuf:"zigzag32,1,opt,name=pid" json:"pid,omitempty"`
 	Command string `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
@@ -1141,7 +1874,7 @@ type Process struct {
 func (m *Process) Reset()                    { *m = Process{} }
 func (m *Process) String() string            { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
 
 func (m *Process) GetPid() int32 {
 	if m != nil {
@@ -1157,7 +1890,679 @@ func (m *Process) GetCommand() string {
 	return ""
 }
 
-// KernelFunctionCallEvent describes an event that occurred related to kernel
+// Credentials describes the credentials of a process.
+type Credentials struct {
+	Uid   uint32 `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
+	Gid   uint32 `protobuf:"varint,2,opt,name=gid" json:"gid,omitempty"`
+	Euid  uint32 `protobuf:"varint,3,opt,name=euid" json:"euid,omitempty"`
+	Egid  uint32 `protobuf:"varint,4,opt,name=egid" json:"egid,omitempty"`
+	Fsuid uint32 `protobuf:"varint,5,opt,name=fsuid" json:"fsuid,omitempty"`
+	// Capability sets, as bitmasks of capabilities. These are zero when
+	// the sensor can't determine the running kernel's version, because
+	// their location in the kernel's credentials depends on it.
+	CapInheritable uint64 `protobuf:"varint,10,opt,name=cap_inheritable,json=capInheritable" json:"cap_inheritable,omitempty"`
+	CapPermitted   uint64 `protobuf:"varint,11,opt,name=cap_permitted,json=capPermitted" json:"cap_permitted,omitempty"`
+	CapEffective   uint64 `protobuf:"varint,12,opt,name=cap_effective,json=capEffective" json:"cap_effective,omitempty"`
+	CapBounding    uint64 `protobuf:"varint,13,opt,name=cap_bounding,json=capBounding" json:"cap_bounding,omitempty"`
+}
+
+func (m *Credentials) Reset()                    { *m = Credentials{} }
+func (m *Credentials) String() string            { return proto.CompactTextString(m) }
+func (*Credentials) ProtoMessage()               {}
+func (*Credentials) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *Credentials) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *Credentials) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+func (m *Credentials) GetEuid() uint32 {
+	if m != nil {
+		return m.Euid
+	}
+	return 0
+}
+
+func (m *Credentials) GetEgid() uint32 {
+	if m != nil {
+		return m.Egid
+	}
+	return 0
+}
+
+func (m *Credentials) GetFsuid() uint32 {
+	if m != nil {
+		return m.Fsuid
+	}
+	return 0
+}
+
+func (m *Credentials) GetCapInheritable() uint64 {
+	if m != nil {
+		return m.CapInheritable
+	}
+	return 0
+}
+
+func (m *Credentials) GetCapPermitted() uint64 {
+	if m != nil {
+		return m.CapPermitted
+	}
+	return 0
+}
+
+func (m *Credentials) GetCapEffective() uint64 {
+	if m != nil {
+		return m.CapEffective
+	}
+	return 0
+}
+
+func (m *Credentials) GetCapBounding() uint64 {
+	if m != nil {
+		return m.CapBounding
+	}
+	return 0
+}
+
+// CredentialsEvent describes a change to the credentials of a process, such
+// as by setuid(2) or by executing a setuid program.
+type CredentialsEvent struct {
+	// The credentials of the process before the change. This is absent
+	// if the sensor did not know the process's credentials.
+	Old *Credentials `protobuf:"bytes,1,opt,name=old" json:"old,omitempty"`
+	// The credentials of the process after the change
+	New *Credentials `protobuf:"bytes,2,opt,name=new" json:"new,omitempty"`
+}
+
+func (m *CredentialsEvent) Reset()                    { *m = CredentialsEvent{} }
+func (m *CredentialsEvent) String() string            { return proto.CompactTextString(m) }
+func (*CredentialsEvent) ProtoMessage()               {}
+func (*CredentialsEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *CredentialsEvent) GetOld() *Credentials {
+	if m != nil {
+		return m.Old
+	}
+	return nil
+}
+
+func (m *CredentialsEvent) GetNew() *Credentials {
+	if m != nil {
+		return m.New
+	}
+	return nil
+}
+
+// SignalEvent describes a signal being sent to or delivered to a process.
+type SignalEvent struct {
+	// The type of event described by this SignalEvent message
+	Type SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	// The signal number
+	Signal uint32 `protobuf:"varint,2,opt,name=signal" json:"signal,omitempty"`
+	// The process sending the signal. This is only present for
+	// generate events, where it is also the process of the event.
+	SenderPid int32  `protobuf:"zigzag32,10,opt,name=sender_pid,json=senderPid" json:"sender_pid,omitempty"`
+	SenderId  string `protobuf:"bytes,11,opt,name=sender_id,json=senderId" json:"sender_id,omitempty"`
+	// The process the signal is sent to. For deliver events, this is
+	// the process of the event.
+	TargetPid int32  `protobuf:"zigzag32,12,opt,name=target_pid,json=targetPid" json:"target_pid,omitempty"`
+	TargetId  string `protobuf:"bytes,13,opt,name=target_id,json=targetId" json:"target_id,omitempty"`
+	// The si_code of the signal, which identifies how it was sent
+	// (e.g. SI_USER for kill(2) or SI_TKILL for tgkill(2)).
+	Code int32 `protobuf:"zigzag32,20,opt,name=code" json:"code,omitempty"`
+	// The si_errno of the signal
+	Errno int32 `protobuf:"zigzag32,21,opt,name=errno" json:"errno,omitempty"`
+	// Present for generate events. This is true if the signal was sent
+	// to the target's thread group rather than to a single thread.
+	Group bool `protobuf:"varint,22,opt,name=group" json:"group,omitempty"`
+	// Present for generate events. The result of sending the signal:
+	// 0 if delivered, 1 if ignored, 2 if already pending, 3 if queueing
+	// the signal failed, or 4 if its siginfo was lost.
+	Result int32 `protobuf:"zigzag32,23,opt,name=result" json:"result,omitempty"`
+	// Present for deliver events. The address of the signal handler,
+	// or 0 for SIG_DFL and 1 for SIG_IGN.
+	Handler uint64 `protobuf:"varint,24,opt,name=handler" json:"handler,omitempty"`
+	// Present for deliver events. The sa_flags of the signal handler.
+	HandlerFlags uint64 `protobuf:"varint,25,opt,name=handler_flags,json=handlerFlags" json:"handler_flags,omitempty"`
+}
+
+func (m *SignalEvent) Reset()                    { *m = SignalEvent{} }
+func (m *SignalEvent) String() string            { return proto.CompactTextString(m) }
+func (*SignalEvent) ProtoMessage()               {}
+func (*SignalEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *SignalEvent) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SignalEvent) GetSignal() uint32 {
+	if m != nil {
+		return m.Signal
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSenderPid() int32 {
+	if m != nil {
+		return m.SenderPid
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSenderId() string {
+	if m != nil {
+		return m.SenderId
+	}
+	return ""
+}
+
+func (m *SignalEvent) GetTargetPid() int32 {
+	if m != nil {
+		return m.TargetPid
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
+
+func (m *SignalEvent) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetErrno() int32 {
+	if m != nil {
+		return m.Errno
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetGroup() bool {
+	if m != nil {
+		return m.Group
+	}
+	return false
+}
+
+func (m *SignalEvent) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetHandler() uint64 {
+	if m != nil {
+		return m.Handler
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetHandlerFlags() uint64 {
+	if m != nil {
+		return m.HandlerFlags
+	}
+	return 0
+}
+
+// Namespace identifies a Linux namespace.
+type Namespace struct {
+	// The type of the namespace, as named in /proc/PID/ns (i.e. "mnt",
+	// "uts", "ipc", "user", "pid", "net", or "cgroup")
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// The inode number of the namespace
+	Inode uint64 `protobuf:"varint,2,opt,name=inode" json:"inode,omitempty"`
+	// True if this is the namespace of the host's init process
+	Host bool `protobuf:"varint,3,opt,name=host" json:"host,omitempty"`
+}
+
+func (m *Namespace) Reset()                    { *m = Namespace{} }
+func (m *Namespace) String() string            { return proto.CompactTextString(m) }
+func (*Namespace) ProtoMessage()               {}
+func (*Namespace) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *Namespace) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Namespace) GetInode() uint64 {
+	if m != nil {
+		return m.Inode
+	}
+	return 0
+}
+
+func (m *Namespace) GetHost() bool {
+	if m != nil {
+		return m.Host
+	}
+	return false
+}
+
+// NamespaceEvent describes a process creating or entering namespaces.
+type NamespaceEvent struct {
+	// The type of event described by this NamespaceEvent message
+	Type NamespaceEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.NamespaceEventType" json:"type,omitempty"`
+	// The flags passed to clone(2) or unshare(2), or the nstype passed
+	// to setns(2)
+	Flags uint64 `protobuf:"varint,2,opt,name=flags" json:"flags,omitempty"`
+	// The namespaces created or entered. These are read from procfs
+	// shortly after the event occurs, so they are best-effort: they may
+	// be absent if the process has already exited or closed the
+	// namespace file descriptor, or if the Sensor is too busy to read
+	// them, and for setns events, they describe whatever the file
+	// descriptor refers to by then. Because of this, namespace events
+	// may be delivered after events that occurred later.
+	Namespaces []*Namespace `protobuf:"bytes,3,rep,name=namespaces" json:"namespaces,omitempty"`
+	// Present when the event is a clone event. This is the PID of the
+	// new child process.
+	CloneChildPid int32 `protobuf:"zigzag32,10,opt,name=clone_child_pid,json=cloneChildPid" json:"clone_child_pid,omitempty"`
+	// Present when the event is a clone event. This is the Sensor's
+	// process ID of the new child process.
+	CloneChildId string `protobuf:"bytes,11,opt,name=clone_child_id,json=cloneChildId" json:"clone_child_id,omitempty"`
+	// Present when the event is a setns event. This is the file
+	// descriptor of the namespace that was entered.
+	SetnsFd int32 `protobuf:"zigzag32,12,opt,name=setns_fd,json=setnsFd" json:"setns_fd,omitempty"`
+}
+
+func (m *NamespaceEvent) Reset()                    { *m = NamespaceEvent{} }
+func (m *NamespaceEvent) String() string            { return proto.CompactTextString(m) }
+func (*NamespaceEvent) ProtoMessage()               {}
+func (*NamespaceEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+
+func (m *NamespaceEvent) GetType() NamespaceEventType {
+	if m != nil {
+		return m.Type
+	}
+	return NamespaceEventType_NAMESPACE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *NamespaceEvent) GetFlags() uint64 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *NamespaceEvent) GetNamespaces() []*Namespace {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *NamespaceEvent) GetCloneChildPid() int32 {
+	if m != nil {
+		return m.CloneChildPid
+	}
+	return 0
+}
+
+func (m *NamespaceEvent) GetCloneChildId() string {
+	if m != nil {
+		return m.CloneChildId
+	}
+	return ""
+}
+
+func (m *NamespaceEvent) GetSetnsFd() int32 {
+	if m != nil {
+		return m.SetnsFd
+	}
+	return 0
+}
+
+// KernelModuleEvent describes a kernel module being loaded or unloaded. The
+// process of the event is the process that loaded or unloaded the module.
+type KernelModuleEvent struct {
+	// The type of event described by this KernelModuleEvent message
+	Type KernelModuleEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.KernelModuleEventType" json:"type,omitempty"`
+	// The name of the kernel module
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Present when the event is a load event. This is the bitmask of
+	// kernel taint flags set by the module, such as 1 << 0 for a
+	// proprietary module, 1 << 12 for an out-of-tree module, or 1 << 13
+	// for an unsigned module.
+	Taints uint32 `protobuf:"varint,3,opt,name=taints" json:"taints,omitempty"`
+}
+
+func (m *KernelModuleEvent) Reset()                    { *m = KernelModuleEvent{} }
+func (m *KernelModuleEvent) String() string            { return proto.CompactTextString(m) }
+func (*KernelModuleEvent) ProtoMessage()               {}
+func (*KernelModuleEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *KernelModuleEvent) GetType() KernelModuleEventType {
+	if m != nil {
+		return m.Type
+	}
+	return KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *KernelModuleEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KernelModuleEvent) GetTaints() uint32 {
+	if m != nil {
+		return m.Taints
+	}
+	return 0
+}
+
+// ProcessAccessEvent describes a process attempting to trace or access the
+// memory of another process. The process of the event is the process making
+// the attempt, and its container is the container of the event.
+type ProcessAccessEvent struct {
+	// The type of event described by this ProcessAccessEvent message
+	Type ProcessAccessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessAccessEventType" json:"type,omitempty"`
+	// The PID of the target process, as seen by the calling process
+	TargetPid int32 `protobuf:"zigzag32,2,opt,name=target_pid,json=targetPid" json:"target_pid,omitempty"`
+	// The Sensor's process ID of the target process. This is empty if
+	// the calling process is not in the host's PID namespace, because
+	// target_pid is then not a host PID.
+	TargetId string `protobuf:"bytes,3,opt,name=target_id,json=targetId" json:"target_id,omitempty"`
+	// The container ID of the target process. Like target_id, this is
+	// empty if the calling process is not in the host's PID namespace.
+	TargetContainerId string `protobuf:"bytes,4,opt,name=target_container_id,json=targetContainerId" json:"target_container_id,omitempty"`
+	// Present when the event is a ptrace event. This is the ptrace
+	// request (e.g. PTRACE_ATTACH or PTRACE_PEEKDATA) and its
+	// arguments.
+	PtraceRequest uint64 `protobuf:"varint,10,opt,name=ptrace_request,json=ptraceRequest" json:"ptrace_request,omitempty"`
+	PtraceAddr    uint64 `protobuf:"varint,11,opt,name=ptrace_addr,json=ptraceAddr" json:"ptrace_addr,omitempty"`
+	PtraceData    uint64 `protobuf:"varint,12,opt,name=ptrace_data,json=ptraceData" json:"ptrace_data,omitempty"`
+	// Present when the event is a process_vm_readv or process_vm_writev
+	// event. This is the number of iovecs in the target process.
+	RemoteIovcnt uint64 `protobuf:"varint,20,opt,name=remote_iovcnt,json=remoteIovcnt" json:"remote_iovcnt,omitempty"`
+}
+
+func (m *ProcessAccessEvent) Reset()                    { *m = ProcessAccessEvent{} }
+func (m *ProcessAccessEvent) String() string            { return proto.CompactTextString(m) }
+func (*ProcessAccessEvent) ProtoMessage()               {}
+func (*ProcessAccessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *ProcessAccessEvent) GetType() ProcessAccessEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ProcessAccessEvent) GetTargetPid() int32 {
+	if m != nil {
+		return m.TargetPid
+	}
+	return 0
+}
+
+func (m *ProcessAccessEvent) GetTargetId() string {
+	if m != nil {
+		return m.TargetId
+	}
+	return ""
+}
+
+func (m *ProcessAccessEvent) GetTargetContainerId() string {
+	if m != nil {
+		return m.TargetContainerId
+	}
+	return ""
+}
+
+func (m *ProcessAccessEvent) GetPtraceRequest() uint64 {
+	if m != nil {
+		return m.PtraceRequest
+	}
+	return 0
+}
+
+func (m *ProcessAccessEvent) GetPtraceAddr() uint64 {
+	if m != nil {
+		return m.PtraceAddr
+	}
+	return 0
+}
+
+func (m *ProcessAccessEvent) GetPtraceData() uint64 {
+	if m != nil {
+		return m.PtraceData
+	}
+	return 0
+}
+
+func (m *ProcessAccessEvent) GetRemoteIovcnt() uint64 {
+	if m != nil {
+		return m.RemoteIovcnt
+	}
+	return 0
+}
+
+// MemoryEvent describes a process requesting executable memory. Only calls
+// that include PROT_EXEC in the requested protection are reported.
+type MemoryEvent struct {
+	// The type of event described by this MemoryEvent message
+	Type MemoryEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MemoryEventType" json:"type,omitempty"`
+	// The address and length of the memory. For mmap events, the
+	// address is the hint passed to mmap(2), and it is often 0.
+	Addr   uint64 `protobuf:"varint,2,opt,name=addr" json:"addr,omitempty"`
+	Length uint64 `protobuf:"varint,3,opt,name=length" json:"length,omitempty"`
+	// The requested protection, as a bitmask of PROT_READ (1),
+	// PROT_WRITE (2), and PROT_EXEC (4)
+	Prot uint64 `protobuf:"varint,4,opt,name=prot" json:"prot,omitempty"`
+	// Present when the event is an mmap event. These are the flags,
+	// file descriptor, and offset passed to mmap(2).
+	Flags  uint64 `protobuf:"varint,5,opt,name=flags" json:"flags,omitempty"`
+	Fd     int32  `protobuf:"zigzag32,6,opt,name=fd" json:"fd,omitempty"`
+	Offset uint64 `protobuf:"varint,7,opt,name=offset" json:"offset,omitempty"`
+	// The file backing the memory, if there is one. For mmap events, it
+	// is read from procfs shortly after the call, so it is absent if the
+	// file descriptor has been closed by then or if the Sensor is too
+	// busy to read it, and mmap events that map files may be delivered
+	// after events that occurred later. For mprotect
+	// events, this may also be a pseudo-path such as "[heap]" or
+	// "[stack]", and it is best-effort: it comes from the process's
+	// memory mappings as last read from procfs, which the sensor reads
+	// in the background and at most once a second, so it is absent
+	// for the first mprotect events of a process and may be stale.
+	Filename string `protobuf:"bytes,8,opt,name=filename" json:"filename,omitempty"`
+}
+
+func (m *MemoryEvent) Reset()                    { *m = MemoryEvent{} }
+func (m *MemoryEvent) String() string            { return proto.CompactTextString(m) }
+func (*MemoryEvent) ProtoMessage()               {}
+func (*MemoryEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+
+func (m *MemoryEvent) GetType() MemoryEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MemoryEvent) GetAddr() uint64 {
+	if m != nil {
+		return m.Addr
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetLength() uint64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetProt() uint64 {
+	if m != nil {
+		return m.Prot
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetFlags() uint64 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetFd() int32 {
+	if m != nil {
+		return m.Fd
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+// FlowEvent summarizes the data sent and received by a process on an IPv4 or
+// IPv6 socket. Flows are tracked from the data passed through the kernel's
+// inet_sendmsg() and inet_recvmsg(), or their IPv6 counterparts, by the
+// system calls that send and receive data (read(2), readv(2), write(2),
+// writev(2), and the send and receive calls). Data sent with sendfile(2) or
+// splice(2) is not counted. A socket has a flow for each address that data
+// is sent to without connecting it, and a flow for the data sent on it
+// while connected and the data received on it. Closed sockets are noticed
+// when their file descriptors are reused for other sockets or when flows
+// are summarized.
+type FlowEvent struct {
+	// The type of event described by this FlowEvent message
+	Type FlowEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FlowEventType" json:"type,omitempty"`
+	// The file descriptor and inode of the socket. The inode is read
+	// from procfs when the socket's flows are first summarized, so it
+	// is absent for sockets closed before then.
+	Sockfd uint64 `protobuf:"varint,2,opt,name=sockfd" json:"sockfd,omitempty"`
+	Inode  uint64 `protobuf:"varint,3,opt,name=inode" json:"inode,omitempty"`
+	// The local and remote addresses of the socket. The remote address
+	// of data sent without connecting the socket is the address it was
+	// sent to. Otherwise, the addresses are read from procfs along with
+	// the inode, and are only present for TCP and UDP sockets.
+	LocalAddress  *NetworkAddress `protobuf:"bytes,4,opt,name=local_address,json=localAddress" json:"local_address,omitempty"`
+	RemoteAddress *NetworkAddress `protobuf:"bytes,5,opt,name=remote_address,json=remoteAddress" json:"remote_address,omitempty"`
+	// The number of bytes and the number of messages sent and received
+	// since the previous summary of the flow
+	BytesSent        uint64 `protobuf:"varint,10,opt,name=bytes_sent,json=bytesSent" json:"bytes_sent,omitempty"`
+	BytesReceived    uint64 `protobuf:"varint,11,opt,name=bytes_received,json=bytesReceived" json:"bytes_received,omitempty"`
+	MessagesSent     uint64 `protobuf:"varint,12,opt,name=messages_sent,json=messagesSent" json:"messages_sent,omitempty"`
+	MessagesReceived uint64 `protobuf:"varint,13,opt,name=messages_received,json=messagesReceived" json:"messages_received,omitempty"`
+	// The sensor monotime at which the flow was first seen
+	StartMonotimeNanos int64 `protobuf:"varint,20,opt,name=start_monotime_nanos,json=startMonotimeNanos" json:"start_monotime_nanos,omitempty"`
+}
+
+func (m *FlowEvent) Reset()                    { *m = FlowEvent{} }
+func (m *FlowEvent) String() string            { return proto.CompactTextString(m) }
+func (*FlowEvent) ProtoMessage()               {}
+func (*FlowEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
+
+func (m *FlowEvent) GetType() FlowEventType {
+	if m != nil {
+		return m.Type
+	}
+	return FlowEventType_FLOW_EVENT_TYPE_UNKNOWN
+}
+
+func (m *FlowEvent) GetSockfd() uint64 {
+	if m != nil {
+		return m.Sockfd
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetInode() uint64 {
+	if m != nil {
+		return m.Inode
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetLocalAddress() *NetworkAddress {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return nil
+}
+
+func (m *FlowEvent) GetRemoteAddress() *NetworkAddress {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return nil
+}
+
+func (m *FlowEvent) GetBytesSent() uint64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetBytesReceived() uint64 {
+	if m != nil {
+		return m.BytesReceived
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetMessagesSent() uint64 {
+	if m != nil {
+		return m.MessagesSent
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetMessagesReceived() uint64 {
+	if m != nil {
+		return m.MessagesReceived
+	}
+	return 0
+}
+
+func (m *FlowEvent) GetStartMonotimeNanos() int64 {
+	if m != nil {
+		return m.StartMonotimeNanos
+	}
+	return 0
+}
+
+// KernelFunctionCallEvent describes an event that occurred related to kernel
 // functions being entered or exited.
 type KernelFunctionCallEvent struct {
 	// Label repeated w/ a `mapEntry` option set to `true`.
@@ -1170,7 +2575,7 @@ type KernelFunctionCallEvent struct {
 func (m *KernelFunctionCallEvent) Reset()                    { *m = KernelFunctionCallEvent{} }
 func (m *KernelFunctionCallEvent) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent) ProtoMessage()               {}
-func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
 
 func (m *KernelFunctionCallEvent) GetArguments() map[string]*KernelFunctionCallEvent_FieldValue {
 	if m != nil {
@@ -1196,7 +2601,7 @@ func (m *KernelFunctionCallEvent_FieldValue) Reset()         { *m = KernelFuncti
 func (m *KernelFunctionCallEvent_FieldValue) String() string { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent_FieldValue) ProtoMessage()    {}
 func (*KernelFunctionCallEvent_FieldValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{18, 0}
 }
 
 type isKernelFunctionCallEvent_FieldValue_Value interface {
@@ -1358,6 +2763,35 @@ func _KernelFunctionCallEvent_FieldValue_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// TracepointEvent describes an event reported by a kernel tracepoint.
+type TracepointEvent struct {
+	// The name of the tracepoint, including its subsystem
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// This is a map of field names and values. The keys are the names
+	// of the fields in the tracepoint's format, and the values are the
+	// decoded values of each field.
+	Fields map[string]*KernelFunctionCallEvent_FieldValue `protobuf:"bytes,2,rep,name=fields" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TracepointEvent) Reset()                    { *m = TracepointEvent{} }
+func (m *TracepointEvent) String() string            { return proto.CompactTextString(m) }
+func (*TracepointEvent) ProtoMessage()               {}
+func (*TracepointEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
+
+func (m *TracepointEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TracepointEvent) GetFields() map[string]*KernelFunctionCallEvent_FieldValue {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
 // NetworkEvent describes an event that occurred related to network activity
 // occurring as detected by the Sensor.
 type NetworkEvent struct {
@@ -1378,12 +2812,45 @@ type NetworkEvent struct {
 	// Present only when the event describes a listen attempt. This is the
 	// value of the backlog argument passed to listen(2).
 	Backlog uint64 `protobuf:"varint,13,opt,name=backlog" json:"backlog,omitempty"`
+	// Present when the event describes a TCP connection state
+	// transition. These are the local and remote addresses and ports of
+	// the connection.
+	LocalAddress  *NetworkAddress `protobuf:"bytes,14,opt,name=local_address,json=localAddress" json:"local_address,omitempty"`
+	RemoteAddress *NetworkAddress `protobuf:"bytes,15,opt,name=remote_address,json=remoteAddress" json:"remote_address,omitempty"`
+	// Present when the event describes a TCP connection state
+	// transition. These are the states before and after the transition.
+	OldTcpState TCPState `protobuf:"varint,16,opt,name=old_tcp_state,json=oldTcpState,enum=capsule8.api.v0.TCPState" json:"old_tcp_state,omitempty"`
+	NewTcpState TCPState `protobuf:"varint,17,opt,name=new_tcp_state,json=newTcpState,enum=capsule8.api.v0.TCPState" json:"new_tcp_state,omitempty"`
+	// Present only when the event describes an attempt to create a
+	// socket. These are the domain, type, and protocol arguments passed
+	// to socket(2). The type may include the SOCK_NONBLOCK and
+	// SOCK_CLOEXEC flags.
+	SocketFamily   uint32 `protobuf:"varint,18,opt,name=socket_family,json=socketFamily" json:"socket_family,omitempty"`
+	SocketType     uint32 `protobuf:"varint,19,opt,name=socket_type,json=socketType" json:"socket_type,omitempty"`
+	SocketProtocol uint32 `protobuf:"varint,20,opt,name=socket_protocol,json=socketProtocol" json:"socket_protocol,omitempty"`
+	// Present only when the event describes an attempt to connect to a
+	// unix domain socket whose listening process is known. These
+	// identify the process bound to the socket's path or abstract name
+	// in the connecting process's namespaces, and its container, if any.
+	// Binds are only traced while connect attempts are subscribed to.
+	// Processes bound before then are found by searching procfs in the
+	// background, at most once a second, so the first connections to
+	// them are not attributed.
+	PeerPid         int32  `protobuf:"varint,21,opt,name=peer_pid,json=peerPid" json:"peer_pid,omitempty"`
+	PeerProcessId   string `protobuf:"bytes,22,opt,name=peer_process_id,json=peerProcessId" json:"peer_process_id,omitempty"`
+	PeerContainerId string `protobuf:"bytes,23,opt,name=peer_container_id,json=peerContainerId" json:"peer_container_id,omitempty"`
+	// Present only when the event describes an attempt to connect to an
+	// IPv4 or IPv6 address belonging to a container on the same host.
+	// These identify that container.
+	RemoteContainerId   string `protobuf:"bytes,24,opt,name=remote_container_id,json=remoteContainerId" json:"remote_container_id,omitempty"`
+	RemoteContainerName string `protobuf:"bytes,25,opt,name=remote_container_name,json=remoteContainerName" json:"remote_container_name,omitempty"`
+	RemoteImageName     string `protobuf:"bytes,26,opt,name=remote_image_name,json=remoteImageName" json:"remote_image_name,omitempty"`
 }
 
 func (m *NetworkEvent) Reset()                    { *m = NetworkEvent{} }
 func (m *NetworkEvent) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEvent) ProtoMessage()               {}
-func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{20} }
 
 func (m *NetworkEvent) GetType() NetworkEventType {
 	if m != nil {
@@ -1420,8 +2887,100 @@ func (m *NetworkEvent) GetBacklog() uint64 {
 	return 0
 }
 
+func (m *NetworkEvent) GetLocalAddress() *NetworkAddress {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return nil
+}
+
+func (m *NetworkEvent) GetRemoteAddress() *NetworkAddress {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return nil
+}
+
+func (m *NetworkEvent) GetOldTcpState() TCPState {
+	if m != nil {
+		return m.OldTcpState
+	}
+	return TCPState_TCP_STATE_UNKNOWN
+}
+
+func (m *NetworkEvent) GetNewTcpState() TCPState {
+	if m != nil {
+		return m.NewTcpState
+	}
+	return TCPState_TCP_STATE_UNKNOWN
+}
+
+func (m *NetworkEvent) GetSocketFamily() uint32 {
+	if m != nil {
+		return m.SocketFamily
+	}
+	return 0
+}
+
+func (m *NetworkEvent) GetSocketType() uint32 {
+	if m != nil {
+		return m.SocketType
+	}
+	return 0
+}
+
+func (m *NetworkEvent) GetSocketProtocol() uint32 {
+	if m != nil {
+		return m.SocketProtocol
+	}
+	return 0
+}
+
+func (m *NetworkEvent) GetPeerPid() int32 {
+	if m != nil {
+		return m.PeerPid
+	}
+	return 0
+}
+
+func (m *NetworkEvent) GetPeerProcessId() string {
+	if m != nil {
+		return m.PeerProcessId
+	}
+	return ""
+}
+
+func (m *NetworkEvent) GetPeerContainerId() string {
+	if m != nil {
+		return m.PeerContainerId
+	}
+	return ""
+}
+
+func (m *NetworkEvent) GetRemoteContainerId() string {
+	if m != nil {
+		return m.RemoteContainerId
+	}
+	return ""
+}
+
+func (m *NetworkEvent) GetRemoteContainerName() string {
+	if m != nil {
+		return m.RemoteContainerName
+	}
+	return ""
+}
+
+func (m *NetworkEvent) GetRemoteImageName() string {
+	if m != nil {
+		return m.RemoteImageName
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TelemetryEvent)(nil), "capsule8.api.v0.TelemetryEvent")
+	proto.RegisterType((*LostEvent)(nil), "capsule8.api.v0.LostEvent")
 	proto.RegisterType((*ChargenEvent)(nil), "capsule8.api.v0.ChargenEvent")
 	proto.RegisterType((*TickerEvent)(nil), "capsule8.api.v0.TickerEvent")
 	proto.RegisterType((*ContainerEvent)(nil), "capsule8.api.v0.ContainerEvent")
@@ -1429,127 +2988,257 @@ func init() {
 	proto.RegisterType((*SyscallEvent)(nil), "capsule8.api.v0.SyscallEvent")
 	proto.RegisterType((*FileEvent)(nil), "capsule8.api.v0.FileEvent")
 	proto.RegisterType((*Process)(nil), "capsule8.api.v0.Process")
+	proto.RegisterType((*Credentials)(nil), "capsule8.api.v0.Credentials")
+	proto.RegisterType((*CredentialsEvent)(nil), "capsule8.api.v0.CredentialsEvent")
+	proto.RegisterType((*SignalEvent)(nil), "capsule8.api.v0.SignalEvent")
+	proto.RegisterType((*Namespace)(nil), "capsule8.api.v0.Namespace")
+	proto.RegisterType((*NamespaceEvent)(nil), "capsule8.api.v0.NamespaceEvent")
+	proto.RegisterType((*KernelModuleEvent)(nil), "capsule8.api.v0.KernelModuleEvent")
+	proto.RegisterType((*ProcessAccessEvent)(nil), "capsule8.api.v0.ProcessAccessEvent")
+	proto.RegisterType((*MemoryEvent)(nil), "capsule8.api.v0.MemoryEvent")
+	proto.RegisterType((*FlowEvent)(nil), "capsule8.api.v0.FlowEvent")
 	proto.RegisterType((*KernelFunctionCallEvent)(nil), "capsule8.api.v0.KernelFunctionCallEvent")
 	proto.RegisterType((*KernelFunctionCallEvent_FieldValue)(nil), "capsule8.api.v0.KernelFunctionCallEvent.FieldValue")
+	proto.RegisterType((*TracepointEvent)(nil), "capsule8.api.v0.TracepointEvent")
 	proto.RegisterType((*NetworkEvent)(nil), "capsule8.api.v0.NetworkEvent")
+	proto.RegisterEnum("capsule8.api.v0.LostEventType", LostEventType_name, LostEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.ContainerEventType", ContainerEventType_name, ContainerEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.ProcessEventType", ProcessEventType_name, ProcessEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.SyscallEventType", SyscallEventType_name, SyscallEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.FileEventType", FileEventType_name, FileEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.SignalEventType", SignalEventType_name, SignalEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.NamespaceEventType", NamespaceEventType_name, NamespaceEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.KernelModuleEventType", KernelModuleEventType_name, KernelModuleEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.ProcessAccessEventType", ProcessAccessEventType_name, ProcessAccessEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.MemoryEventType", MemoryEventType_name, MemoryEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.FlowEventType", FlowEventType_name, FlowEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.KernelFunctionCallEventType", KernelFunctionCallEventType_name, KernelFunctionCallEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.NetworkEventType", NetworkEventType_name, NetworkEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.TCPState", TCPState_name, TCPState_value)
 	proto.RegisterEnum("capsule8.api.v0.KernelFunctionCallEvent_FieldType", KernelFunctionCallEvent_FieldType_name, KernelFunctionCallEvent_FieldType_value)
 }
 
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_event.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 1706 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x31, 0x77, 0xdb, 0xc8,
-	0x11, 0x36, 0x48, 0x4a, 0x24, 0x87, 0x14, 0x05, 0x6d, 0x74, 0x77, 0x38, 0xc9, 0x27, 0x51, 0x94,
-	0x7d, 0x66, 0x94, 0x3c, 0xd9, 0xa6, 0x64, 0x9f, 0x93, 0x26, 0x4f, 0x86, 0xc0, 0x18, 0x91, 0x0c,
-	0x2a, 0x4b, 0xc8, 0x77, 0xae, 0xf0, 0x60, 0x60, 0x45, 0x23, 0x22, 0x01, 0x1e, 0x00, 0x3a, 0x56,
-	0x9b, 0x2e, 0x45, 0x8a, 0x54, 0x29, 0xf3, 0x0f, 0xf2, 0x17, 0x52, 0x27, 0x7f, 0x22, 0x75, 0xfa,
-	0xd4, 0x79, 0x79, 0x3b, 0xbb, 0x20, 0x29, 0x89, 0xb0, 0x2e, 0xdd, 0x75, 0xbb, 0xdf, 0xf7, 0xcd,
-	0xec, 0xec, 0x0c, 0x67, 0x16, 0x8f, 0xf0, 0xd0, 0x73, 0xc7, 0xc9, 0x64, 0xc8, 0x5e, 0x3c, 0x76,
-	0xc7, 0xc1, 0xe3, 0x0f, 0x4f, 0x1e, 0xa7, 0x6c, 0xc8, 0x46, 0x2c, 0x8d, 0xaf, 0x1c, 0xf6, 0x81,
-	0x85, 0xe9, 0xfe, 0x38, 0x8e, 0xd2, 0x88, 0xac, 0x66, 0xb2, 0x7d, 0x77, 0x1c, 0xec, 0x7f, 0x78,
-	0xb2, 0xb1, 0x79, 0xcb, 0xee, 0x6a, 0xcc, 0x12, 0xa1, 0x6e, 0xfd, 0xad, 0x0c, 0x0d, 0x3b, 0xf3,
-	0x63, 0x70, 0x37, 0xa4, 0x01, 0x85, 0xc0, 0xd7, 0x94, 0xa6, 0xd2, 0xae, 0xd2, 0x42, 0xe0, 0x93,
-	0xaf, 0x00, 0xc6, 0x71, 0xe4, 0xb1, 0x24, 0x71, 0x02, 0x5f, 0x2b, 0x20, 0x5e, 0x95, 0x88, 0xe9,
-	0x93, 0x6d, 0xa8, 0x65, 0xf4, 0x38, 0xf0, 0xb5, 0x62, 0x53, 0x69, 0x2f, 0xd1, 0xcc, 0xe2, 0x2c,
-	0xf0, 0xc9, 0x0e, 0xd4, 0xbd, 0x28, 0x4c, 0xdd, 0x20, 0x64, 0x31, 0xf7, 0x50, 0x42, 0x0f, 0xb5,
-	0x29, 0x66, 0xfa, 0x64, 0x13, 0xaa, 0x09, 0x0b, 0x93, 0x08, 0xf9, 0x25, 0xe4, 0x2b, 0x02, 0x30,
-	0x7d, 0x72, 0x08, 0x9f, 0x4b, 0x32, 0x61, 0xdf, 0x4f, 0x58, 0xe8, 0x31, 0x27, 0x9c, 0x8c, 0xde,
-	0xb1, 0x58, 0x5b, 0x6e, 0x2a, 0xed, 0x12, 0x5d, 0x17, 0x6c, 0x5f, 0x92, 0x16, 0x72, 0xa4, 0x03,
-	0x9f, 0x49, 0xab, 0x51, 0x14, 0x46, 0x69, 0x30, 0x62, 0x4e, 0xe8, 0x86, 0x51, 0xa2, 0x95, 0x9b,
-	0x4a, 0xbb, 0x48, 0x7f, 0x22, 0xc8, 0xd7, 0x92, 0xb3, 0x38, 0x45, 0x8e, 0x60, 0x35, 0xbb, 0xca,
-	0x30, 0x08, 0x99, 0x3b, 0x60, 0x5a, 0xa5, 0x59, 0x6c, 0xd7, 0x3a, 0xda, 0xfe, 0x8d, 0xa4, 0xee,
-	0x9f, 0x09, 0x1d, 0x6d, 0x48, 0x83, 0x53, 0xa1, 0x27, 0x0f, 0xa1, 0x31, 0xbb, 0x6c, 0xe8, 0x8e,
-	0x98, 0xb6, 0x85, 0xd7, 0x59, 0x99, 0xa2, 0x96, 0x3b, 0x62, 0xe4, 0x4b, 0xa8, 0x04, 0x23, 0x77,
-	0xc0, 0xf8, 0x7d, 0xb7, 0x51, 0x50, 0xc6, 0xbd, 0x89, 0xe9, 0x16, 0x14, 0x5a, 0x37, 0x45, 0xba,
-	0x11, 0x41, 0xcb, 0x5f, 0x40, 0x39, 0xb9, 0x4a, 0x3c, 0x77, 0x38, 0xd4, 0xa0, 0xa9, 0xb4, 0x6b,
-	0x9d, 0xaf, 0x6e, 0xc5, 0xd6, 0x17, 0x3c, 0x56, 0xf3, 0xd5, 0x3d, 0x9a, 0xe9, 0xb9, 0xa9, 0x8c,
-	0x56, 0xab, 0xe5, 0x98, 0xca, 0x6b, 0x4d, 0x4d, 0xa5, 0x9e, 0x3c, 0x81, 0xd2, 0x45, 0x30, 0x64,
-	0x5a, 0x1d, 0xed, 0x36, 0x6e, 0xd9, 0x75, 0x83, 0x21, 0xcb, 0x8c, 0x50, 0x49, 0x4e, 0xa0, 0x76,
-	0xc9, 0xe2, 0x90, 0x0d, 0x1d, 0x8c, 0x75, 0x05, 0x0d, 0xdb, 0xb7, 0x0c, 0x4f, 0x50, 0xd3, 0x9d,
-	0x84, 0x5e, 0x1a, 0x44, 0xa1, 0x3e, 0x17, 0x36, 0x08, 0x73, 0x5d, 0x46, 0x1e, 0xb2, 0xf4, 0xf7,
-	0x51, 0x7c, 0xa9, 0x35, 0x72, 0x22, 0xb7, 0x04, 0x3f, 0x8d, 0x5c, 0xea, 0xc9, 0xaf, 0xa0, 0x3a,
-	0x4d, 0xbd, 0xb6, 0x8e, 0xc6, 0xdb, 0xb7, 0x8c, 0xf5, 0x4c, 0x91, 0x99, 0xcf, 0x6c, 0xf8, 0xd9,
-	0xde, 0x7b, 0x37, 0x1e, 0xb0, 0x50, 0xf3, 0x73, 0xce, 0xd6, 0x05, 0x3f, 0x3d, 0x5b, 0xea, 0xc9,
-	0x73, 0x58, 0x4e, 0x03, 0xef, 0x92, 0xc5, 0x1a, 0x43, 0xcb, 0xfb, 0xb7, 0x2c, 0x6d, 0xa4, 0x33,
-	0x43, 0xa9, 0x26, 0x6b, 0x50, 0xf4, 0xc6, 0x13, 0xed, 0x1f, 0x0a, 0xf6, 0x12, 0x5f, 0xbf, 0x2c,
-	0xc3, 0x12, 0x36, 0x79, 0xeb, 0x18, 0xea, 0xf3, 0xc7, 0x91, 0x75, 0x58, 0x0a, 0x42, 0x9f, 0x7d,
-	0xc4, 0x86, 0x2d, 0x51, 0xb1, 0x21, 0x5b, 0x00, 0x3c, 0x08, 0xd7, 0x4b, 0x59, 0x9c, 0xc8, 0x9e,
-	0x9d, 0x43, 0x5a, 0x26, 0xd4, 0xe6, 0x8e, 0x26, 0x1a, 0x94, 0x13, 0xe6, 0x45, 0xa1, 0x9f, 0xa0,
-	0x9b, 0x22, 0xcd, 0xb6, 0xa4, 0x09, 0x35, 0x6c, 0x1b, 0xc9, 0x16, 0x90, 0x9d, 0x87, 0x5a, 0x7f,
-	0x2e, 0x42, 0xe3, 0x7a, 0xfe, 0xc8, 0x37, 0x50, 0xe2, 0x33, 0x06, 0x7d, 0x35, 0x3a, 0xbb, 0x77,
-	0xa4, 0xdb, 0xbe, 0x1a, 0x33, 0x8a, 0x06, 0x84, 0x40, 0x09, 0x7f, 0xf5, 0x22, 0x60, 0x5c, 0x5f,
-	0x6b, 0x15, 0xf8, 0x54, 0xab, 0xd4, 0x6e, 0xb6, 0xca, 0x97, 0x50, 0x79, 0x1f, 0x25, 0x29, 0x8e,
-	0x25, 0x5e, 0xf9, 0x35, 0x5a, 0xe6, 0x7b, 0x3e, 0x93, 0x36, 0xa1, 0xca, 0x3e, 0x06, 0xa9, 0xe3,
-	0x45, 0xbe, 0xe8, 0xd0, 0x35, 0x5a, 0xe1, 0x80, 0x1e, 0xf9, 0x8c, 0x4f, 0x34, 0x24, 0x93, 0xd4,
-	0x4d, 0x27, 0x09, 0xf6, 0xe7, 0x0a, 0x05, 0x0e, 0xf5, 0x11, 0x99, 0x09, 0x82, 0x41, 0xe8, 0x0e,
-	0xb1, 0x47, 0x33, 0x01, 0x22, 0xa4, 0x0d, 0xaa, 0x74, 0x1f, 0x33, 0xc7, 0x9f, 0x8c, 0xc6, 0xcc,
-	0xd7, 0x76, 0x9a, 0x4a, 0xbb, 0x42, 0x1b, 0xe2, 0x94, 0x98, 0x1d, 0x23, 0x4a, 0x7e, 0x0e, 0xc4,
-	0x8f, 0x78, 0x21, 0x1c, 0x2f, 0x0a, 0x2f, 0x82, 0x81, 0xf3, 0xbb, 0x24, 0x12, 0x3f, 0xb4, 0x2a,
-	0x55, 0x05, 0xa3, 0x23, 0xf1, 0x9b, 0x24, 0x0a, 0xc9, 0xd7, 0xb0, 0x1a, 0x79, 0xc1, 0x35, 0x29,
-	0x13, 0xe3, 0x25, 0xf2, 0x82, 0x99, 0xae, 0xf5, 0xef, 0x02, 0xd4, 0xe7, 0x5b, 0x99, 0x3c, 0xbb,
-	0x56, 0x91, 0x9d, 0x4f, 0xf6, 0xfd, 0x5c, 0x3d, 0x1e, 0x40, 0xe3, 0x22, 0x8a, 0x2f, 0x1d, 0xef,
-	0x7d, 0x30, 0xf4, 0x31, 0x8f, 0x80, 0xb9, 0xaa, 0x73, 0x54, 0xe7, 0x20, 0x4f, 0x66, 0x0b, 0x56,
-	0xe6, 0x54, 0x81, 0x2f, 0x2b, 0x51, 0x9b, 0x8a, 0x4c, 0x9f, 0xec, 0xc2, 0x0a, 0xfb, 0xc8, 0x3c,
-	0x87, 0xcf, 0x06, 0xac, 0xd6, 0x3a, 0x6a, 0xea, 0x1c, 0xec, 0x4a, 0x8c, 0xec, 0xc1, 0x1a, 0x8a,
-	0xbc, 0x68, 0x34, 0x72, 0x43, 0x1f, 0x87, 0xb0, 0xf6, 0x59, 0xb3, 0xd8, 0xae, 0xd2, 0x55, 0x4e,
-	0xe8, 0x02, 0xe7, 0xb3, 0xf6, 0x47, 0x53, 0xc1, 0xd6, 0xbf, 0x14, 0xa8, 0xcf, 0x4f, 0xdc, 0x3b,
-	0x73, 0x3d, 0x2f, 0x9e, 0xcb, 0xb5, 0x78, 0x76, 0x45, 0x83, 0xf1, 0x67, 0x97, 0x40, 0xc9, 0x8d,
-	0x07, 0x4f, 0x30, 0xe3, 0x25, 0x8a, 0x6b, 0x89, 0x3d, 0xc5, 0x04, 0x0b, 0xec, 0xa9, 0xc4, 0x3a,
-	0x38, 0x9a, 0x05, 0xd6, 0x91, 0xd8, 0x01, 0x4e, 0x5d, 0x81, 0x1d, 0x48, 0xec, 0x10, 0x07, 0xa8,
-	0xc0, 0x0e, 0x25, 0xf6, 0x4c, 0x5b, 0x9d, 0x62, 0xcf, 0x88, 0x0a, 0xc5, 0x98, 0xa5, 0x58, 0x9f,
-	0x22, 0xe5, 0xcb, 0xd6, 0x5f, 0x14, 0xa8, 0x4e, 0x07, 0x3c, 0xe9, 0x5c, 0xbb, 0xde, 0x56, 0xfe,
-	0x53, 0x30, 0x77, 0xb7, 0x0d, 0xa8, 0x4c, 0x0b, 0x2f, 0x7a, 0x78, 0xba, 0xe7, 0x4d, 0x1c, 0x8d,
-	0x59, 0xe8, 0x5c, 0x0c, 0xdd, 0x81, 0x78, 0x98, 0xd6, 0x68, 0x95, 0x23, 0x5d, 0x0e, 0xf0, 0x3a,
-	0x23, 0x3d, 0xe2, 0x75, 0xae, 0x8b, 0x3a, 0x73, 0xe0, 0x75, 0xe4, 0xb3, 0xd6, 0x33, 0x28, 0xcb,
-	0x5f, 0x2e, 0x0f, 0x7b, 0x2c, 0x3f, 0x5b, 0xd6, 0x28, 0x5f, 0xf2, 0xa1, 0x26, 0x7f, 0x48, 0x72,
-	0x9e, 0x64, 0xdb, 0xd6, 0x7f, 0x4a, 0xf0, 0x45, 0xce, 0xc3, 0x43, 0xce, 0xa1, 0xea, 0xc6, 0x83,
-	0xc9, 0x88, 0x85, 0x29, 0x1f, 0x86, 0xfc, 0xf5, 0xff, 0xe6, 0x87, 0xbe, 0x5a, 0xfb, 0x47, 0x99,
-	0xa5, 0x11, 0xa6, 0xf1, 0x15, 0x9d, 0x79, 0xda, 0xf8, 0xaf, 0x02, 0xd0, 0x0d, 0xd8, 0xd0, 0x7f,
-	0xe3, 0x0e, 0x27, 0x8c, 0xfc, 0x16, 0xe0, 0x82, 0xef, 0x9c, 0xb9, 0x54, 0x76, 0x7e, 0xf0, 0x31,
-	0xe8, 0x08, 0xd3, 0x5b, 0xbd, 0xc8, 0x96, 0x64, 0x07, 0x6a, 0xef, 0xae, 0x52, 0x96, 0x38, 0x1f,
-	0xf8, 0x09, 0x78, 0xe5, 0x3a, 0x7f, 0x46, 0x11, 0x14, 0xa7, 0xee, 0x42, 0x3d, 0x49, 0xe3, 0x20,
-	0x1c, 0x48, 0x0d, 0xff, 0x56, 0xab, 0xbe, 0xba, 0x47, 0x6b, 0x02, 0x9d, 0x89, 0x82, 0x41, 0xc8,
-	0x7c, 0x29, 0xe2, 0x9f, 0x6b, 0x04, 0x45, 0x88, 0x0a, 0xd1, 0x23, 0x68, 0x4c, 0xc2, 0x6b, 0x32,
-	0xfe, 0xd5, 0x56, 0x7a, 0x75, 0x8f, 0xae, 0x64, 0x38, 0x0a, 0xf9, 0xbb, 0x85, 0xfc, 0xc6, 0xf7,
-	0xd0, 0xb8, 0x9e, 0x1d, 0x5e, 0xb1, 0x4b, 0x76, 0x25, 0x3f, 0x34, 0xf9, 0x92, 0x98, 0x52, 0x8c,
-	0xc1, 0xd7, 0x3a, 0x07, 0xff, 0x5f, 0x42, 0xf0, 0x40, 0x2a, 0x3c, 0xfc, 0xb2, 0xf0, 0x42, 0x69,
-	0xfd, 0x09, 0x7f, 0xb7, 0x59, 0x7e, 0x6a, 0x50, 0x3e, 0xb7, 0x4e, 0xac, 0xde, 0xb7, 0x96, 0x7a,
-	0x8f, 0x54, 0x61, 0xe9, 0xe5, 0x5b, 0xdb, 0xe8, 0xab, 0x0a, 0x01, 0x58, 0xee, 0xdb, 0xd4, 0xb4,
-	0x7e, 0xad, 0x16, 0x38, 0xdc, 0x37, 0x2d, 0xfb, 0x85, 0x5a, 0x44, 0xd8, 0xb4, 0xec, 0xa7, 0xcf,
-	0xd5, 0x52, 0xb6, 0x3e, 0xe8, 0xa8, 0x4b, 0xd9, 0xfa, 0xf9, 0xa1, 0xba, 0xcc, 0xe5, 0xe7, 0x28,
-	0x2f, 0x73, 0xf8, 0x5c, 0xc8, 0x2b, 0xd9, 0xfa, 0xa0, 0xa3, 0x56, 0xb3, 0xf5, 0xf3, 0x43, 0x15,
-	0x5a, 0xff, 0x54, 0xa0, 0x3e, 0xff, 0x99, 0x72, 0xe7, 0xa4, 0x98, 0x17, 0xcf, 0x75, 0xd3, 0xe7,
-	0xb0, 0x9c, 0x44, 0xde, 0xe5, 0x85, 0x2f, 0x67, 0x83, 0xdc, 0xf1, 0x2f, 0x15, 0xd7, 0xf7, 0xe3,
-	0xd9, 0xf7, 0xdd, 0x76, 0x9e, 0xc7, 0x23, 0x21, 0xa3, 0x99, 0x9e, 0xbb, 0x8c, 0x59, 0x32, 0x19,
-	0xa6, 0xd8, 0x62, 0x84, 0xca, 0x1d, 0xef, 0xa1, 0x77, 0xae, 0x77, 0x39, 0x8c, 0x06, 0x72, 0x96,
-	0x64, 0xdb, 0xbd, 0xbf, 0x2b, 0x40, 0x6e, 0xbf, 0xe3, 0xa4, 0x09, 0xf7, 0xf5, 0x9e, 0x65, 0x1f,
-	0x99, 0x96, 0x41, 0x1d, 0xe3, 0x8d, 0x61, 0xd9, 0x8e, 0xfd, 0xf6, 0xcc, 0x70, 0x66, 0xa9, 0xcf,
-	0x53, 0xe8, 0xd4, 0x38, 0xb2, 0x8d, 0x63, 0x55, 0xc9, 0x55, 0xd0, 0x73, 0xcb, 0x12, 0x75, 0xda,
-	0x86, 0xcd, 0x85, 0x0a, 0xe3, 0x3b, 0x93, 0xbb, 0x28, 0x92, 0x16, 0x6c, 0x2d, 0x14, 0x1c, 0x1b,
-	0x7d, 0x9b, 0xf6, 0xde, 0x1a, 0xc7, 0x6a, 0x69, 0xef, 0x8f, 0x0a, 0xa8, 0x37, 0xdf, 0x3d, 0xb2,
-	0x05, 0x1b, 0x67, 0xb4, 0xa7, 0x1b, 0xfd, 0xfe, 0xe2, 0xe8, 0x37, 0xe1, 0x8b, 0x05, 0x7c, 0xb7,
-	0x47, 0x4f, 0x54, 0x25, 0x87, 0x34, 0xbe, 0x33, 0x74, 0xb5, 0x90, 0x4b, 0x9a, 0xb6, 0x5a, 0xdc,
-	0x1b, 0x81, 0x7a, 0xf3, 0x59, 0xe0, 0xa1, 0xf4, 0xdf, 0xf6, 0xf5, 0xa3, 0xd3, 0xd3, 0xc5, 0xa1,
-	0xdc, 0x07, 0x6d, 0x01, 0x6f, 0x58, 0xb6, 0x41, 0x45, 0x2c, 0x8b, 0x58, 0x7e, 0x5c, 0x61, 0xaf,
-	0x0b, 0x2b, 0xd7, 0xc6, 0x34, 0x57, 0x77, 0xcd, 0x53, 0x63, 0xf1, 0x41, 0x1a, 0xac, 0xdf, 0x24,
-	0x7b, 0x67, 0x86, 0xa5, 0x2a, 0x7b, 0x7f, 0x55, 0x60, 0x33, 0xa7, 0x27, 0xd1, 0xed, 0xcf, 0xe0,
-	0xd1, 0x89, 0x41, 0x2d, 0xe3, 0xd4, 0xe9, 0x9e, 0x5b, 0xba, 0x6d, 0xf6, 0x2c, 0x27, 0xff, 0x3e,
-	0x3f, 0x85, 0x87, 0x77, 0x89, 0xb3, 0xcb, 0xb5, 0xe1, 0xc1, 0x9d, 0x52, 0x71, 0xd3, 0x3f, 0x94,
-	0x40, 0xbd, 0xd9, 0x46, 0x3c, 0xb3, 0x96, 0x61, 0x7f, 0xdb, 0xa3, 0x27, 0x8b, 0x23, 0xf9, 0x1a,
-	0x5a, 0x0b, 0x78, 0xbd, 0x67, 0x59, 0x86, 0x6e, 0x3b, 0x47, 0xb6, 0x6d, 0xbc, 0x3e, 0xb3, 0x55,
-	0x85, 0x3c, 0x84, 0x9d, 0x4f, 0xe8, 0xa8, 0xd1, 0x3f, 0x3f, 0xb5, 0xd5, 0x02, 0xd9, 0x85, 0xed,
-	0x05, 0xb2, 0x97, 0xa6, 0x75, 0x3c, 0xf5, 0x85, 0xbf, 0xd8, 0x3c, 0x91, 0x74, 0x54, 0xca, 0x39,
-	0xef, 0xd4, 0xec, 0xdb, 0x86, 0x35, 0x75, 0xb5, 0x44, 0x1e, 0x40, 0x33, 0x5f, 0x26, 0x9d, 0x2d,
-	0xe7, 0x38, 0x3b, 0xd2, 0x75, 0xe3, 0x6c, 0x76, 0xc7, 0x72, 0x8e, 0x33, 0x29, 0x93, 0xce, 0x2a,
-	0x39, 0xce, 0xfa, 0x86, 0x75, 0x6c, 0xf7, 0xa6, 0xce, 0xaa, 0x39, 0xce, 0xa4, 0x4c, 0x3a, 0x03,
-	0xf2, 0x08, 0x76, 0x17, 0xa8, 0xa8, 0xa1, 0xbf, 0xe9, 0xd2, 0xde, 0xeb, 0xa9, 0xbb, 0x5a, 0x4e,
-	0x9d, 0xa6, 0x42, 0xe9, 0xb0, 0xfe, 0x6e, 0x19, 0xff, 0xeb, 0x38, 0xf8, 0x5f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x89, 0x77, 0x94, 0x60, 0x42, 0x11, 0x00, 0x00,
+	// 3499 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x6f, 0xdc, 0xc8,
+	0x72, 0x7f, 0xf3, 0xa1, 0x8f, 0xa9, 0xf9, 0x10, 0xa7, 0x6d, 0xc9, 0xb4, 0xf4, 0x6c, 0xcb, 0xe3,
+	0x2f, 0x3d, 0xbd, 0x07, 0xad, 0x57, 0xf6, 0xee, 0xdb, 0xb7, 0x41, 0x10, 0x8c, 0x47, 0xd4, 0xee,
+	0x44, 0x23, 0x8e, 0xc2, 0xa1, 0xec, 0xe7, 0x13, 0x41, 0x93, 0x2d, 0x99, 0xf1, 0x0c, 0x39, 0x4b,
+	0x72, 0xac, 0x15, 0x72, 0xcd, 0x0b, 0x92, 0x43, 0x0e, 0x01, 0x12, 0x20, 0x39, 0xe5, 0x9f, 0xc8,
+	0x3d, 0x97, 0x04, 0x41, 0x90, 0x3f, 0x21, 0x08, 0x72, 0x0c, 0x90, 0x73, 0xce, 0x41, 0x50, 0xd5,
+	0x4d, 0x0e, 0xe7, 0x83, 0x96, 0x13, 0x24, 0x40, 0x4e, 0x62, 0xff, 0xea, 0x57, 0x35, 0xdd, 0x5d,
+	0xd5, 0x55, 0xd5, 0x2d, 0x78, 0xe2, 0xd8, 0xe3, 0x68, 0x32, 0xe4, 0xdf, 0x7c, 0x61, 0x8f, 0xbd,
+	0x2f, 0x3e, 0x3e, 0xff, 0x22, 0xe6, 0x43, 0x3e, 0xe2, 0x71, 0x78, 0x6d, 0xf1, 0x8f, 0xdc, 0x8f,
+	0x0f, 0xc6, 0x61, 0x10, 0x07, 0x6c, 0x23, 0xa1, 0x1d, 0xd8, 0x63, 0xef, 0xe0, 0xe3, 0xf3, 0xed,
+	0x9d, 0x05, 0xbd, 0xeb, 0x31, 0x8f, 0x04, 0xbb, 0xf5, 0x6f, 0x55, 0x68, 0x98, 0x89, 0x1d, 0x0d,
+	0xcd, 0xb0, 0x06, 0x14, 0x3d, 0x57, 0x2d, 0xec, 0x16, 0xf6, 0x2a, 0x46, 0xd1, 0x73, 0xd9, 0x3d,
+	0x80, 0x71, 0x18, 0x38, 0x3c, 0x8a, 0x2c, 0xcf, 0x55, 0x8b, 0x84, 0x57, 0x24, 0xd2, 0x75, 0xd9,
+	0x03, 0xa8, 0x26, 0xe2, 0xb1, 0xe7, 0xaa, 0xa5, 0xdd, 0xc2, 0xde, 0x8a, 0x91, 0x68, 0x9c, 0x79,
+	0x2e, 0x7b, 0x08, 0x35, 0x27, 0xf0, 0x63, 0xdb, 0xf3, 0x79, 0x88, 0x16, 0xca, 0x64, 0xa1, 0x9a,
+	0x62, 0x5d, 0x97, 0xed, 0x40, 0x25, 0xe2, 0x7e, 0x14, 0x90, 0x7c, 0x85, 0xe4, 0xeb, 0x02, 0xe8,
+	0xba, 0xec, 0x25, 0x6c, 0x49, 0x61, 0xc4, 0x7f, 0x98, 0x70, 0xdf, 0xe1, 0x96, 0x3f, 0x19, 0xbd,
+	0xe3, 0xa1, 0xba, 0xba, 0x5b, 0xd8, 0x2b, 0x1b, 0xb7, 0x85, 0x74, 0x20, 0x85, 0x3a, 0xc9, 0xd8,
+	0x21, 0x6c, 0x4a, 0xad, 0x51, 0xe0, 0x07, 0xb1, 0x37, 0xe2, 0x96, 0x6f, 0xfb, 0x41, 0xa4, 0xae,
+	0xed, 0x16, 0xf6, 0x4a, 0xc6, 0x2d, 0x21, 0x3c, 0x95, 0x32, 0x1d, 0x45, 0xac, 0x0d, 0x1b, 0xc9,
+	0x52, 0x86, 0x9e, 0xcf, 0xed, 0x4b, 0xae, 0xae, 0xef, 0x96, 0xf6, 0xaa, 0x87, 0xea, 0xc1, 0xdc,
+	0xa6, 0x1e, 0x9c, 0x09, 0x9e, 0xd1, 0x90, 0x0a, 0x3d, 0xc1, 0x67, 0x4f, 0xa0, 0x31, 0x5d, 0xac,
+	0x6f, 0x8f, 0xb8, 0x7a, 0x9f, 0x96, 0x53, 0x4f, 0x51, 0xdd, 0x1e, 0x71, 0x76, 0x17, 0xd6, 0xbd,
+	0x91, 0x7d, 0xc9, 0x71, 0xbd, 0x0f, 0x88, 0xb0, 0x46, 0xe3, 0x2e, 0x6d, 0xb7, 0x10, 0x91, 0xf6,
+	0xae, 0xd8, 0x6e, 0x42, 0x48, 0xf3, 0x57, 0xb0, 0x16, 0x5d, 0x47, 0x8e, 0x3d, 0x1c, 0xaa, 0xb0,
+	0x5b, 0xd8, 0xab, 0x1e, 0xde, 0x5b, 0x98, 0xdb, 0x40, 0xc8, 0xc9, 0x9b, 0xdf, 0xff, 0xc4, 0x48,
+	0xf8, 0xa8, 0x2a, 0x67, 0xab, 0x56, 0x73, 0x54, 0xe5, 0xb2, 0x52, 0x55, 0xc9, 0x67, 0xcf, 0xa1,
+	0x7c, 0xe1, 0x0d, 0xb9, 0x5a, 0x23, 0xbd, 0xed, 0x05, 0xbd, 0x63, 0x6f, 0xc8, 0x13, 0x25, 0x62,
+	0xb2, 0x13, 0xa8, 0x7e, 0xe0, 0xa1, 0xcf, 0x87, 0x16, 0xcd, 0xb5, 0x4e, 0x8a, 0x7b, 0x0b, 0x8a,
+	0x27, 0xc4, 0x39, 0x9e, 0xf8, 0x4e, 0xec, 0x05, 0x7e, 0x27, 0x33, 0x6d, 0x10, 0xea, 0x1d, 0x39,
+	0x73, 0x9f, 0xc7, 0x57, 0x41, 0xf8, 0x41, 0x6d, 0xe4, 0xcc, 0x5c, 0x17, 0xf2, 0x74, 0xe6, 0x92,
+	0xcf, 0x34, 0xa8, 0x3a, 0x21, 0x77, 0xb9, 0x1f, 0x7b, 0xf6, 0x30, 0x52, 0x37, 0x48, 0xfd, 0xe1,
+	0x82, 0x7a, 0x67, 0xca, 0x49, 0x4c, 0x64, 0xf5, 0xd8, 0xd7, 0xb0, 0x1a, 0x79, 0x97, 0xbe, 0x3d,
+	0x54, 0x15, 0xb2, 0xf0, 0xd3, 0xc5, 0x5d, 0x27, 0x71, 0xa2, 0x2c, 0xd9, 0xec, 0x77, 0xa0, 0x82,
+	0x7e, 0x8c, 0xc6, 0xb6, 0xc3, 0xd5, 0x26, 0xa9, 0x3e, 0x58, 0x9c, 0x7b, 0xc2, 0x48, 0xb4, 0xa7,
+	0x3a, 0xac, 0x0b, 0x75, 0xb9, 0x8f, 0xa3, 0xc0, 0x9d, 0x0c, 0xb9, 0xca, 0xc8, 0x48, 0x2b, 0x67,
+	0x27, 0x4f, 0x89, 0x94, 0xd8, 0xa9, 0x7d, 0xc8, 0x80, 0xac, 0x07, 0x49, 0xb4, 0x5a, 0xb6, 0x83,
+	0x7f, 0xd4, 0x5b, 0x64, 0xeb, 0x51, 0x5e, 0x18, 0xb4, 0x9d, 0x6c, 0x30, 0xd4, 0xc7, 0x59, 0x94,
+	0xbd, 0x02, 0x88, 0x43, 0xdb, 0xe1, 0xe3, 0xc0, 0xf3, 0x63, 0xf5, 0x0e, 0x59, 0xda, 0x5d, 0xb0,
+	0x64, 0xa6, 0x94, 0xd4, 0xaf, 0x53, 0x2d, 0xdc, 0x9d, 0xf4, 0x5c, 0xa8, 0xb7, 0x73, 0x76, 0xa7,
+	0x93, 0x30, 0xd2, 0xdd, 0x49, 0x75, 0xd0, 0x2d, 0x23, 0x3e, 0x0a, 0xc2, 0x6b, 0x75, 0x33, 0xc7,
+	0x2d, 0xa7, 0x24, 0x4e, 0xdd, 0x22, 0xd8, 0x14, 0xcf, 0xc3, 0xe0, 0x4a, 0xdd, 0xca, 0x8b, 0xe7,
+	0x61, 0x70, 0x35, 0x8d, 0xe7, 0x61, 0x70, 0x85, 0x1a, 0xc3, 0x20, 0x8a, 0xd5, 0xbd, 0x1c, 0x8d,
+	0x5e, 0x10, 0xa5, 0x4b, 0x24, 0x26, 0x06, 0xad, 0xf3, 0xde, 0x0e, 0x2f, 0xb9, 0xaf, 0xba, 0x39,
+	0x41, 0xdb, 0x11, 0xf2, 0x34, 0x68, 0x25, 0x1f, 0x97, 0x15, 0x7b, 0xce, 0x07, 0x1e, 0xaa, 0x3c,
+	0x67, 0x59, 0x26, 0x89, 0xd3, 0x65, 0x09, 0x36, 0x6b, 0x42, 0xc9, 0x19, 0x4f, 0xd4, 0x7f, 0x2c,
+	0x50, 0x12, 0xc6, 0xef, 0x57, 0x6b, 0xb0, 0x42, 0xd5, 0xa1, 0xf5, 0x57, 0x45, 0xa8, 0xa4, 0x93,
+	0x64, 0x4c, 0x2e, 0xa7, 0x40, 0x29, 0x54, 0x4c, 0xf8, 0x39, 0xdc, 0x8e, 0x62, 0x3b, 0x8c, 0xe7,
+	0x33, 0x66, 0x91, 0x32, 0x26, 0x23, 0xd9, 0x6c, 0xc2, 0xfc, 0x05, 0x30, 0xee, 0xbb, 0xf3, 0xfc,
+	0x12, 0xf1, 0x15, 0xee, 0xbb, 0xb3, 0xec, 0x43, 0x28, 0x63, 0xe9, 0xa1, 0x02, 0xd0, 0x38, 0xbc,
+	0x9f, 0xbf, 0x85, 0xe6, 0xf5, 0x98, 0x1b, 0xc4, 0xc5, 0x34, 0x7e, 0xe1, 0x85, 0x51, 0xbc, 0x90,
+	0xfb, 0x57, 0x68, 0xe2, 0xb7, 0x48, 0x38, 0x97, 0xfa, 0x9f, 0xc3, 0xed, 0xa1, 0x1d, 0xc5, 0x39,
+	0xe5, 0x82, 0x0d, 0xed, 0x79, 0x8d, 0xd6, 0x11, 0xd4, 0xb2, 0xae, 0x60, 0xb7, 0x61, 0xc5, 0xf3,
+	0x5d, 0xfe, 0xa3, 0xdc, 0x1e, 0x31, 0x60, 0xf7, 0x01, 0xd0, 0x41, 0xb6, 0x13, 0xf3, 0x30, 0x92,
+	0x85, 0x30, 0x83, 0xb4, 0xba, 0x50, 0xcd, 0xb8, 0x85, 0xa9, 0xb0, 0x16, 0x71, 0x27, 0xf0, 0xdd,
+	0x88, 0xcc, 0x94, 0x8c, 0x64, 0xc8, 0x76, 0xa1, 0x4a, 0x3b, 0x25, 0xa5, 0x62, 0x7f, 0xb3, 0x50,
+	0xeb, 0xcf, 0x4a, 0xd0, 0x98, 0x8d, 0x7b, 0xf6, 0x4b, 0xb9, 0x7b, 0x05, 0xda, 0xbd, 0x47, 0x37,
+	0x1c, 0x93, 0xcc, 0x16, 0x32, 0x28, 0x53, 0x29, 0x11, 0x13, 0x2e, 0xfb, 0xf3, 0xf5, 0x07, 0x3e,
+	0x55, 0x7f, 0xaa, 0xf3, 0xf5, 0xe7, 0x2e, 0xac, 0xbf, 0x0f, 0xa2, 0x98, 0x6a, 0x3d, 0x9e, 0xd8,
+	0xa6, 0xb1, 0x86, 0x63, 0x2c, 0xf4, 0x3b, 0x50, 0xe1, 0x3f, 0x7a, 0xb1, 0xe5, 0x04, 0xae, 0x28,
+	0x7b, 0x4d, 0x63, 0x1d, 0x81, 0x4e, 0xe0, 0x72, 0x6c, 0x13, 0x48, 0x18, 0xc5, 0x76, 0x3c, 0x89,
+	0xa8, 0xe8, 0xd5, 0x0d, 0x40, 0x68, 0x40, 0xc8, 0x94, 0x20, 0xd2, 0xec, 0x6e, 0x86, 0x40, 0x08,
+	0xdb, 0x03, 0x45, 0x9a, 0x0f, 0xb9, 0xe5, 0x4e, 0x46, 0x63, 0xee, 0xaa, 0x0f, 0x77, 0x0b, 0x7b,
+	0xeb, 0x46, 0x43, 0xfc, 0x4a, 0xc8, 0x8f, 0x08, 0xc5, 0xb0, 0x74, 0x03, 0x74, 0x84, 0xe5, 0x04,
+	0xfe, 0x85, 0x77, 0x69, 0xfd, 0x7e, 0x14, 0x88, 0x43, 0x58, 0x31, 0x14, 0x21, 0xe9, 0x90, 0xe0,
+	0x77, 0xa3, 0xc0, 0x67, 0x4f, 0x61, 0x23, 0x70, 0xbc, 0x19, 0x2a, 0x17, 0x35, 0x3b, 0x70, 0xbc,
+	0x29, 0xaf, 0xf5, 0xe7, 0x25, 0xa8, 0x65, 0xeb, 0x23, 0xfb, 0x6a, 0xc6, 0x23, 0x0f, 0x3f, 0x59,
+	0x4c, 0x33, 0xfe, 0x78, 0x0c, 0x8d, 0x8b, 0x20, 0xfc, 0x60, 0x39, 0xef, 0xbd, 0xa1, 0x6b, 0x8d,
+	0xa5, 0x07, 0x9a, 0x46, 0x0d, 0xd1, 0x0e, 0x82, 0xb8, 0x99, 0x2d, 0xa8, 0x67, 0x58, 0x9e, 0x2b,
+	0x3d, 0x51, 0x4d, 0x49, 0x5d, 0x97, 0x3d, 0x82, 0x3a, 0xff, 0x91, 0x3b, 0x16, 0x16, 0x5c, 0xf2,
+	0xd6, 0x6d, 0xe2, 0xd4, 0x10, 0x3c, 0x96, 0x18, 0xdb, 0x87, 0x26, 0x91, 0x9c, 0x60, 0x34, 0xb2,
+	0x7d, 0x97, 0x3a, 0x1b, 0x75, 0x73, 0xb7, 0xb4, 0x57, 0x31, 0x36, 0x50, 0xd0, 0x11, 0x38, 0x36,
+	0x30, 0xec, 0x67, 0xb8, 0xc5, 0xdc, 0xb1, 0xb8, 0xff, 0xd1, 0x0b, 0x03, 0x7f, 0xc4, 0xfd, 0x58,
+	0xdd, 0x9a, 0x52, 0xb5, 0x29, 0xfc, 0xff, 0xc6, 0xd9, 0xad, 0x7f, 0x2d, 0x40, 0x2d, 0xdb, 0xf1,
+	0xdc, 0xe8, 0x96, 0x2c, 0x39, 0xe3, 0x16, 0xd1, 0xf6, 0x8a, 0xb3, 0x88, 0x6d, 0x2f, 0x83, 0xb2,
+	0x1d, 0x5e, 0x3e, 0x27, 0xe7, 0x94, 0x0d, 0xfa, 0x96, 0xd8, 0x97, 0x6a, 0x35, 0xc5, 0xbe, 0x94,
+	0xd8, 0xa1, 0x5a, 0x4b, 0xb1, 0x43, 0x89, 0xbd, 0x50, 0xeb, 0x29, 0xf6, 0x42, 0x62, 0x2f, 0xd5,
+	0x46, 0x8a, 0xbd, 0x94, 0xd8, 0x57, 0xea, 0x46, 0x8a, 0x7d, 0xc5, 0x14, 0x28, 0x85, 0x3c, 0x26,
+	0x57, 0x96, 0x0c, 0xfc, 0x6c, 0xfd, 0x71, 0x11, 0x2a, 0x69, 0x83, 0xc5, 0x0e, 0x67, 0x96, 0x77,
+	0x3f, 0xbf, 0x15, 0xcb, 0xac, 0x6d, 0x1b, 0xd6, 0xd3, 0x18, 0x11, 0xc7, 0x3d, 0x1d, 0xe3, 0x79,
+	0x0f, 0xc6, 0xdc, 0xb7, 0x2e, 0x86, 0xf6, 0xa5, 0x68, 0x0c, 0x9b, 0x46, 0x05, 0x91, 0x63, 0x04,
+	0xd0, 0xcf, 0x24, 0x1e, 0xa1, 0x9f, 0x6b, 0xc2, 0xcf, 0x08, 0x9c, 0xa2, 0x9f, 0x1f, 0x42, 0xcd,
+	0xe7, 0x57, 0xd3, 0xf8, 0xab, 0x8b, 0x18, 0xf5, 0xf9, 0x55, 0x1a, 0x7e, 0x0c, 0xca, 0xa4, 0xda,
+	0x20, 0x55, 0xfa, 0xc6, 0x25, 0x4e, 0x3c, 0x97, 0x56, 0xdd, 0x34, 0xf0, 0x13, 0x91, 0x4b, 0xcf,
+	0xa5, 0xde, 0xaa, 0x69, 0xe0, 0x27, 0xa6, 0x60, 0x31, 0xa3, 0x26, 0x61, 0x62, 0xd0, 0xfa, 0x0a,
+	0xd6, 0xe4, 0xa9, 0x42, 0x95, 0xb1, 0xbc, 0xa7, 0x34, 0x0d, 0xfc, 0xc4, 0x84, 0x2b, 0x83, 0x5c,
+	0xe6, 0xba, 0x64, 0xd8, 0xfa, 0x4d, 0x11, 0xaa, 0x99, 0x0e, 0x2f, 0x99, 0x40, 0x81, 0xc2, 0x2e,
+	0x3b, 0x81, 0xa2, 0x40, 0x2e, 0x85, 0xff, 0xf9, 0x44, 0x5e, 0x68, 0xea, 0x06, 0x7d, 0x13, 0x76,
+	0x29, 0xaf, 0x30, 0x88, 0x25, 0x13, 0x8d, 0x26, 0xf2, 0xde, 0x52, 0x37, 0xc4, 0x80, 0x3d, 0x03,
+	0xbc, 0x87, 0x59, 0x9e, 0xff, 0x9e, 0x87, 0x5e, 0x6c, 0xbf, 0x1b, 0x72, 0x19, 0x48, 0x0d, 0xc7,
+	0x1e, 0x77, 0xa7, 0x28, 0x9e, 0x61, 0x24, 0x8e, 0x79, 0x38, 0xf2, 0xe2, 0x98, 0xbb, 0x32, 0xb6,
+	0x6a, 0x8e, 0x3d, 0x3e, 0x4b, 0xb0, 0x84, 0xc4, 0x2f, 0x2e, 0xb8, 0x13, 0x7b, 0x1f, 0xb9, 0x5a,
+	0x4b, 0x49, 0x5a, 0x82, 0xd1, 0x3d, 0xcb, 0x1e, 0x5b, 0xef, 0x82, 0x89, 0xef, 0x7a, 0xfe, 0xa5,
+	0x0c, 0xbe, 0xaa, 0x63, 0x8f, 0x5f, 0x49, 0xa8, 0x15, 0x82, 0x32, 0xdf, 0xe8, 0xb2, 0x03, 0x28,
+	0x05, 0x43, 0xb1, 0x17, 0xcb, 0x1a, 0x8d, 0x0c, 0xdf, 0x40, 0x22, 0xf2, 0x7d, 0x7e, 0xa5, 0x16,
+	0x3f, 0x87, 0xef, 0xf3, 0xab, 0xd6, 0xbf, 0x17, 0xa1, 0x9a, 0xe9, 0x8d, 0xd9, 0xcb, 0x99, 0xf8,
+	0xdd, 0xfd, 0x54, 0x1f, 0x9d, 0x89, 0xe0, 0xad, 0xb4, 0xff, 0x16, 0x2e, 0x92, 0x23, 0x8c, 0xde,
+	0x88, 0xfb, 0x2e, 0x0f, 0x33, 0x89, 0xb4, 0x22, 0x10, 0x59, 0x92, 0xa4, 0x38, 0xcd, 0xa0, 0xeb,
+	0x02, 0x10, 0x95, 0x2e, 0xc6, 0xa2, 0x2f, 0x8a, 0x99, 0x88, 0xed, 0x8a, 0x40, 0xa4, 0xae, 0x14,
+	0x7b, 0xae, 0x8c, 0xec, 0x75, 0x01, 0x74, 0x29, 0x12, 0x28, 0xf3, 0x89, 0x12, 0x48, 0xdf, 0x18,
+	0x09, 0x3c, 0x0c, 0xfd, 0x80, 0x7a, 0xd1, 0xa6, 0x21, 0x06, 0x88, 0x5e, 0x86, 0xc1, 0x64, 0x4c,
+	0xbd, 0xe6, 0xba, 0x21, 0x06, 0xb8, 0x9e, 0x90, 0x47, 0x93, 0xa1, 0xe8, 0x9c, 0x9b, 0x86, 0x1c,
+	0x61, 0x0c, 0xbf, 0xb7, 0x7d, 0x77, 0xc8, 0x43, 0x55, 0x25, 0xff, 0x25, 0x43, 0x8c, 0x01, 0xf9,
+	0x29, 0x8f, 0xea, 0x5d, 0x11, 0x03, 0x12, 0xa4, 0xd3, 0xda, 0xea, 0x42, 0x25, 0xbd, 0x4c, 0xe0,
+	0x1c, 0xd3, 0x9d, 0xae, 0xc8, 0x7d, 0xa4, 0xce, 0x06, 0x27, 0x5e, 0x4c, 0x3a, 0x1b, 0x9c, 0x39,
+	0x83, 0x32, 0x16, 0x71, 0x8a, 0xf5, 0x75, 0x83, 0xbe, 0x5b, 0x7f, 0x58, 0x84, 0xc6, 0xec, 0xc5,
+	0xe4, 0xc6, 0x16, 0x64, 0x96, 0x9e, 0xf1, 0x5e, 0x7a, 0x98, 0xe5, 0xaf, 0xd2, 0x80, 0x7d, 0x0b,
+	0x90, 0xde, 0x73, 0xb0, 0x6b, 0x2c, 0x2d, 0x6d, 0xac, 0x53, 0xa3, 0x46, 0x86, 0x8d, 0x45, 0xdb,
+	0x19, 0x06, 0x3e, 0x5f, 0xa8, 0xa2, 0x75, 0x82, 0xd3, 0x32, 0xfa, 0x18, 0x1a, 0x59, 0x5e, 0x1a,
+	0x05, 0xb5, 0x29, 0xad, 0xeb, 0x62, 0x53, 0x13, 0xf1, 0xd8, 0x8f, 0xac, 0x8b, 0x24, 0x0e, 0xd6,
+	0x68, 0x7c, 0xec, 0xb6, 0xfe, 0x00, 0x9a, 0x0b, 0x37, 0x2b, 0xf6, 0xed, 0xcc, 0x46, 0x3c, 0xbd,
+	0xf9, 0x2e, 0x76, 0x43, 0x3b, 0xb6, 0x05, 0xab, 0xd8, 0xbb, 0xc5, 0x91, 0xcc, 0x36, 0x72, 0xd4,
+	0xfa, 0xa7, 0x22, 0xb0, 0xc5, 0xbb, 0x18, 0xfb, 0xad, 0x99, 0x9f, 0x7f, 0xf6, 0x19, 0xd7, 0xb7,
+	0xcc, 0xef, 0xcf, 0x46, 0x7d, 0xf1, 0x93, 0x51, 0x5f, 0x9a, 0x8b, 0xfa, 0x03, 0xb8, 0x25, 0x85,
+	0x4b, 0x5e, 0x74, 0x9a, 0x42, 0xd4, 0xc9, 0xbc, 0xeb, 0x3c, 0x81, 0xc6, 0x98, 0xae, 0x7b, 0x56,
+	0x88, 0x0d, 0x77, 0x14, 0xcb, 0x24, 0x58, 0x17, 0xa8, 0x21, 0x40, 0x7a, 0x42, 0x12, 0x34, 0xdb,
+	0x75, 0x43, 0x99, 0x01, 0x41, 0x40, 0x6d, 0xd7, 0x0d, 0x33, 0x04, 0xd7, 0x8e, 0x6d, 0xb5, 0x96,
+	0x25, 0x1c, 0xd9, 0xb1, 0x8d, 0x87, 0x23, 0xe4, 0xa3, 0x20, 0xe6, 0x96, 0x17, 0x7c, 0x74, 0x7c,
+	0x51, 0x3e, 0xcb, 0x46, 0x4d, 0x80, 0x5d, 0xc2, 0x5a, 0xff, 0x52, 0x80, 0x6a, 0xe6, 0x3a, 0x78,
+	0x63, 0x26, 0xca, 0x70, 0x67, 0xfd, 0x47, 0xb3, 0x2c, 0xca, 0x9a, 0x8d, 0xf3, 0xdb, 0x82, 0xd5,
+	0x21, 0xf7, 0x2f, 0xe3, 0xf7, 0xb4, 0x63, 0x65, 0x43, 0x8e, 0x90, 0x8b, 0xcf, 0x6c, 0xb4, 0x41,
+	0x65, 0x83, 0xbe, 0xa7, 0x67, 0x61, 0x25, 0x7b, 0x16, 0x1a, 0x50, 0xbc, 0x70, 0xe9, 0x86, 0xd2,
+	0x34, 0x8a, 0x17, 0x2e, 0x5a, 0x0c, 0x2e, 0x2e, 0x22, 0x1e, 0xd3, 0x7b, 0x55, 0xd9, 0x90, 0xa3,
+	0x99, 0x4a, 0xbe, 0x3e, 0x5b, 0xc9, 0x5b, 0x7f, 0x5f, 0x82, 0x4a, 0x7a, 0x71, 0xbd, 0xb9, 0x4f,
+	0x48, 0x98, 0x73, 0x59, 0x36, 0x70, 0x3e, 0x5c, 0xb8, 0x72, 0x75, 0x72, 0x34, 0xcd, 0x1a, 0xa5,
+	0x6c, 0xd6, 0x38, 0x82, 0xfa, 0x30, 0x70, 0xec, 0x21, 0x79, 0x0d, 0x9f, 0x13, 0xca, 0x79, 0xef,
+	0x1b, 0xe2, 0x2d, 0xa6, 0x2d, 0x68, 0x46, 0x8d, 0xb4, 0xe4, 0x88, 0x1d, 0x43, 0x43, 0xba, 0x2e,
+	0x31, 0xb3, 0xf2, 0x79, 0x66, 0xa4, 0xc7, 0x13, 0x3b, 0xf7, 0x00, 0xde, 0x5d, 0xc7, 0x3c, 0xb2,
+	0x22, 0xee, 0x27, 0x71, 0x56, 0x21, 0x64, 0x80, 0xdb, 0xf1, 0x04, 0x1a, 0x42, 0x1c, 0x72, 0x87,
+	0x7b, 0x1f, 0xd3, 0x42, 0x5b, 0x27, 0xd4, 0x90, 0x20, 0x06, 0xd2, 0x88, 0x47, 0x91, 0x7d, 0x99,
+	0x18, 0x92, 0x95, 0x36, 0x01, 0xc9, 0xd6, 0xcf, 0xa1, 0x99, 0x92, 0x52, 0x73, 0xa2, 0xdc, 0x2a,
+	0x89, 0x20, 0xb5, 0x98, 0x77, 0xab, 0xbe, 0x9d, 0x77, 0xab, 0x6e, 0xfd, 0x47, 0x19, 0xee, 0xe4,
+	0xbc, 0x8b, 0xb1, 0x73, 0xa8, 0xd8, 0xe1, 0xe5, 0x04, 0x5b, 0x70, 0xbc, 0x56, 0x62, 0xca, 0xfc,
+	0xe5, 0xe7, 0x3e, 0xaa, 0x1d, 0xb4, 0x13, 0x4d, 0xcd, 0x8f, 0xc3, 0x6b, 0x63, 0x6a, 0x69, 0xfb,
+	0x3f, 0x0b, 0x00, 0xc7, 0x1e, 0x1f, 0xba, 0xaf, 0xed, 0xe1, 0x84, 0xb3, 0xdf, 0x03, 0xb8, 0xc0,
+	0x91, 0x95, 0x89, 0xa0, 0xc3, 0xcf, 0xfe, 0x19, 0x32, 0x44, 0x51, 0x55, 0xb9, 0x48, 0x3e, 0xd9,
+	0x43, 0xa8, 0x8a, 0xfd, 0xff, 0x88, 0xbf, 0x40, 0xf1, 0x55, 0xc3, 0xd7, 0x20, 0x02, 0xc5, 0xaf,
+	0x3e, 0x82, 0x5a, 0x14, 0x87, 0x9e, 0x7f, 0x29, 0x39, 0x94, 0x7d, 0xf0, 0x21, 0x4e, 0xa0, 0x53,
+	0x92, 0x77, 0xe9, 0x73, 0x57, 0x92, 0x30, 0xe6, 0x18, 0x91, 0x08, 0x15, 0xa4, 0x67, 0xd0, 0x98,
+	0xf8, 0x33, 0x34, 0x3a, 0x6c, 0xf8, 0x88, 0x35, 0xf1, 0x33, 0x44, 0x7c, 0x1d, 0x21, 0xf9, 0xf6,
+	0x0f, 0xd0, 0x98, 0xdd, 0x1d, 0xec, 0x08, 0x3f, 0xf0, 0x6b, 0x59, 0x3c, 0xf1, 0x93, 0x75, 0x61,
+	0x65, 0x3a, 0xf9, 0xea, 0xe1, 0x8b, 0xff, 0xde, 0x86, 0xd0, 0x0f, 0x1a, 0xc2, 0xc2, 0xb7, 0xc5,
+	0x6f, 0x0a, 0xad, 0x3f, 0x2d, 0x60, 0x5b, 0x9f, 0xec, 0x4f, 0x15, 0xd6, 0xce, 0xf5, 0x13, 0xbd,
+	0xff, 0x46, 0x57, 0x7e, 0xc2, 0x2a, 0xb0, 0xf2, 0xea, 0xad, 0xa9, 0x0d, 0x94, 0x02, 0x03, 0x58,
+	0x1d, 0x98, 0x46, 0x57, 0xff, 0x4e, 0x29, 0x22, 0x3c, 0xe8, 0xea, 0xe6, 0x37, 0x4a, 0x89, 0xe0,
+	0xae, 0x6e, 0x7e, 0xf9, 0xb5, 0x52, 0x4e, 0xbe, 0x5f, 0x1c, 0x2a, 0x2b, 0xc9, 0xf7, 0xd7, 0x2f,
+	0x95, 0x55, 0xa4, 0x9f, 0x13, 0x7d, 0x0d, 0xe1, 0x73, 0x41, 0x5f, 0x4f, 0xbe, 0x5f, 0x1c, 0x2a,
+	0x95, 0xe4, 0xfb, 0xeb, 0x97, 0x0a, 0xb4, 0xfe, 0xb9, 0x00, 0x1b, 0x73, 0xcf, 0x75, 0x69, 0xb1,
+	0x2a, 0x64, 0x8a, 0xd5, 0x11, 0xac, 0x92, 0x5b, 0xb1, 0x9a, 0x63, 0xfc, 0xfd, 0xe2, 0xa6, 0x47,
+	0x3f, 0xb1, 0x7e, 0x19, 0x74, 0x52, 0x77, 0xdb, 0x87, 0x6a, 0x06, 0xfe, 0xbf, 0xdf, 0xed, 0xbf,
+	0x5b, 0x85, 0x5a, 0xf6, 0x8d, 0xf8, 0xc6, 0x6b, 0x62, 0x96, 0xbc, 0x34, 0x45, 0xc2, 0x4c, 0x8a,
+	0xfc, 0x15, 0xac, 0x25, 0xf9, 0xab, 0xfa, 0x79, 0xf9, 0x2b, 0xe1, 0x67, 0x7a, 0x41, 0x4c, 0x36,
+	0x2c, 0xdb, 0x0b, 0xbe, 0xb3, 0x9d, 0x0f, 0xc3, 0x20, 0xe9, 0xe5, 0x93, 0xe1, 0x62, 0xe6, 0x6d,
+	0xfc, 0xef, 0x64, 0xde, 0x8d, 0xff, 0x51, 0xe6, 0xfd, 0x6d, 0xa8, 0x07, 0x98, 0x2b, 0x9c, 0x31,
+	0x5d, 0xf8, 0x39, 0x5d, 0xe3, 0x1a, 0x87, 0x77, 0x17, 0xe3, 0xa2, 0x73, 0x86, 0xf7, 0x7f, 0x6e,
+	0x54, 0x83, 0xa1, 0x6b, 0x3a, 0x63, 0x1a, 0xa0, 0x3a, 0x5e, 0x22, 0xa7, 0xea, 0xcd, 0x1b, 0xd5,
+	0x7d, 0x7e, 0x95, 0xaa, 0x3f, 0x82, 0x3a, 0xba, 0x80, 0xc7, 0xd6, 0x85, 0x3d, 0xf2, 0x86, 0xd7,
+	0xf4, 0x40, 0x5e, 0x37, 0x6a, 0x02, 0x3c, 0x26, 0x0c, 0x1b, 0x08, 0x49, 0x22, 0x9f, 0xdf, 0x22,
+	0x0a, 0x08, 0x88, 0x8e, 0xdf, 0x33, 0xd8, 0x90, 0x04, 0xfa, 0xbf, 0x98, 0x13, 0x0c, 0x29, 0x41,
+	0xd7, 0x8d, 0x86, 0x80, 0xcf, 0x24, 0x8a, 0xad, 0xe2, 0x98, 0xcb, 0xeb, 0xc6, 0x26, 0x3d, 0xb3,
+	0xae, 0xe1, 0x18, 0x5b, 0xa7, 0xa7, 0xb0, 0x21, 0x44, 0xd3, 0xff, 0x96, 0x6d, 0x89, 0x87, 0x24,
+	0x62, 0xa4, 0xff, 0x31, 0xdb, 0x87, 0x26, 0xf1, 0x66, 0x7a, 0xa8, 0x3b, 0xc4, 0x24, 0x03, 0xd9,
+	0x0e, 0xea, 0x00, 0x6e, 0x49, 0x1f, 0xcd, 0xb0, 0x55, 0xd1, 0x71, 0x09, 0x51, 0x96, 0x7f, 0x08,
+	0x9b, 0x0b, 0x7c, 0x3a, 0xc1, 0x77, 0x49, 0xe3, 0xd6, 0x9c, 0x86, 0x2e, 0x5f, 0x88, 0xa4, 0x4e,
+	0xe6, 0xe1, 0x6f, 0x5b, 0xcc, 0x47, 0x08, 0xba, 0xc9, 0xf3, 0xdf, 0xfe, 0x0f, 0x50, 0x9f, 0x79,
+	0xa6, 0x65, 0x3b, 0x70, 0xa7, 0xd7, 0x1f, 0x98, 0x96, 0xf6, 0x5a, 0xd3, 0x4d, 0xcb, 0x7c, 0x7b,
+	0xa6, 0x59, 0xd3, 0x3c, 0xf6, 0x00, 0x76, 0xe6, 0x85, 0x98, 0xca, 0xac, 0x57, 0xe7, 0xc7, 0xc7,
+	0x9a, 0xa1, 0x14, 0x58, 0x0b, 0xee, 0xcf, 0x13, 0x8e, 0xb4, 0x5e, 0xf7, 0xb5, 0x66, 0xbc, 0xb5,
+	0x06, 0x67, 0xfd, 0x7e, 0x4f, 0x29, 0xee, 0xff, 0x6d, 0x01, 0xd8, 0xe2, 0xe3, 0x26, 0xdb, 0x85,
+	0x9f, 0x76, 0xfa, 0xba, 0xd9, 0xee, 0xea, 0x9a, 0xb1, 0xfc, 0xd7, 0xf3, 0x18, 0x1d, 0x43, 0x6b,
+	0x9b, 0xda, 0x91, 0x52, 0xc8, 0x65, 0x18, 0xe7, 0xba, 0x2e, 0x52, 0xee, 0x03, 0xd8, 0x59, 0xca,
+	0xd0, 0x7e, 0xdd, 0x45, 0x13, 0x25, 0x5c, 0xc1, 0x52, 0xc2, 0x91, 0x36, 0x30, 0x8d, 0xfe, 0x5b,
+	0xed, 0x48, 0x29, 0xef, 0xff, 0x49, 0x01, 0x94, 0xf9, 0xc7, 0x40, 0x76, 0x1f, 0xb6, 0xcf, 0x8c,
+	0x7e, 0x47, 0x1b, 0x0c, 0x96, 0xcf, 0x7e, 0x07, 0xee, 0x2c, 0x91, 0x1f, 0xf7, 0x8d, 0x13, 0xa5,
+	0x90, 0x23, 0xd4, 0x7e, 0xad, 0x75, 0x94, 0x62, 0xae, 0xb0, 0x6b, 0x2a, 0xa5, 0xfd, 0x11, 0x28,
+	0xf3, 0x0f, 0x60, 0x38, 0x95, 0xc1, 0xdb, 0x41, 0xa7, 0xdd, 0xeb, 0x2d, 0x9f, 0xca, 0x4f, 0x41,
+	0x5d, 0x22, 0xd7, 0x74, 0x93, 0x7c, 0xb8, 0x03, 0x77, 0x96, 0x49, 0xf1, 0xe7, 0x8a, 0xfb, 0x7f,
+	0x54, 0x84, 0xfa, 0xcc, 0x8b, 0x14, 0xd2, 0x8f, 0xbb, 0x3d, 0x6d, 0xf9, 0x2f, 0xa9, 0x70, 0x7b,
+	0x5e, 0xd8, 0x3f, 0xd3, 0x74, 0xa5, 0xc0, 0xb6, 0x61, 0x6b, 0x51, 0xad, 0xd7, 0xd5, 0x4f, 0x94,
+	0xe2, 0x32, 0x99, 0xa1, 0xe9, 0xed, 0x53, 0x4d, 0x29, 0xb1, 0xbb, 0xb0, 0x39, 0x2f, 0xeb, 0x7c,
+	0x7f, 0xda, 0x3f, 0x52, 0xca, 0xcb, 0x45, 0x38, 0x8f, 0x95, 0x65, 0xa2, 0xd3, 0x93, 0xa3, 0xae,
+	0xa1, 0xac, 0x2e, 0x9b, 0x22, 0x4d, 0x63, 0x6d, 0xd9, 0xca, 0x06, 0x6f, 0x4f, 0x49, 0xb8, 0xbe,
+	0x1f, 0xc0, 0xc6, 0xdc, 0xcb, 0x06, 0xbb, 0x07, 0x77, 0x07, 0xdd, 0xef, 0xf4, 0x76, 0xce, 0xae,
+	0xa3, 0x57, 0x16, 0xc4, 0xdf, 0x69, 0xba, 0x66, 0xb4, 0x4d, 0x4d, 0x29, 0x2c, 0x57, 0x97, 0xa7,
+	0x47, 0x29, 0xee, 0xff, 0x65, 0x01, 0xd8, 0xe2, 0x85, 0x1c, 0x43, 0x1e, 0x77, 0x66, 0x70, 0xd6,
+	0xee, 0x68, 0xb9, 0xbf, 0xbb, 0x94, 0xd1, 0xe9, 0xf5, 0x75, 0x4d, 0x1c, 0x9a, 0x1c, 0x0b, 0x83,
+	0xef, 0xdb, 0x86, 0xa6, 0x14, 0x73, 0x2d, 0x0c, 0x34, 0x53, 0x1f, 0x28, 0xa5, 0xfd, 0xdf, 0x14,
+	0x60, 0x73, 0xe9, 0x15, 0x99, 0x3d, 0x86, 0xdd, 0x13, 0xcd, 0xd0, 0xb5, 0x9e, 0x75, 0xda, 0x3f,
+	0x3a, 0xcf, 0x8b, 0x92, 0x87, 0x70, 0x2f, 0x97, 0xd5, 0xeb, 0xb7, 0xf1, 0x64, 0x3f, 0x82, 0x07,
+	0x9f, 0x30, 0x44, 0xa4, 0xe2, 0xfe, 0xdf, 0x14, 0x60, 0x6b, 0xf9, 0x5d, 0x99, 0x3d, 0x81, 0x87,
+	0xc9, 0x19, 0x6a, 0x77, 0xf2, 0x0f, 0xe9, 0x63, 0xd8, 0xcd, 0xa7, 0x9d, 0x99, 0x46, 0xbb, 0x83,
+	0x3b, 0xf6, 0x49, 0x63, 0xaf, 0x4f, 0x2d, 0x43, 0xc3, 0xe9, 0xb0, 0xa7, 0xd0, 0xfa, 0x24, 0xed,
+	0x8d, 0xd1, 0x35, 0x35, 0xa5, 0xb4, 0x3f, 0x84, 0x8d, 0xb9, 0xab, 0x29, 0xc6, 0xc2, 0xa9, 0x76,
+	0xda, 0x37, 0xde, 0x2e, 0x9f, 0xe6, 0x36, 0x6c, 0x2d, 0x8a, 0x4f, 0x4f, 0xdb, 0x67, 0x4a, 0x01,
+	0x9d, 0xb5, 0x44, 0x76, 0x66, 0xf4, 0x4d, 0xad, 0x83, 0x27, 0xd8, 0x81, 0xfa, 0xcc, 0x55, 0x91,
+	0xc2, 0xbc, 0xd7, 0x7f, 0x93, 0xfb, 0x4b, 0x0b, 0xc2, 0xb3, 0x23, 0x11, 0xb0, 0x78, 0xa8, 0xe6,
+	0x64, 0x9d, 0x5e, 0x7f, 0xa0, 0x29, 0xc5, 0xfd, 0xbf, 0x2e, 0xc0, 0x4e, 0x4e, 0x3f, 0x47, 0xbf,
+	0xf9, 0x73, 0x78, 0x26, 0xdd, 0x79, 0x7c, 0xae, 0x77, 0xcc, 0x6e, 0x5f, 0xb7, 0xf2, 0xd3, 0xd5,
+	0xcf, 0xe0, 0xc9, 0x4d, 0xe4, 0x24, 0x77, 0xed, 0xc1, 0xe3, 0x1b, 0xa9, 0x22, 0x91, 0xfd, 0xc5,
+	0x0a, 0x28, 0xf3, 0x2d, 0x21, 0x05, 0xba, 0x66, 0xbe, 0xe9, 0x1b, 0x27, 0xcb, 0x67, 0xf2, 0x14,
+	0x5a, 0x4b, 0xe4, 0x9d, 0xbe, 0xae, 0x6b, 0x1d, 0xd3, 0x6a, 0x9b, 0xa6, 0x76, 0x7a, 0x66, 0x8a,
+	0x00, 0xf9, 0x04, 0xcf, 0xd0, 0x06, 0xe7, 0x3d, 0x53, 0x29, 0x62, 0x50, 0x2f, 0xa1, 0xbd, 0xea,
+	0xea, 0x47, 0xa9, 0x2d, 0x2a, 0x48, 0x79, 0x24, 0x69, 0xa8, 0x9c, 0xf3, 0x7b, 0xbd, 0xee, 0xc0,
+	0xd4, 0xf4, 0xd4, 0xd4, 0x0a, 0x46, 0x77, 0x3e, 0x4d, 0x1a, 0x5b, 0xcd, 0x31, 0x86, 0x11, 0x7c,
+	0x36, 0x5d, 0xe3, 0x5a, 0x8e, 0x31, 0x49, 0x93, 0xc6, 0xd6, 0x73, 0x8c, 0x0d, 0x34, 0xfd, 0xc8,
+	0xec, 0xa7, 0xc6, 0x2a, 0x39, 0xc6, 0x24, 0x4d, 0x1a, 0x03, 0xf6, 0x0c, 0x1e, 0x2d, 0x61, 0x19,
+	0x5a, 0xe7, 0xf5, 0xb1, 0xd1, 0x3f, 0x4d, 0xcd, 0x55, 0x73, 0xfc, 0x94, 0x12, 0xa5, 0xc1, 0x5a,
+	0x0e, 0xcf, 0xec, 0x9c, 0x59, 0xda, 0xc0, 0x6c, 0xbf, 0xea, 0x75, 0x07, 0xdf, 0x6b, 0x47, 0x4a,
+	0x1d, 0x13, 0x54, 0x0e, 0x8f, 0x02, 0xfe, 0x48, 0x69, 0xe4, 0xcc, 0x0d, 0x29, 0x03, 0xb3, 0x6d,
+	0x62, 0x25, 0x6a, 0xeb, 0xdf, 0x69, 0xca, 0x06, 0x1e, 0xed, 0x65, 0x4b, 0xed, 0x77, 0x4e, 0x34,
+	0x53, 0x51, 0xf6, 0xff, 0xa1, 0x08, 0xeb, 0x49, 0x63, 0xcc, 0x36, 0xa1, 0x39, 0xb5, 0x30, 0x0d,
+	0xc3, 0xbb, 0xb0, 0x39, 0x85, 0xb3, 0x33, 0x2d, 0xb0, 0x2d, 0x60, 0x53, 0xd1, 0xe0, 0xad, 0x8e,
+	0x7b, 0x88, 0xa1, 0xb6, 0x80, 0xe3, 0x66, 0x28, 0x25, 0x76, 0x07, 0x6e, 0x4d, 0xf1, 0xe3, 0xae,
+	0x6e, 0xbd, 0x69, 0x77, 0xcd, 0x2f, 0x95, 0xf2, 0x72, 0x01, 0xde, 0x48, 0x67, 0x04, 0x66, 0xf7,
+	0x54, 0x23, 0x89, 0xb2, 0xca, 0x6e, 0xc1, 0x46, 0x66, 0xb9, 0x94, 0x08, 0xd6, 0xb0, 0xba, 0xce,
+	0x81, 0x82, 0xbe, 0x3e, 0x3b, 0xa3, 0x5e, 0x7b, 0x60, 0x5a, 0xed, 0xce, 0x89, 0x52, 0x61, 0xb7,
+	0x41, 0xc9, 0xe0, 0x14, 0x9b, 0x0a, 0xcc, 0xee, 0x04, 0xda, 0xc1, 0x76, 0xae, 0x8a, 0xe9, 0x69,
+	0x0a, 0xeb, 0xda, 0x9b, 0xe9, 0xd2, 0x6a, 0xef, 0x56, 0xa9, 0xf3, 0x7f, 0xf1, 0x5f, 0x01, 0x00,
+	0x00, 0xff, 0xff, 0xc9, 0x82, 0xba, 0x85, 0x68, 0x27, 0x00, 0x00,
 }
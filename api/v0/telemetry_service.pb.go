@@ -23,6 +23,13 @@ type GetEventsRequest struct {
 	// The Subscription message defines which events should be
 	// returned in the stream.
 	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+	// If not empty, events are delivered at least once. Each event
+	// carries an ack that must be sent to Acknowledge, or else the
+	// event will be re-transmitted. Unacknowledged events are spooled
+	// by the Sensor, so a client that reconnects with the same
+	// delivery_id resumes where it left off, and events that were not
+	// acknowledged before the client disconnected are re-transmitted.
+	DeliveryId string `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId" json:"delivery_id,omitempty"`
 }
 
 func (m *GetEventsRequest) Reset()                    { *m = GetEventsRequest{} }
@@ -37,6 +44,44 @@ func (m *GetEventsRequest) GetSubscription() *Subscription {
 	return nil
 }
 
+func (m *GetEventsRequest) GetDeliveryId() string {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return ""
+}
+
+// A request message to open or modify a subscription. The first request
+// on a Subscribe stream opens the subscription and each of the following
+// requests modifies it. An invalid request closes the stream with an
+// InvalidArgument error.
+type SubscribeRequest struct {
+	// Required in the first request; the subscription to open.
+	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+	// Required in each following request; the modification to apply
+	// to the subscription.
+	Modification *SubscriptionModification `protobuf:"bytes,2,opt,name=modification" json:"modification,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
+
+func (m *SubscribeRequest) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetModification() *SubscriptionModification {
+	if m != nil {
+		return m.Modification
+	}
+	return nil
+}
+
 // A response message containing telemetry events
 type GetEventsResponse struct {
 	// Can publish one or more message(s) at a time
@@ -46,7 +91,7 @@ type GetEventsResponse struct {
 func (m *GetEventsResponse) Reset()                    { *m = GetEventsResponse{} }
 func (m *GetEventsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetEventsResponse) ProtoMessage()               {}
-func (*GetEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
+func (*GetEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
 
 func (m *GetEventsResponse) GetEvents() []*ReceivedTelemetryEvent {
 	if m != nil {
@@ -65,15 +110,15 @@ type ReceivedTelemetryEvent struct {
 	// Recorder.
 	Event *TelemetryEvent `protobuf:"bytes,2,opt,name=event" json:"event,omitempty"`
 	// An opaque ack for the event. If present, this ack must be sent to
-	// the PubsubService's Acknowledge method or else the TelemetryService
-	// will re-transmit the event.
+	// the TelemetryService's Acknowledge method or else the
+	// TelemetryService will re-transmit the event.
 	Ack []byte `protobuf:"bytes,3,opt,name=ack,proto3" json:"ack,omitempty"`
 }
 
 func (m *ReceivedTelemetryEvent) Reset()                    { *m = ReceivedTelemetryEvent{} }
 func (m *ReceivedTelemetryEvent) String() string            { return proto.CompactTextString(m) }
 func (*ReceivedTelemetryEvent) ProtoMessage()               {}
-func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
 
 func (m *ReceivedTelemetryEvent) GetPublishTimeMicros() int64 {
 	if m != nil {
@@ -96,10 +141,276 @@ func (m *ReceivedTelemetryEvent) GetAck() []byte {
 	return nil
 }
 
+// A request message to acknowledge the receipt of telemetry events
+type AcknowledgeRequest struct {
+	// The acks of the events being acknowledged
+	Acks [][]byte `protobuf:"bytes,1,rep,name=acks,proto3" json:"acks,omitempty"`
+}
+
+func (m *AcknowledgeRequest) Reset()                    { *m = AcknowledgeRequest{} }
+func (m *AcknowledgeRequest) String() string            { return proto.CompactTextString(m) }
+func (*AcknowledgeRequest) ProtoMessage()               {}
+func (*AcknowledgeRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+
+func (m *AcknowledgeRequest) GetAcks() [][]byte {
+	if m != nil {
+		return m.Acks
+	}
+	return nil
+}
+
+// A response message to an acknowledgement of telemetry events
+type AcknowledgeResponse struct {
+}
+
+func (m *AcknowledgeResponse) Reset()                    { *m = AcknowledgeResponse{} }
+func (m *AcknowledgeResponse) String() string            { return proto.CompactTextString(m) }
+func (*AcknowledgeResponse) ProtoMessage()               {}
+func (*AcknowledgeResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+// A request message to get information about the Sensor
+type GetSensorInfoRequest struct {
+}
+
+func (m *GetSensorInfoRequest) Reset()                    { *m = GetSensorInfoRequest{} }
+func (m *GetSensorInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetSensorInfoRequest) ProtoMessage()               {}
+func (*GetSensorInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
+// A response message describing the Sensor
+type GetSensorInfoResponse struct {
+	// The ephemeral unique identifier of the Sensor
+	SensorId string `protobuf:"bytes,1,opt,name=sensor_id,json=sensorId" json:"sensor_id,omitempty"`
+	// The version of the Sensor
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	// The opaque identifier of the build of the Sensor
+	Build string `protobuf:"bytes,3,opt,name=build" json:"build,omitempty"`
+	// The hostname of the Sensor's host
+	Hostname string `protobuf:"bytes,10,opt,name=hostname" json:"hostname,omitempty"`
+	// The kernel release of the Sensor's host (i.e. "4.13.0-17-generic")
+	KernelRelease string `protobuf:"bytes,11,opt,name=kernel_release,json=kernelRelease" json:"kernel_release,omitempty"`
+	// The boot identifier of the Sensor's host
+	BootId string `protobuf:"bytes,12,opt,name=boot_id,json=bootId" json:"boot_id,omitempty"`
+	// The tracefs mount used by the Sensor
+	TracefsMount string `protobuf:"bytes,20,opt,name=tracefs_mount,json=tracefsMount" json:"tracefs_mount,omitempty"`
+	// The perf_event cgroup mount used by the Sensor, if any
+	PerfEventMount string `protobuf:"bytes,21,opt,name=perf_event_mount,json=perfEventMount" json:"perf_event_mount,omitempty"`
+	// The kernel probes and tracepoints that the Sensor could not
+	// register, mapped to the errors that occurred
+	UnavailableProbes map[string]string `protobuf:"bytes,22,rep,name=unavailable_probes,json=unavailableProbes" json:"unavailable_probes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The number of events created by the Sensor
+	Events uint64 `protobuf:"varint,30,opt,name=events" json:"events,omitempty"`
+	// The number of subscriptions created by the Sensor
+	Subscriptions int32 `protobuf:"varint,31,opt,name=subscriptions" json:"subscriptions,omitempty"`
+	// The subscriptions that are currently active
+	ActiveSubscriptions []*Subscription `protobuf:"bytes,32,rep,name=active_subscriptions,json=activeSubscriptions" json:"active_subscriptions,omitempty"`
+}
+
+func (m *GetSensorInfoResponse) Reset()                    { *m = GetSensorInfoResponse{} }
+func (m *GetSensorInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetSensorInfoResponse) ProtoMessage()               {}
+func (*GetSensorInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
+
+func (m *GetSensorInfoResponse) GetSensorId() string {
+	if m != nil {
+		return m.SensorId
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetBuild() string {
+	if m != nil {
+		return m.Build
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetKernelRelease() string {
+	if m != nil {
+		return m.KernelRelease
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetBootId() string {
+	if m != nil {
+		return m.BootId
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetTracefsMount() string {
+	if m != nil {
+		return m.TracefsMount
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetPerfEventMount() string {
+	if m != nil {
+		return m.PerfEventMount
+	}
+	return ""
+}
+
+func (m *GetSensorInfoResponse) GetUnavailableProbes() map[string]string {
+	if m != nil {
+		return m.UnavailableProbes
+	}
+	return nil
+}
+
+func (m *GetSensorInfoResponse) GetEvents() uint64 {
+	if m != nil {
+		return m.Events
+	}
+	return 0
+}
+
+func (m *GetSensorInfoResponse) GetSubscriptions() int32 {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return 0
+}
+
+func (m *GetSensorInfoResponse) GetActiveSubscriptions() []*Subscription {
+	if m != nil {
+		return m.ActiveSubscriptions
+	}
+	return nil
+}
+
+// A request message to validate a subscription
+type ValidateSubscriptionRequest struct {
+	// The subscription to validate
+	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+}
+
+func (m *ValidateSubscriptionRequest) Reset()                    { *m = ValidateSubscriptionRequest{} }
+func (m *ValidateSubscriptionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ValidateSubscriptionRequest) ProtoMessage()               {}
+func (*ValidateSubscriptionRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+func (m *ValidateSubscriptionRequest) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+// A response message describing the validation of a subscription
+type ValidateSubscriptionResponse struct {
+	// True if all of the filters in the subscription are valid. A
+	// GetEventsRequest with an invalid subscription is rejected.
+	Valid bool `protobuf:"varint,1,opt,name=valid" json:"valid,omitempty"`
+	// The results of validating each filter in the subscription
+	Filters []*FilterValidation `protobuf:"bytes,2,rep,name=filters" json:"filters,omitempty"`
+}
+
+func (m *ValidateSubscriptionResponse) Reset()                    { *m = ValidateSubscriptionResponse{} }
+func (m *ValidateSubscriptionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ValidateSubscriptionResponse) ProtoMessage()               {}
+func (*ValidateSubscriptionResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
+
+func (m *ValidateSubscriptionResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *ValidateSubscriptionResponse) GetFilters() []*FilterValidation {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+// The result of validating a single filter in a subscription
+type FilterValidation struct {
+	// The path to the filter in the subscription (i.e.
+	// "event_filter.syscall_events[0]")
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// True if the filter is valid
+	Valid bool `protobuf:"varint,2,opt,name=valid" json:"valid,omitempty"`
+	// The filter string passed to the kernel for the filter, if any
+	KernelFilter string `protobuf:"bytes,3,opt,name=kernel_filter,json=kernelFilter" json:"kernel_filter,omitempty"`
+	// The kernel tracepoints and kprobes that events matching the
+	// filter are collected from. Where the Sensor falls back to
+	// alternatives depending on the running kernel, all of them are
+	// listed.
+	Probes []string `protobuf:"bytes,4,rep,name=probes" json:"probes,omitempty"`
+	// The reason that the filter is invalid
+	Error string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *FilterValidation) Reset()                    { *m = FilterValidation{} }
+func (m *FilterValidation) String() string            { return proto.CompactTextString(m) }
+func (*FilterValidation) ProtoMessage()               {}
+func (*FilterValidation) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+func (m *FilterValidation) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FilterValidation) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *FilterValidation) GetKernelFilter() string {
+	if m != nil {
+		return m.KernelFilter
+	}
+	return ""
+}
+
+func (m *FilterValidation) GetProbes() []string {
+	if m != nil {
+		return m.Probes
+	}
+	return nil
+}
+
+func (m *FilterValidation) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*GetEventsRequest)(nil), "capsule8.api.v0.GetEventsRequest")
+	proto.RegisterType((*SubscribeRequest)(nil), "capsule8.api.v0.SubscribeRequest")
 	proto.RegisterType((*GetEventsResponse)(nil), "capsule8.api.v0.GetEventsResponse")
 	proto.RegisterType((*ReceivedTelemetryEvent)(nil), "capsule8.api.v0.ReceivedTelemetryEvent")
+	proto.RegisterType((*AcknowledgeRequest)(nil), "capsule8.api.v0.AcknowledgeRequest")
+	proto.RegisterType((*AcknowledgeResponse)(nil), "capsule8.api.v0.AcknowledgeResponse")
+	proto.RegisterType((*GetSensorInfoRequest)(nil), "capsule8.api.v0.GetSensorInfoRequest")
+	proto.RegisterType((*GetSensorInfoResponse)(nil), "capsule8.api.v0.GetSensorInfoResponse")
+	proto.RegisterType((*ValidateSubscriptionRequest)(nil), "capsule8.api.v0.ValidateSubscriptionRequest")
+	proto.RegisterType((*ValidateSubscriptionResponse)(nil), "capsule8.api.v0.ValidateSubscriptionResponse")
+	proto.RegisterType((*FilterValidation)(nil), "capsule8.api.v0.FilterValidation")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -115,6 +426,17 @@ const _ = grpc.SupportPackageIsVersion4
 type TelemetryServiceClient interface {
 	// Opens a new stream of telemetry events
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (TelemetryService_GetEventsClient, error)
+	// Acknowledges events received from a GetEvents stream that
+	// requested reliable delivery
+	Acknowledge(ctx context.Context, in *AcknowledgeRequest, opts ...grpc.CallOption) (*AcknowledgeResponse, error)
+	// Returns the identity, capabilities, and health of the Sensor
+	GetSensorInfo(ctx context.Context, in *GetSensorInfoRequest, opts ...grpc.CallOption) (*GetSensorInfoResponse, error)
+	// Validates a subscription without creating it and explains how
+	// each of its filters is compiled
+	ValidateSubscription(ctx context.Context, in *ValidateSubscriptionRequest, opts ...grpc.CallOption) (*ValidateSubscriptionResponse, error)
+	// Opens a new stream of telemetry events for a subscription that
+	// may be modified while the stream is open
+	Subscribe(ctx context.Context, opts ...grpc.CallOption) (TelemetryService_SubscribeClient, error)
 }
 
 type telemetryServiceClient struct {
@@ -157,11 +479,80 @@ func (x *telemetryServiceGetEventsClient) Recv() (*GetEventsResponse, error) {
 	return m, nil
 }
 
+func (c *telemetryServiceClient) Acknowledge(ctx context.Context, in *AcknowledgeRequest, opts ...grpc.CallOption) (*AcknowledgeResponse, error) {
+	out := new(AcknowledgeResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/Acknowledge", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) GetSensorInfo(ctx context.Context, in *GetSensorInfoRequest, opts ...grpc.CallOption) (*GetSensorInfoResponse, error) {
+	out := new(GetSensorInfoResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetSensorInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) ValidateSubscription(ctx context.Context, in *ValidateSubscriptionRequest, opts ...grpc.CallOption) (*ValidateSubscriptionResponse, error) {
+	out := new(ValidateSubscriptionResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/ValidateSubscription", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) Subscribe(ctx context.Context, opts ...grpc.CallOption) (TelemetryService_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TelemetryService_serviceDesc.Streams[1], c.cc, "/capsule8.api.v0.TelemetryService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &telemetryServiceSubscribeClient{stream}
+	return x, nil
+}
+
+type TelemetryService_SubscribeClient interface {
+	Send(*SubscribeRequest) error
+	Recv() (*GetEventsResponse, error)
+	grpc.ClientStream
+}
+
+type telemetryServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *telemetryServiceSubscribeClient) Send(m *SubscribeRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *telemetryServiceSubscribeClient) Recv() (*GetEventsResponse, error) {
+	m := new(GetEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for TelemetryService service
 
 type TelemetryServiceServer interface {
 	// Opens a new stream of telemetry events
 	GetEvents(*GetEventsRequest, TelemetryService_GetEventsServer) error
+	// Acknowledges events received from a GetEvents stream that
+	// requested reliable delivery
+	Acknowledge(context.Context, *AcknowledgeRequest) (*AcknowledgeResponse, error)
+	// Returns the identity, capabilities, and health of the Sensor
+	GetSensorInfo(context.Context, *GetSensorInfoRequest) (*GetSensorInfoResponse, error)
+	// Validates a subscription without creating it and explains how
+	// each of its filters is compiled
+	ValidateSubscription(context.Context, *ValidateSubscriptionRequest) (*ValidateSubscriptionResponse, error)
+	// Opens a new stream of telemetry events for a subscription that
+	// may be modified while the stream is open
+	Subscribe(TelemetryService_SubscribeServer) error
 }
 
 func RegisterTelemetryServiceServer(s *grpc.Server, srv TelemetryServiceServer) {
@@ -189,16 +580,115 @@ func (x *telemetryServiceGetEventsServer) Send(m *GetEventsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TelemetryService_Acknowledge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).Acknowledge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/Acknowledge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).Acknowledge(ctx, req.(*AcknowledgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_GetSensorInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSensorInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetSensorInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetSensorInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetSensorInfo(ctx, req.(*GetSensorInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_ValidateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).ValidateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/ValidateSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).ValidateSubscription(ctx, req.(*ValidateSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TelemetryServiceServer).Subscribe(&telemetryServiceSubscribeServer{stream})
+}
+
+type TelemetryService_SubscribeServer interface {
+	Send(*GetEventsResponse) error
+	Recv() (*SubscribeRequest, error)
+	grpc.ServerStream
+}
+
+type telemetryServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *telemetryServiceSubscribeServer) Send(m *GetEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *telemetryServiceSubscribeServer) Recv() (*SubscribeRequest, error) {
+	m := new(SubscribeRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "capsule8.api.v0.TelemetryService",
 	HandlerType: (*TelemetryServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Acknowledge",
+			Handler:    _TelemetryService_Acknowledge_Handler,
+		},
+		{
+			MethodName: "GetSensorInfo",
+			Handler:    _TelemetryService_GetSensorInfo_Handler,
+		},
+		{
+			MethodName: "ValidateSubscription",
+			Handler:    _TelemetryService_ValidateSubscription_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetEvents",
 			Handler:       _TelemetryService_GetEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _TelemetryService_Subscribe_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "capsule8/api/v0/telemetry_service.proto",
 }
@@ -206,26 +696,61 @@ var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_service.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 336 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x86, 0xd9, 0x06, 0x0b, 0x6e, 0x0b, 0x6d, 0x57, 0x94, 0x50, 0x14, 0xeb, 0x82, 0xb4, 0x78,
-	0x48, 0x4b, 0x45, 0x10, 0x2f, 0xe2, 0x41, 0x3c, 0x79, 0x49, 0xeb, 0xb9, 0xa4, 0x71, 0xa8, 0x4b,
-	0x93, 0xec, 0x9a, 0xd9, 0x04, 0x3c, 0x09, 0xbe, 0x81, 0xf8, 0x68, 0xbe, 0x82, 0x0f, 0x22, 0xd9,
-	0x6d, 0x4b, 0x4d, 0xd4, 0xdb, 0xc2, 0x7c, 0xf3, 0xed, 0x3f, 0x33, 0xb4, 0x1f, 0x06, 0x0a, 0xb3,
-	0x08, 0x2e, 0x87, 0x81, 0x12, 0xc3, 0x7c, 0x34, 0xd4, 0x10, 0x41, 0x0c, 0x3a, 0x7d, 0x99, 0x21,
-	0xa4, 0xb9, 0x08, 0xc1, 0x53, 0xa9, 0xd4, 0x92, 0xb5, 0xd6, 0xa0, 0x17, 0x28, 0xe1, 0xe5, 0xa3,
-	0x2e, 0x2f, 0x77, 0x62, 0x36, 0xc7, 0x30, 0x15, 0x4a, 0x0b, 0x99, 0xd8, 0xa6, 0xee, 0xe9, 0xdf,
-	0x76, 0xc8, 0x21, 0xd1, 0x2b, 0xec, 0x70, 0x21, 0xe5, 0x22, 0x02, 0x03, 0x05, 0x49, 0x22, 0x75,
-	0x50, 0x38, 0xd0, 0x56, 0xf9, 0x03, 0x6d, 0xdf, 0x81, 0xbe, 0x2d, 0x78, 0xf4, 0xe1, 0x39, 0x03,
-	0xd4, 0xec, 0x86, 0x36, 0xb7, 0xbf, 0x73, 0x49, 0x8f, 0x0c, 0x1a, 0xe3, 0x23, 0xaf, 0x14, 0xd2,
-	0x9b, 0x6c, 0x41, 0xfe, 0x8f, 0x16, 0x3e, 0xa5, 0x9d, 0x2d, 0x2d, 0x2a, 0x99, 0x20, 0xb0, 0x6b,
-	0x5a, 0x37, 0xc1, 0xd0, 0x25, 0x3d, 0x67, 0xd0, 0x18, 0xf7, 0x2b, 0x46, 0x1f, 0x42, 0x10, 0x39,
-	0x3c, 0x4e, 0xd7, 0x93, 0x18, 0x83, 0xbf, 0x6a, 0xe3, 0xef, 0x84, 0x1e, 0xfc, 0x8e, 0x30, 0x8f,
-	0xee, 0xa9, 0x6c, 0x1e, 0x09, 0x7c, 0x9a, 0x69, 0x11, 0xc3, 0x2c, 0x16, 0x61, 0x2a, 0xd1, 0x44,
-	0x77, 0xfc, 0xce, 0xaa, 0x34, 0x15, 0x31, 0xdc, 0x9b, 0x02, 0xbb, 0xa0, 0x3b, 0x46, 0xea, 0xd6,
-	0xcc, 0x70, 0xc7, 0x95, 0x28, 0xa5, 0x08, 0x96, 0x66, 0x6d, 0xea, 0x04, 0xe1, 0xd2, 0x75, 0x7a,
-	0x64, 0xd0, 0xf4, 0x8b, 0xe7, 0xf8, 0x95, 0xb6, 0x37, 0xe8, 0xc4, 0x1e, 0x95, 0x2d, 0xe9, 0xee,
-	0x66, 0x7a, 0x76, 0x52, 0x51, 0x97, 0x17, 0xde, 0xe5, 0xff, 0x21, 0x76, 0x79, 0x7c, 0xff, 0xed,
-	0xf3, 0xeb, 0xa3, 0xd6, 0xe2, 0xb4, 0xb8, 0xb4, 0xdd, 0xc7, 0x15, 0x39, 0x1b, 0x91, 0x79, 0xdd,
-	0x1c, 0xf2, 0xfc, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xf2, 0xf7, 0x4e, 0x1e, 0x6d, 0x02, 0x00, 0x00,
+	// 885 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0xdb, 0x36,
+	0x14, 0x8e, 0x62, 0xe7, 0xc7, 0xc7, 0x4e, 0xeb, 0x30, 0x8e, 0x27, 0xb8, 0xdd, 0xa2, 0x2a, 0xcd,
+	0xaa, 0x0d, 0x9b, 0x13, 0x64, 0x18, 0x50, 0x74, 0x18, 0x86, 0x02, 0xeb, 0x8a, 0x5c, 0x04, 0x28,
+	0x94, 0x6c, 0x18, 0x76, 0xa3, 0xd2, 0xd2, 0x71, 0x42, 0x58, 0x16, 0x55, 0x92, 0xd2, 0x90, 0xdb,
+	0x3d, 0xc0, 0x86, 0x61, 0xaf, 0xb0, 0x37, 0xea, 0x2b, 0xec, 0x41, 0x06, 0x91, 0xb4, 0xeb, 0xbf,
+	0xa6, 0xb9, 0xe8, 0x1d, 0xcf, 0x39, 0xdf, 0xf9, 0x0e, 0x75, 0xf8, 0x1d, 0x52, 0xf0, 0x24, 0xa6,
+	0xb9, 0x2c, 0x52, 0x7c, 0x7a, 0x4c, 0x73, 0x76, 0x5c, 0x9e, 0x1c, 0x2b, 0x4c, 0x71, 0x8c, 0x4a,
+	0xdc, 0x44, 0x12, 0x45, 0xc9, 0x62, 0xec, 0xe7, 0x82, 0x2b, 0x4e, 0xee, 0x4f, 0x80, 0x7d, 0x9a,
+	0xb3, 0x7e, 0x79, 0xd2, 0xf3, 0x17, 0x33, 0x65, 0x31, 0x90, 0xb1, 0x60, 0xb9, 0x62, 0x3c, 0x33,
+	0x49, 0xbd, 0xa3, 0xf7, 0xb3, 0x63, 0x89, 0x99, 0xb2, 0xb0, 0x87, 0x57, 0x9c, 0x5f, 0xa5, 0xa8,
+	0x41, 0x34, 0xcb, 0xb8, 0xa2, 0x15, 0x87, 0x34, 0x51, 0xbf, 0x84, 0xf6, 0x4b, 0x54, 0x2f, 0x2a,
+	0xbc, 0x0c, 0xf1, 0x4d, 0x81, 0x52, 0x91, 0xe7, 0xd0, 0x9a, 0x2d, 0xe7, 0x3a, 0x9e, 0x13, 0x34,
+	0x4f, 0x3f, 0xed, 0x2f, 0x6c, 0xb2, 0x7f, 0x31, 0x03, 0x0a, 0xe7, 0x52, 0xc8, 0x01, 0x34, 0x13,
+	0x4c, 0x59, 0x89, 0xe2, 0x26, 0x62, 0x89, 0xbb, 0xee, 0x39, 0x41, 0x23, 0x84, 0x89, 0xeb, 0x2c,
+	0xf1, 0xff, 0x75, 0xa0, 0x6d, 0xf3, 0x07, 0xf8, 0x11, 0x0b, 0x9f, 0x43, 0x6b, 0xcc, 0x13, 0x36,
+	0x64, 0xb1, 0xfe, 0x4c, 0x5d, 0xb9, 0x79, 0xfa, 0xc5, 0xad, 0x14, 0xe7, 0x33, 0x09, 0xe1, 0x5c,
+	0xba, 0x7f, 0x09, 0xbb, 0x33, 0xed, 0x91, 0x39, 0xcf, 0x24, 0x92, 0x1f, 0x60, 0x53, 0x37, 0x58,
+	0xba, 0x8e, 0x57, 0x0b, 0x9a, 0xa7, 0x4f, 0x96, 0xd8, 0x43, 0x8c, 0x91, 0x95, 0x98, 0x5c, 0x4e,
+	0x4e, 0x44, 0x33, 0x84, 0x36, 0xcd, 0xff, 0xdb, 0x81, 0xee, 0x6a, 0x08, 0xe9, 0xc3, 0x5e, 0x5e,
+	0x0c, 0x52, 0x26, 0xaf, 0x23, 0xc5, 0xc6, 0x18, 0x8d, 0x59, 0x2c, 0xb8, 0xd4, 0x9d, 0xa8, 0x85,
+	0xbb, 0x36, 0x74, 0xc9, 0xc6, 0x78, 0xae, 0x03, 0xe4, 0x5b, 0xd8, 0xd0, 0xa4, 0xf6, 0x43, 0x0f,
+	0x96, 0xb6, 0xb2, 0xb0, 0x05, 0x83, 0x26, 0x6d, 0xa8, 0xd1, 0x78, 0xe4, 0xd6, 0x3c, 0x27, 0x68,
+	0x85, 0xd5, 0xd2, 0x0f, 0x80, 0x3c, 0x8f, 0x47, 0x19, 0xff, 0x3d, 0xc5, 0xe4, 0x6a, 0x7a, 0x22,
+	0x04, 0xea, 0x34, 0x1e, 0x99, 0x0f, 0x6d, 0x85, 0x7a, 0xed, 0xef, 0xc3, 0xde, 0x1c, 0xd2, 0x74,
+	0xc5, 0xef, 0x42, 0xe7, 0x25, 0xaa, 0x0b, 0xcc, 0x24, 0x17, 0x67, 0xd9, 0x90, 0x5b, 0x0a, 0xff,
+	0x6d, 0x1d, 0xf6, 0x17, 0x02, 0xb6, 0x8f, 0x0f, 0xa0, 0x21, 0xb5, 0xb7, 0x92, 0x88, 0xa3, 0x25,
+	0xb2, 0x6d, 0x1c, 0x67, 0x09, 0x71, 0x61, 0xab, 0x44, 0x21, 0x27, 0x67, 0xd8, 0x08, 0x27, 0x26,
+	0xe9, 0xc0, 0xc6, 0xa0, 0x60, 0x69, 0xa2, 0x77, 0xdf, 0x08, 0x8d, 0x41, 0x7a, 0xb0, 0x7d, 0xcd,
+	0xa5, 0xca, 0xe8, 0x18, 0x5d, 0x30, 0x5c, 0x13, 0x9b, 0x1c, 0xc1, 0xbd, 0x11, 0x8a, 0x0c, 0xd3,
+	0x48, 0x60, 0x8a, 0x54, 0xa2, 0xdb, 0xd4, 0x88, 0x1d, 0xe3, 0x0d, 0x8d, 0x93, 0x7c, 0x02, 0x5b,
+	0x03, 0xce, 0x55, 0xb5, 0x9b, 0x96, 0x8e, 0x6f, 0x56, 0xe6, 0x59, 0x42, 0x0e, 0x61, 0x47, 0x09,
+	0x1a, 0xe3, 0x50, 0x46, 0x63, 0x5e, 0x64, 0xca, 0xed, 0xe8, 0x70, 0xcb, 0x3a, 0xcf, 0x2b, 0x1f,
+	0x09, 0xa0, 0x9d, 0xa3, 0x18, 0x9a, 0xd9, 0xb3, 0xb8, 0x7d, 0x8d, 0xbb, 0x57, 0xf9, 0x75, 0xfb,
+	0x0d, 0x32, 0x05, 0x52, 0x64, 0xb4, 0xa4, 0x2c, 0xa5, 0x83, 0x14, 0xa3, 0x5c, 0xf0, 0x01, 0x4a,
+	0xb7, 0xab, 0xb5, 0xf4, 0xfd, 0xd2, 0x01, 0xae, 0xec, 0x5d, 0xff, 0xe7, 0x77, 0x04, 0xaf, 0x74,
+	0xfe, 0x8b, 0x4c, 0x89, 0x9b, 0x70, 0xb7, 0x58, 0xf4, 0x93, 0xee, 0x54, 0xad, 0x9f, 0x79, 0x4e,
+	0x50, 0x9f, 0x88, 0x90, 0x3c, 0x86, 0x9d, 0xd9, 0xc9, 0x91, 0xee, 0x81, 0xe7, 0x04, 0x1b, 0xe1,
+	0xbc, 0x93, 0xbc, 0x82, 0x0e, 0x8d, 0x15, 0x2b, 0x31, 0x9a, 0x07, 0x7b, 0x5e, 0xed, 0xc3, 0xa3,
+	0xb9, 0x67, 0x52, 0x67, 0x7d, 0xb2, 0xf7, 0x23, 0x74, 0x57, 0x6f, 0xbe, 0x12, 0xe5, 0x08, 0x6f,
+	0xac, 0x12, 0xaa, 0x65, 0x75, 0xd4, 0x25, 0x4d, 0x0b, 0xb4, 0x12, 0x30, 0xc6, 0xb3, 0xf5, 0xa7,
+	0x8e, 0xff, 0x1a, 0x1e, 0xfc, 0x42, 0x53, 0x96, 0x50, 0x35, 0x47, 0xff, 0xf1, 0x6e, 0x12, 0xff,
+	0x0d, 0x3c, 0x5c, 0x5d, 0xc1, 0xaa, 0xd7, 0xec, 0xcd, 0x2a, 0x77, 0x3b, 0x34, 0x06, 0xf9, 0x0e,
+	0xb6, 0x86, 0x2c, 0x55, 0x28, 0xa4, 0xbb, 0xae, 0x5b, 0xf4, 0x68, 0xa9, 0xe6, 0x4f, 0x3a, 0x6e,
+	0xb9, 0x2b, 0xc6, 0x49, 0x86, 0xff, 0x97, 0x03, 0xed, 0xc5, 0x68, 0x35, 0x82, 0x39, 0x55, 0xd7,
+	0xb6, 0x2d, 0x7a, 0xfd, 0xae, 0xf6, 0xfa, 0x6c, 0xed, 0x43, 0xb0, 0x82, 0x8e, 0x0c, 0xa1, 0x1d,
+	0x90, 0x96, 0x71, 0x1a, 0xe2, 0x4a, 0x0e, 0x56, 0x70, 0x75, 0xaf, 0x56, 0x69, 0xdc, 0x58, 0x15,
+	0x25, 0x0a, 0xc1, 0x85, 0xbb, 0x61, 0x5a, 0xad, 0x8d, 0xd3, 0x3f, 0xeb, 0xd0, 0x9e, 0xde, 0x20,
+	0x17, 0xe6, 0xcd, 0x22, 0x23, 0x68, 0x4c, 0x2f, 0x45, 0xf2, 0x68, 0x95, 0x60, 0xe7, 0xde, 0x93,
+	0x9e, 0x7f, 0x1b, 0xc4, 0xde, 0x1e, 0xfb, 0x7f, 0xbc, 0xfd, 0xef, 0x9f, 0xf5, 0xfb, 0x3e, 0x54,
+	0x0f, 0x99, 0x51, 0xe8, 0x33, 0xe7, 0xcb, 0x13, 0x87, 0xfc, 0x06, 0xcd, 0x99, 0xdb, 0x86, 0x1c,
+	0x2e, 0x71, 0x2d, 0xdf, 0x5a, 0xbd, 0xc7, 0xb7, 0x83, 0x6c, 0xc9, 0x35, 0xf2, 0x1a, 0x76, 0xe6,
+	0xa6, 0x8b, 0x1c, 0x7d, 0x68, 0xfa, 0x0c, 0xff, 0xe7, 0x77, 0x1b, 0x52, 0x7f, 0x8d, 0x14, 0xd0,
+	0x59, 0x25, 0x22, 0xf2, 0xd5, 0x12, 0xc3, 0x2d, 0x6a, 0xee, 0x7d, 0x7d, 0x47, 0xf4, 0xb4, 0xec,
+	0xaf, 0xd0, 0x98, 0x3e, 0xae, 0x2b, 0x4e, 0x68, 0xf1, 0xe1, 0xbd, 0xd3, 0x09, 0xad, 0x05, 0xce,
+	0x89, 0x33, 0xd8, 0xd4, 0xbf, 0x0d, 0xdf, 0xfc, 0x1f, 0x00, 0x00, 0xff, 0xff, 0xa3, 0xb3, 0x3b,
+	0x31, 0xdb, 0x08, 0x00, 0x00,
 }
@@ -16,8 +16,12 @@ It has these top-level messages:
 	IPv4AddressAndPort
 	IPv6Address
 	IPv6AddressAndPort
+	NetlinkAddress
+	PacketAddress
+	VsockAddress
 	NetworkAddress
 	TelemetryEvent
+	LostEvent
 	ChargenEvent
 	TickerEvent
 	ContainerEvent
@@ -25,17 +29,42 @@ It has these top-level messages:
 	SyscallEvent
 	FileEvent
 	Process
+	Credentials
+	CredentialsEvent
+	SignalEvent
+	Namespace
+	NamespaceEvent
+	KernelModuleEvent
+	ProcessAccessEvent
+	MemoryEvent
+	FlowEvent
 	KernelFunctionCallEvent
 	NetworkEvent
 	GetEventsRequest
+	SubscribeRequest
 	GetEventsResponse
 	ReceivedTelemetryEvent
+	AcknowledgeRequest
+	AcknowledgeResponse
+	GetSensorInfoRequest
+	GetSensorInfoResponse
+	ValidateSubscriptionRequest
+	ValidateSubscriptionResponse
+	FilterValidation
 	Subscription
+	SubscriptionModification
 	ContainerFilter
 	EventFilter
 	SyscallEventFilter
 	ProcessEventFilter
 	FileEventFilter
+	CredentialsEventFilter
+	SignalEventFilter
+	NamespaceEventFilter
+	KernelModuleEventFilter
+	ProcessAccessEventFilter
+	MemoryEventFilter
+	FlowEventFilter
 	KernelFunctionCallFilter
 	NetworkEventFilter
 	ContainerEventFilter
@@ -77,6 +106,12 @@ const (
 	NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6 NetworkAddressFamily = 2
 	// AF_LOCAL / AF_UNIX; local filesystem address formats
 	NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_LOCAL NetworkAddressFamily = 3
+	// AF_NETLINK; kernel user interface address formats
+	NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_NETLINK NetworkAddressFamily = 4
+	// AF_PACKET; device level address formats
+	NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_PACKET NetworkAddressFamily = 5
+	// AF_VSOCK; virtual machine socket address formats
+	NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_VSOCK NetworkAddressFamily = 6
 )
 
 var NetworkAddressFamily_name = map[int32]string{
@@ -84,12 +119,18 @@ var NetworkAddressFamily_name = map[int32]string{
 	1: "NETWORK_ADDRESS_FAMILY_INET",
 	2: "NETWORK_ADDRESS_FAMILY_INET6",
 	3: "NETWORK_ADDRESS_FAMILY_LOCAL",
+	4: "NETWORK_ADDRESS_FAMILY_NETLINK",
+	5: "NETWORK_ADDRESS_FAMILY_PACKET",
+	6: "NETWORK_ADDRESS_FAMILY_VSOCK",
 }
 var NetworkAddressFamily_value = map[string]int32{
 	"NETWORK_ADDRESS_FAMILY_UNKNOWN": 0,
 	"NETWORK_ADDRESS_FAMILY_INET":    1,
 	"NETWORK_ADDRESS_FAMILY_INET6":   2,
 	"NETWORK_ADDRESS_FAMILY_LOCAL":   3,
+	"NETWORK_ADDRESS_FAMILY_NETLINK": 4,
+	"NETWORK_ADDRESS_FAMILY_PACKET":  5,
+	"NETWORK_ADDRESS_FAMILY_VSOCK":   6,
 }
 
 func (x NetworkAddressFamily) String() string {
@@ -196,6 +237,114 @@ func (m *IPv6AddressAndPort) GetPort() uint32 {
 	return 0
 }
 
+// A netlink address
+type NetlinkAddress struct {
+	// The port id of the socket, which is 0 for the kernel
+	Pid uint32 `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
+	// The bitmask of multicast groups
+	Groups uint32 `protobuf:"varint,2,opt,name=groups" json:"groups,omitempty"`
+}
+
+func (m *NetlinkAddress) Reset()                    { *m = NetlinkAddress{} }
+func (m *NetlinkAddress) String() string            { return proto.CompactTextString(m) }
+func (*NetlinkAddress) ProtoMessage()               {}
+func (*NetlinkAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *NetlinkAddress) GetPid() uint32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *NetlinkAddress) GetGroups() uint32 {
+	if m != nil {
+		return m.Groups
+	}
+	return 0
+}
+
+// A device level packet address
+type PacketAddress struct {
+	// The physical layer protocol in network byte order (big endian)
+	Protocol uint32 `protobuf:"varint,1,opt,name=protocol" json:"protocol,omitempty"`
+	// The index of the interface, or 0 for any interface
+	Ifindex int32 `protobuf:"zigzag32,2,opt,name=ifindex" json:"ifindex,omitempty"`
+	// The ARP hardware type
+	Hatype uint32 `protobuf:"varint,3,opt,name=hatype" json:"hatype,omitempty"`
+	// The packet type
+	Pkttype uint32 `protobuf:"varint,4,opt,name=pkttype" json:"pkttype,omitempty"`
+	// The physical layer address
+	Address []byte `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *PacketAddress) Reset()                    { *m = PacketAddress{} }
+func (m *PacketAddress) String() string            { return proto.CompactTextString(m) }
+func (*PacketAddress) ProtoMessage()               {}
+func (*PacketAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PacketAddress) GetProtocol() uint32 {
+	if m != nil {
+		return m.Protocol
+	}
+	return 0
+}
+
+func (m *PacketAddress) GetIfindex() int32 {
+	if m != nil {
+		return m.Ifindex
+	}
+	return 0
+}
+
+func (m *PacketAddress) GetHatype() uint32 {
+	if m != nil {
+		return m.Hatype
+	}
+	return 0
+}
+
+func (m *PacketAddress) GetPkttype() uint32 {
+	if m != nil {
+		return m.Pkttype
+	}
+	return 0
+}
+
+func (m *PacketAddress) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+// A virtual machine socket address
+type VsockAddress struct {
+	// The context id of the virtual machine or host
+	Cid uint32 `protobuf:"varint,1,opt,name=cid" json:"cid,omitempty"`
+	// The port
+	Port uint32 `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+}
+
+func (m *VsockAddress) Reset()                    { *m = VsockAddress{} }
+func (m *VsockAddress) String() string            { return proto.CompactTextString(m) }
+func (*VsockAddress) ProtoMessage()               {}
+func (*VsockAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *VsockAddress) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *VsockAddress) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
 // A network address
 type NetworkAddress struct {
 	// The address family that specifies which address format is in use
@@ -204,13 +353,16 @@ type NetworkAddress struct {
 	//	*NetworkAddress_Ipv4Address
 	//	*NetworkAddress_Ipv6Address
 	//	*NetworkAddress_LocalAddress
+	//	*NetworkAddress_NetlinkAddress
+	//	*NetworkAddress_PacketAddress
+	//	*NetworkAddress_VsockAddress
 	Address isNetworkAddress_Address `protobuf_oneof:"address"`
 }
 
 func (m *NetworkAddress) Reset()                    { *m = NetworkAddress{} }
 func (m *NetworkAddress) String() string            { return proto.CompactTextString(m) }
 func (*NetworkAddress) ProtoMessage()               {}
-func (*NetworkAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*NetworkAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type isNetworkAddress_Address interface {
 	isNetworkAddress_Address()
@@ -225,10 +377,22 @@ type NetworkAddress_Ipv6Address struct {
 type NetworkAddress_LocalAddress struct {
 	LocalAddress string `protobuf:"bytes,30,opt,name=local_address,json=localAddress,oneof"`
 }
+type NetworkAddress_NetlinkAddress struct {
+	NetlinkAddress *NetlinkAddress `protobuf:"bytes,40,opt,name=netlink_address,json=netlinkAddress,oneof"`
+}
+type NetworkAddress_PacketAddress struct {
+	PacketAddress *PacketAddress `protobuf:"bytes,50,opt,name=packet_address,json=packetAddress,oneof"`
+}
+type NetworkAddress_VsockAddress struct {
+	VsockAddress *VsockAddress `protobuf:"bytes,60,opt,name=vsock_address,json=vsockAddress,oneof"`
+}
 
-func (*NetworkAddress_Ipv4Address) isNetworkAddress_Address()  {}
-func (*NetworkAddress_Ipv6Address) isNetworkAddress_Address()  {}
-func (*NetworkAddress_LocalAddress) isNetworkAddress_Address() {}
+func (*NetworkAddress_Ipv4Address) isNetworkAddress_Address()    {}
+func (*NetworkAddress_Ipv6Address) isNetworkAddress_Address()    {}
+func (*NetworkAddress_LocalAddress) isNetworkAddress_Address()   {}
+func (*NetworkAddress_NetlinkAddress) isNetworkAddress_Address() {}
+func (*NetworkAddress_PacketAddress) isNetworkAddress_Address()  {}
+func (*NetworkAddress_VsockAddress) isNetworkAddress_Address()   {}
 
 func (m *NetworkAddress) GetAddress() isNetworkAddress_Address {
 	if m != nil {
@@ -265,12 +429,36 @@ func (m *NetworkAddress) GetLocalAddress() string {
 	return ""
 }
 
+func (m *NetworkAddress) GetNetlinkAddress() *NetlinkAddress {
+	if x, ok := m.GetAddress().(*NetworkAddress_NetlinkAddress); ok {
+		return x.NetlinkAddress
+	}
+	return nil
+}
+
+func (m *NetworkAddress) GetPacketAddress() *PacketAddress {
+	if x, ok := m.GetAddress().(*NetworkAddress_PacketAddress); ok {
+		return x.PacketAddress
+	}
+	return nil
+}
+
+func (m *NetworkAddress) GetVsockAddress() *VsockAddress {
+	if x, ok := m.GetAddress().(*NetworkAddress_VsockAddress); ok {
+		return x.VsockAddress
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*NetworkAddress) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _NetworkAddress_OneofMarshaler, _NetworkAddress_OneofUnmarshaler, _NetworkAddress_OneofSizer, []interface{}{
 		(*NetworkAddress_Ipv4Address)(nil),
 		(*NetworkAddress_Ipv6Address)(nil),
 		(*NetworkAddress_LocalAddress)(nil),
+		(*NetworkAddress_NetlinkAddress)(nil),
+		(*NetworkAddress_PacketAddress)(nil),
+		(*NetworkAddress_VsockAddress)(nil),
 	}
 }
 
@@ -291,6 +479,21 @@ func _NetworkAddress_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 	case *NetworkAddress_LocalAddress:
 		b.EncodeVarint(30<<3 | proto.WireBytes)
 		b.EncodeStringBytes(x.LocalAddress)
+	case *NetworkAddress_NetlinkAddress:
+		b.EncodeVarint(40<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NetlinkAddress); err != nil {
+			return err
+		}
+	case *NetworkAddress_PacketAddress:
+		b.EncodeVarint(50<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PacketAddress); err != nil {
+			return err
+		}
+	case *NetworkAddress_VsockAddress:
+		b.EncodeVarint(60<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VsockAddress); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("NetworkAddress.Address has unexpected type %T", x)
@@ -324,6 +527,30 @@ func _NetworkAddress_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		x, err := b.DecodeStringBytes()
 		m.Address = &NetworkAddress_LocalAddress{x}
 		return true, err
+	case 40: // address.netlink_address
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NetlinkAddress)
+		err := b.DecodeMessage(msg)
+		m.Address = &NetworkAddress_NetlinkAddress{msg}
+		return true, err
+	case 50: // address.packet_address
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PacketAddress)
+		err := b.DecodeMessage(msg)
+		m.Address = &NetworkAddress_PacketAddress{msg}
+		return true, err
+	case 60: // address.vsock_address
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(VsockAddress)
+		err := b.DecodeMessage(msg)
+		m.Address = &NetworkAddress_VsockAddress{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -347,6 +574,21 @@ func _NetworkAddress_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(30<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(len(x.LocalAddress)))
 		n += len(x.LocalAddress)
+	case *NetworkAddress_NetlinkAddress:
+		s := proto.Size(x.NetlinkAddress)
+		n += proto.SizeVarint(40<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *NetworkAddress_PacketAddress:
+		s := proto.Size(x.PacketAddress)
+		n += proto.SizeVarint(50<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *NetworkAddress_VsockAddress:
+		s := proto.Size(x.VsockAddress)
+		n += proto.SizeVarint(60<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -359,6 +601,9 @@ func init() {
 	proto.RegisterType((*IPv4AddressAndPort)(nil), "capsule8.api.v0.IPv4AddressAndPort")
 	proto.RegisterType((*IPv6Address)(nil), "capsule8.api.v0.IPv6Address")
 	proto.RegisterType((*IPv6AddressAndPort)(nil), "capsule8.api.v0.IPv6AddressAndPort")
+	proto.RegisterType((*NetlinkAddress)(nil), "capsule8.api.v0.NetlinkAddress")
+	proto.RegisterType((*PacketAddress)(nil), "capsule8.api.v0.PacketAddress")
+	proto.RegisterType((*VsockAddress)(nil), "capsule8.api.v0.VsockAddress")
 	proto.RegisterType((*NetworkAddress)(nil), "capsule8.api.v0.NetworkAddress")
 	proto.RegisterEnum("capsule8.api.v0.NetworkAddressFamily", NetworkAddressFamily_name, NetworkAddressFamily_value)
 }
@@ -366,28 +611,42 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0x4f, 0x6b, 0xe2, 0x40,
-	0x18, 0xc6, 0x8d, 0x2e, 0x11, 0x5f, 0xff, 0x6c, 0x18, 0x3c, 0x08, 0x8a, 0x1b, 0xb2, 0xc8, 0xca,
-	0x1e, 0xa2, 0xa8, 0x84, 0x5e, 0x7a, 0x48, 0xab, 0xa2, 0x68, 0xa3, 0x8c, 0x16, 0xe9, 0x29, 0x4d,
-	0x35, 0xad, 0xa1, 0x69, 0x67, 0x48, 0xd2, 0x88, 0x1f, 0xa4, 0xe7, 0x7e, 0xd5, 0xe2, 0x98, 0xa4,
-	0xda, 0xaa, 0x94, 0xde, 0xde, 0x24, 0xcf, 0xf3, 0xcb, 0x6f, 0x5e, 0x06, 0x8a, 0x73, 0x83, 0xba,
-	0x2f, 0xb6, 0x79, 0x56, 0x33, 0xa8, 0x55, 0xf3, 0xeb, 0x35, 0x6f, 0x4d, 0x4d, 0x57, 0xa6, 0x0e,
-	0xf1, 0x08, 0xfa, 0x1d, 0x7e, 0x94, 0x0d, 0x6a, 0xc9, 0x7e, 0x5d, 0xfa, 0x07, 0xe9, 0xfe, 0xd8,
-	0x6f, 0xa9, 0x8b, 0x85, 0x63, 0xba, 0x2e, 0x2a, 0x40, 0xd2, 0xd8, 0x8e, 0x05, 0x4e, 0xe4, 0xaa,
-	0x49, 0x1c, 0x3e, 0x4a, 0xb7, 0x80, 0x76, 0x82, 0xea, 0xf3, 0x62, 0x4c, 0x1c, 0x0f, 0x29, 0xfb,
-	0xf9, 0x74, 0xa3, 0x24, 0x7f, 0xfa, 0x83, 0xbc, 0xd3, 0x8a, 0x68, 0x08, 0xc1, 0x2f, 0x4a, 0x1c,
-	0xaf, 0x10, 0x17, 0xb9, 0x6a, 0x16, 0xb3, 0x59, 0x6a, 0x32, 0x15, 0x45, 0xfd, 0x88, 0x2c, 0xad,
-	0x87, 0x25, 0xe3, 0xf2, 0x98, 0xcd, 0x48, 0x80, 0x84, 0x4d, 0x56, 0xac, 0xc5, 0xe3, 0xcd, 0x18,
-	0x68, 0x29, 0x3f, 0xd2, 0x52, 0xbe, 0xa5, 0xf5, 0x1a, 0x87, 0x9c, 0x66, 0x7a, 0x2b, 0xe2, 0x3c,
-	0x86, 0x6a, 0xe7, 0xc0, 0xdf, 0x1b, 0x4f, 0x96, 0xbd, 0x66, 0xf4, 0x5c, 0xa3, 0xf2, 0x85, 0xbe,
-	0x5f, 0xe8, 0xb2, 0x30, 0x0e, 0x4a, 0xa8, 0x07, 0x19, 0x8b, 0xfa, 0x2d, 0x3d, 0x54, 0x04, 0xa6,
-	0xf8, 0xf7, 0xd4, 0xe6, 0x82, 0x83, 0xf5, 0x62, 0x38, 0xbd, 0xa9, 0x86, 0x22, 0x5b, 0x92, 0x12,
-	0x91, 0xf2, 0xc7, 0x49, 0xca, 0x41, 0x52, 0xb4, 0xed, 0x0a, 0x64, 0x6d, 0x32, 0x37, 0xec, 0x08,
-	0x55, 0x16, 0xb9, 0x6a, 0xaa, 0x17, 0xc3, 0x19, 0xf6, 0x3a, 0x88, 0x5d, 0xa4, 0xa2, 0xc5, 0xfe,
-	0x7f, 0xe3, 0x20, 0x7f, 0xe8, 0x98, 0x48, 0x82, 0xb2, 0xd6, 0x99, 0xce, 0x46, 0x78, 0xa0, 0xab,
-	0xed, 0x36, 0xee, 0x4c, 0x26, 0x7a, 0x57, 0xbd, 0xea, 0x0f, 0x6f, 0xf4, 0x6b, 0x6d, 0xa0, 0x8d,
-	0x66, 0x9a, 0x10, 0x43, 0x7f, 0xa0, 0x78, 0x24, 0xd3, 0xd7, 0x3a, 0x53, 0x81, 0x43, 0x22, 0x94,
-	0x4e, 0x04, 0x14, 0x21, 0x7e, 0x22, 0x31, 0x1c, 0x5d, 0xaa, 0x43, 0x21, 0x71, 0xc7, 0xb3, 0x3b,
-	0xdf, 0x7c, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x63, 0xef, 0x38, 0x12, 0x03, 0x00, 0x00,
+	// 577 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xc1, 0x6e, 0xda, 0x4c,
+	0x10, 0xc7, 0x31, 0x10, 0xe7, 0xcb, 0x80, 0x89, 0xbf, 0x55, 0x54, 0xa1, 0x26, 0x21, 0x94, 0x2a,
+	0x2a, 0xea, 0x01, 0x22, 0x82, 0xac, 0xaa, 0x6a, 0x0f, 0x2e, 0x90, 0x42, 0xa1, 0x06, 0x2d, 0x34,
+	0x51, 0x4f, 0xd4, 0xb5, 0x9d, 0xb0, 0xc2, 0xc5, 0x2b, 0xdb, 0x71, 0x9a, 0x37, 0xe8, 0xb5, 0x6f,
+	0xda, 0x47, 0xa8, 0xbc, 0xd8, 0xc6, 0x14, 0xb0, 0xaa, 0xde, 0x76, 0xc6, 0xff, 0xf9, 0xed, 0xec,
+	0xfc, 0xc7, 0x70, 0xac, 0xa9, 0xd4, 0xb9, 0x37, 0x8d, 0x57, 0x75, 0x95, 0x92, 0xba, 0x77, 0x51,
+	0x77, 0x1f, 0xa9, 0xe1, 0xd4, 0xa8, 0x6d, 0xb9, 0x16, 0x3a, 0x0c, 0x3f, 0xd6, 0x54, 0x4a, 0x6a,
+	0xde, 0x45, 0xe5, 0x05, 0xe4, 0x7a, 0x23, 0xaf, 0x29, 0xeb, 0xba, 0x6d, 0x38, 0x0e, 0x2a, 0xc2,
+	0xbe, 0xba, 0x3c, 0x16, 0xb9, 0x32, 0x57, 0xdd, 0xc7, 0x61, 0x58, 0xf9, 0x02, 0x28, 0x26, 0x94,
+	0x17, 0xfa, 0xc8, 0xb2, 0x5d, 0x24, 0xad, 0xeb, 0x73, 0x8d, 0x93, 0xda, 0x1f, 0x37, 0xd4, 0x62,
+	0x55, 0x11, 0x0d, 0x21, 0xc8, 0x52, 0xcb, 0x76, 0x8b, 0xe9, 0x32, 0x57, 0x15, 0x30, 0x3b, 0x57,
+	0x2e, 0x59, 0x2b, 0x92, 0xbc, 0x92, 0xcc, 0xc8, 0xdd, 0x8c, 0x71, 0x79, 0xcc, 0xce, 0x48, 0x84,
+	0x8c, 0x69, 0x3d, 0xb0, 0x2a, 0x1e, 0xfb, 0xc7, 0xa0, 0x2d, 0xe9, 0x9f, 0xda, 0x92, 0xfe, 0xaa,
+	0xad, 0xd7, 0x50, 0x50, 0x0c, 0xd7, 0x24, 0x8b, 0x79, 0xd8, 0x99, 0x08, 0x19, 0x4a, 0x74, 0x46,
+	0x16, 0xb0, 0x7f, 0x44, 0x4f, 0x80, 0xbf, 0xb3, 0xad, 0x7b, 0xea, 0x04, 0x95, 0x41, 0x54, 0xf9,
+	0xc9, 0x81, 0x30, 0x52, 0xb5, 0xb9, 0xe1, 0x86, 0xb5, 0x4f, 0xe1, 0x3f, 0xe6, 0x84, 0x66, 0x99,
+	0x01, 0x20, 0x8a, 0xfd, 0xe1, 0x93, 0x5b, 0xb2, 0xd0, 0x8d, 0xef, 0x0c, 0xf3, 0x3f, 0x0e, 0x43,
+	0x9f, 0x3f, 0x53, 0x7d, 0x1f, 0x8b, 0x99, 0x25, 0x7f, 0x19, 0xf9, 0x15, 0x74, 0xee, 0xb2, 0x0f,
+	0x59, 0xf6, 0x21, 0x0c, 0xe3, 0x46, 0xee, 0x95, 0xb9, 0x6a, 0x7e, 0x65, 0x64, 0x13, 0xf2, 0xd7,
+	0x8e, 0xa5, 0xc5, 0x5f, 0xa3, 0xad, 0x5e, 0xa3, 0x11, 0x7d, 0xeb, 0x14, 0x7e, 0x65, 0xd8, 0x18,
+	0x1e, 0x2c, 0x3b, 0x2a, 0x7c, 0x0b, 0xfc, 0xad, 0xfa, 0x8d, 0x98, 0x8f, 0xac, 0xb6, 0xd0, 0x38,
+	0xdf, 0x98, 0xf1, 0x7a, 0xc1, 0x15, 0x13, 0xe3, 0xa0, 0x08, 0x75, 0x21, 0x4f, 0xa8, 0xd7, 0x9c,
+	0x86, 0x6d, 0x02, 0x33, 0xea, 0x79, 0xd2, 0xfe, 0x04, 0xf6, 0x76, 0x53, 0x38, 0x47, 0x68, 0x94,
+	0x0d, 0x48, 0x52, 0x44, 0x3a, 0xda, 0x4d, 0x92, 0xb6, 0x92, 0xc2, 0x2c, 0x3a, 0x07, 0xc1, 0xb4,
+	0x34, 0xd5, 0x8c, 0x50, 0xa5, 0x32, 0x57, 0x3d, 0xe8, 0xa6, 0x70, 0x9e, 0xa5, 0x43, 0xd9, 0x07,
+	0x38, 0x5c, 0x2c, 0x57, 0x22, 0x12, 0x56, 0xd9, 0x9d, 0x67, 0xdb, 0x46, 0x10, 0x5b, 0x9d, 0x6e,
+	0x0a, 0x17, 0x16, 0x6b, 0x19, 0xf4, 0x1e, 0x0a, 0x94, 0x6d, 0x48, 0x84, 0x6a, 0x30, 0x54, 0x69,
+	0x03, 0xb5, 0xb6, 0x48, 0xdd, 0x14, 0x16, 0x68, 0x3c, 0x81, 0xda, 0x20, 0x78, 0xbe, 0xaf, 0x11,
+	0xe7, 0x0d, 0xe3, 0x9c, 0x6e, 0x70, 0xe2, 0xee, 0xfb, 0x4f, 0xf3, 0x62, 0xf1, 0xbb, 0x83, 0x68,
+	0x6f, 0x5e, 0xfe, 0x48, 0xc3, 0xd1, 0x36, 0x07, 0x51, 0x05, 0x4a, 0x4a, 0x67, 0x72, 0x33, 0xc4,
+	0xfd, 0xa9, 0xdc, 0x6e, 0xe3, 0xce, 0x78, 0x3c, 0xbd, 0x92, 0x3f, 0xf6, 0x06, 0x9f, 0xa7, 0x9f,
+	0x94, 0xbe, 0x32, 0xbc, 0x51, 0xc4, 0x14, 0x3a, 0x83, 0xe3, 0x1d, 0x9a, 0x9e, 0xd2, 0x99, 0x88,
+	0x1c, 0x2a, 0xc3, 0x49, 0x82, 0x40, 0x12, 0xd3, 0x09, 0x8a, 0xc1, 0xb0, 0x25, 0x0f, 0xc4, 0x4c,
+	0x42, 0x23, 0x4a, 0x67, 0x32, 0xe8, 0x29, 0x7d, 0x31, 0x8b, 0x9e, 0xc1, 0xe9, 0x0e, 0xcd, 0x48,
+	0x6e, 0xf5, 0x3b, 0x13, 0x71, 0x2f, 0xe1, 0xa2, 0xeb, 0xf1, 0xb0, 0xd5, 0x17, 0xf9, 0xaf, 0x3c,
+	0xfb, 0x47, 0x2f, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x69, 0xf6, 0x91, 0xb1, 0x5c, 0x05, 0x00,
+	0x00,
 }
@@ -67,6 +67,15 @@ type firehoseEvent struct {
 	TelemetryEvent interface{}
 }
 
+//
+// A batch of records to send to Firehose along with the acks for the
+// telemetry events that they were converted from
+//
+type firehoseBatch struct {
+	records []*firehose.Record
+	acks    [][]byte
+}
+
 // Custom gRPC Dialer that understands "unix:/path/to/sock" as well as TCP addrs
 func dialer(addr string, timeout time.Duration) (net.Conn, error) {
 	var network, address string
@@ -182,6 +191,9 @@ func main() {
 
 	useMock := flag.Bool("mock", false, "Use mock Firehose API")
 
+	deliveryID := flag.String("d", "kinesis-firehose-forwarder",
+		"Delivery ID used to acknowledge events once they have been sent to Firehose (empty to disable)")
+
 	flag.Set("logtostderr", "true")
 	flag.Parse()
 
@@ -240,6 +252,7 @@ func main() {
 
 	stream, err := c.GetEvents(ctx, &api.GetEventsRequest{
 		Subscription: &sub,
+		DeliveryId:   *deliveryID,
 	})
 
 	if err != nil {
@@ -256,8 +269,8 @@ func main() {
 		cancel()
 	}()
 
-	recordsChan := make(chan []*firehose.Record, channelBufferLength)
-	kinesisBatchChan := make(chan []*firehose.Record, batchBufferLength)
+	recordsChan := make(chan firehoseBatch, channelBufferLength)
+	kinesisBatchChan := make(chan firehoseBatch, batchBufferLength)
 
 	//
 	// Create a goroutine to pull from queue of batches of events to send to Kinesis Firehose
@@ -265,11 +278,11 @@ func main() {
 	go func() {
 		for {
 			select {
-			case records, ok := <-kinesisBatchChan:
+			case batch, ok := <-kinesisBatchChan:
 				if ok {
 					output, err := firehoseService.PutRecordBatch(&firehose.PutRecordBatchInput{
 						DeliveryStreamName: aws.String(deliveryStreamName),
-						Records:            records,
+						Records:            batch.records,
 					})
 
 					if err != nil {
@@ -277,6 +290,8 @@ func main() {
 					}
 
 					glog.V(1).Infof("PutRecordBatch: %s", output)
+
+					acknowledge(c, batch, output)
 				} else {
 					// kinesisBatchChan has been closed, so we should exit the goroutine
 					glog.Info("Channel closed, exiting Kinesis Firehose sender goroutine")
@@ -305,32 +320,40 @@ func main() {
 				return
 			}
 
-			kinesisRecords, err := convertTelemetryToFirehoseRecords(response.Events)
+			kinesisRecords, acks, err := convertTelemetryToFirehoseRecords(response.Events)
 			if err != nil {
 				glog.Fatal(err)
 			}
 
-			recordsChan <- kinesisRecords
+			recordsChan <- firehoseBatch{
+				records: kinesisRecords,
+				acks:    acks,
+			}
 		}
 	}()
 
 	//
 	// Batching for Kinesis: PutRecord accepts up to 500 records
 	//
-	var kinesisBatch []*firehose.Record
+	var kinesisBatch firehoseBatch
 
 	timerDuration := time.Duration(batchTimerMillis * time.Millisecond)
 	timer := time.NewTimer(timerDuration)
 
 	for {
 		select {
-		case records, ok := <-recordsChan:
+		case batch, ok := <-recordsChan:
 			if ok {
-				kinesisBatch = append(kinesisBatch, records...)
+				kinesisBatch.records = append(kinesisBatch.records, batch.records...)
+				kinesisBatch.acks = append(kinesisBatch.acks, batch.acks...)
 
-				if len(kinesisBatch) >= maxBatchSize {
-					kinesisBatchChan <- kinesisBatch[:maxBatchSize]
-					kinesisBatch = kinesisBatch[maxBatchSize:]
+				if len(kinesisBatch.records) >= maxBatchSize {
+					kinesisBatchChan <- firehoseBatch{
+						records: kinesisBatch.records[:maxBatchSize],
+						acks:    kinesisBatch.acks[:maxBatchSize],
+					}
+					kinesisBatch.records = kinesisBatch.records[maxBatchSize:]
+					kinesisBatch.acks = kinesisBatch.acks[maxBatchSize:]
 
 					// Reset timer
 					timer.Reset(timerDuration)
@@ -347,9 +370,9 @@ func main() {
 		case <-timer.C:
 			// Timer fired, queue up the batch of the
 			// events that we have for sending
-			if len(kinesisBatch) > 0 {
+			if len(kinesisBatch.records) > 0 {
 				kinesisBatchChan <- kinesisBatch
-				kinesisBatch = []*firehose.Record{}
+				kinesisBatch = firehoseBatch{}
 			}
 
 			timer.Reset(timerDuration)
@@ -357,12 +380,46 @@ func main() {
 	}
 }
 
+//
+// Acknowledge the telemetry events for the records in a batch that were
+// successfully put to Firehose. Events that aren't acknowledged are
+// re-transmitted by the sensor.
+//
+func acknowledge(c api.TelemetryServiceClient, batch firehoseBatch, output *firehose.PutRecordBatchOutput) {
+	var acks [][]byte
+	for i, ack := range batch.acks {
+		if len(ack) == 0 {
+			continue
+		}
+		if i < len(output.RequestResponses) &&
+			output.RequestResponses[i].ErrorCode != nil {
+			continue
+		}
+		acks = append(acks, ack)
+	}
+
+	if len(acks) == 0 {
+		return
+	}
+
+	_, err := c.Acknowledge(context.Background(), &api.AcknowledgeRequest{
+		Acks: acks,
+	})
+	if err != nil {
+		glog.Warningf("Couldn't acknowledge %d events: %v", len(acks), err)
+	}
+}
+
 //
 // Convert received telemetry event from proto to JSON and enrich with a
-// few fields.
+// few fields. The acks for the events that were converted are returned in
+// the same order as the records.
 //
-func convertTelemetryToFirehoseRecords(telemetryEvents []*api.ReceivedTelemetryEvent) ([]*firehose.Record, error) {
-	var records []*firehose.Record
+func convertTelemetryToFirehoseRecords(telemetryEvents []*api.ReceivedTelemetryEvent) ([]*firehose.Record, [][]byte, error) {
+	var (
+		records []*firehose.Record
+		acks    [][]byte
+	)
 
 	jsonMarshaller := jsonpb.Marshaler{
 		EmitDefaults: true,
@@ -372,7 +429,7 @@ func convertTelemetryToFirehoseRecords(telemetryEvents []*api.ReceivedTelemetryE
 		jsonString, err := jsonMarshaller.MarshalToString(telemetryEvent)
 		if err != nil {
 			glog.Warning(err)
-			return records, nil
+			return records, acks, nil
 		}
 
 		// Unmarshal to a map to allow us to modify it
@@ -388,13 +445,14 @@ func convertTelemetryToFirehoseRecords(telemetryEvents []*api.ReceivedTelemetryE
 		jsonBytes, err := json.Marshal(d)
 		if err != nil {
 			glog.Warning(err)
-			return records, nil
+			return records, acks, nil
 		}
 
 		glog.V(2).Infof("%s", string(jsonBytes))
 		r := &firehose.Record{Data: jsonBytes}
 		records = append(records, r)
+		acks = append(acks, telemetryEvent.Ack)
 	}
 
-	return records, nil
+	return records, acks, nil
 }
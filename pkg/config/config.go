@@ -15,6 +15,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
 )
@@ -45,6 +47,19 @@ var Sensor struct {
 	//   :8484
 	ListenAddr string `split_words:"true" default:"unix:/var/run/capsule8/sensor.sock"`
 
+	// Sensor REST/JSON gateway listen address may be specified in any of
+	// the same forms as ListenAddr. If it is empty, the gateway is not
+	// started. If UseTLS is true, the gateway serves HTTPS using the same
+	// TLS settings as the gRPC API Server, and it authenticates to the
+	// gRPC API Server using GatewayTLSClientCertPath and
+	// GatewayTLSClientKeyPath.
+	GatewayListenAddr string `split_words:"true"`
+
+	// Sensor metrics listen address may be specified in any of the same
+	// forms as ListenAddr. If it is set, the sensor's metrics are served
+	// at /metrics in the Prometheus text exposition format.
+	MetricsListenAddr string `split_words:"true"`
+
 	// UseTLS is the boolean switch to enable TLS use. By default it
 	// is false. If UseTLS is true, TLSCACertPath, TLSServerCertPath
 	// and TLSServerKeyPath will need to be set.
@@ -65,6 +80,24 @@ var Sensor struct {
 	// if UseTLS is true.
 	TLSServerKeyPath string `split_words:"true" default:"/var/lib/capsule8/tls/server.key"`
 
+	// GatewayTLSClientCertPath is the path to the file that holds the
+	// client certificate that the REST/JSON gateway presents to the
+	// telemetry server. It must be signed by the certificate authority
+	// in TLSCACertPath. This will only be used if UseTLS is true and
+	// GatewayListenAddr is set.
+	GatewayTLSClientCertPath string `split_words:"true" default:"/var/lib/capsule8/tls/gateway.crt"`
+
+	// GatewayTLSClientKeyPath is the path to the file that holds the
+	// client key for the REST/JSON gateway. This will only be used if
+	// UseTLS is true and GatewayListenAddr is set.
+	GatewayTLSClientKeyPath string `split_words:"true" default:"/var/lib/capsule8/tls/gateway.key"`
+
+	// GatewayTLSServerName is the name that the REST/JSON gateway expects
+	// the telemetry server's certificate to be issued for. If it is empty,
+	// the host of ListenAddr is used. It must be set if UseTLS is true,
+	// GatewayListenAddr is set, and ListenAddr is a unix socket.
+	GatewayTLSServerName string `split_words:"true"`
+
 	// Names of cgroups to monitor for events. Each cgroup specified must
 	// exist within the perf_event cgroup hierarchy. For example, if this
 	// is set to "docker", the Sensor will monitor containers for events
@@ -92,6 +125,61 @@ var Sensor struct {
 	// The size of the process info cache. If the system pid_max is greater
 	// than this size, a less performant method of caching will be used.
 	ProcessInfoCacheSize uint `split_words:"true" default:"131072"`
+
+	// The maximum number of processes included in the process lineage of
+	// telemetry events for subscriptions that request it.
+	ProcessLineageMaxDepth uint32 `split_words:"true" default:"32"`
+
+	// The maximum number of elements of argv captured from execve() for
+	// the command-lines of exec events. Arguments are fetched in chunks
+	// of 8 by a chain of kernel probes, so larger values add probes. If a
+	// command-line may have been cut short, it is read from procfs.
+	ExecArgCount int `split_words:"true" default:"32"`
+
+	// Capture the environment of processes in exec events. The
+	// environment is fetched from envp passed to execve() by a chain of
+	// kernel probes, like argv.
+	ExecEnvironment bool `split_words:"true"`
+
+	// The maximum number of elements of envp captured from execve() when
+	// ExecEnvironment is set. Variables are fetched in chunks of 8, so
+	// larger values add probes. Variables past this limit are not
+	// captured.
+	ExecEnvironmentCount int `split_words:"true" default:"64"`
+
+	// If set, only environment variables with names matching one of
+	// these glob patterns are captured in exec events.
+	ExecEnvironmentAllow []string `split_words:"true"`
+
+	// The values of environment variables with names matching one of
+	// these glob patterns, ignoring case, are redacted in exec events.
+	ExecEnvironmentRedact []string `split_words:"true" default:"*PASSWORD*,*PASSWD*,*SECRET*,*TOKEN*,*KEY*,*CREDENTIAL*"`
+
+	// The maximum number of recent telemetry events retained by the
+	// sensor for subscriptions that request historic events using
+	// since_duration. Only events collected for subscriptions that were
+	// active at the time are retained. Event history is disabled by
+	// default, in which case since_duration only limits live events.
+	EventHistoryLength int `split_words:"true"`
+
+	// The maximum age of telemetry events retained in the event history.
+	EventHistoryDuration time.Duration `split_words:"true" default:"5m"`
+
+	// The maximum number of unacknowledged events retained for each
+	// subscription that requests reliable delivery.
+	DeliverySpoolLength int `split_words:"true" default:"16384"`
+
+	// How long to wait for an event sent with reliable delivery to be
+	// acknowledged before sending it again.
+	DeliveryAckTimeout time.Duration `split_words:"true" default:"30s"`
+
+	// How long to retain the unacknowledged events for a reliable
+	// delivery subscription after its client disconnects.
+	DeliverySpoolIdleTimeout time.Duration `split_words:"true" default:"5m"`
+
+	// The maximum number of events sent together in a single response to
+	// subscriptions that request batching.
+	MaxEventBatchSize int `split_words:"true" default:"1024"`
 }
 
 func init() {
@@ -19,6 +19,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/golang/glog"
 )
@@ -66,6 +67,12 @@ func ValidateTLSConfig() error {
 			return fmt.Errorf("error parsing certificate authority: %s", err)
 		}
 
+		if len(Sensor.GatewayListenAddr) > 0 &&
+			len(Sensor.GatewayTLSServerName) == 0 &&
+			strings.HasPrefix(Sensor.ListenAddr, "unix:") {
+			return fmt.Errorf("gateway TLS server name must be set when listening on a unix socket")
+		}
+
 		return nil
 	}
 	glog.V(1).Infoln("UseTLS set to false, TLS credentials will not be used")
@@ -87,6 +87,55 @@ func TestValidateTLS(t *testing.T) {
 	}
 }
 
+func TestValidateGatewayTLS(t *testing.T) {
+	crtPath, keyPath, caPath, falsePath := createTLSFiles(t)
+
+	// Remove all newly created files after testing
+	defer os.Remove(crtPath)
+	defer os.Remove(keyPath)
+	defer os.Remove(caPath)
+	defer os.Remove(falsePath)
+
+	Sensor.UseTLS = true
+	Sensor.TLSServerCertPath = crtPath
+	Sensor.TLSServerKeyPath = keyPath
+	Sensor.TLSCACertPath = caPath
+	Sensor.GatewayListenAddr = ":8485"
+	defer func() {
+		Sensor.UseTLS = false
+		Sensor.GatewayListenAddr = ""
+	}()
+
+	var testGatewayObjects = []struct {
+		listenAddr string
+		serverName string
+		valid      bool
+	}{
+		{
+			listenAddr: "127.0.0.1:8484",
+			valid:      true,
+		},
+		{
+			listenAddr: "unix:/var/run/capsule8/sensor.sock",
+			valid:      false,
+		},
+		{
+			listenAddr: "unix:/var/run/capsule8/sensor.sock",
+			serverName: "sensor",
+			valid:      true,
+		},
+	}
+
+	for _, testObj := range testGatewayObjects {
+		Sensor.ListenAddr = testObj.listenAddr
+		Sensor.GatewayTLSServerName = testObj.serverName
+
+		if err := ValidateTLSConfig(); (err == nil) != testObj.valid {
+			t.Errorf("Gateway TLS credentials incorrectly validated: %+v %+v", testObj, err)
+		}
+	}
+}
+
 func createTLSFiles(t *testing.T) (crtPath, keyPath, caPath, falsePath string) {
 	workingDirectory, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	if err != nil {
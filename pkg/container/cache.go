@@ -25,6 +25,7 @@ import "sync"
 
 var (
 	cache     map[string]*Info
+	addresses map[string]string // IP address : container ID
 	cacheLock sync.Mutex
 	cacheOnce sync.Once
 )
@@ -35,26 +36,53 @@ type Info struct {
 	Name      string
 	ImageID   string
 	ImageName string
+
+	// The IP addresses of the container on its networks, if it is
+	// running
+	Addresses []string
 }
 
-func cacheUpdate(cID string, cName string, iID string, iName string) {
+func cacheUpdate(cID string, cName string, iID string, iName string, addrs []string) {
 	// Initialize container cache if this is the first event
 	cacheOnce.Do(func() {
 		cache = make(map[string]*Info)
+		addresses = make(map[string]string)
 	})
 
 	cacheLock.Lock()
 	defer cacheLock.Unlock()
-	_, ok := cache[cID]
+	i, ok := cache[cID]
 	if !ok {
-		i := &Info{
+		i = &Info{
 			ID:        cID,
 			Name:      cName,
 			ImageID:   iID,
 			ImageName: iName,
 		}
+	} else {
+		// Info is shared with callers of GetInfo, so it is replaced
+		// rather than modified.
+		n := *i
+		i = &n
+		removeAddresses(i)
+	}
+
+	// Addresses are assigned when a container starts and released when it
+	// stops, so they are always taken from the latest configuration.
+	i.Addresses = addrs
+	for _, addr := range addrs {
+		addresses[addr] = cID
+	}
+
+	cache[cID] = i
+}
 
-		cache[cID] = i
+// removeAddresses must be called with the cache locked.
+func removeAddresses(i *Info) {
+	for _, addr := range i.Addresses {
+		if addresses[addr] == i.ID {
+			delete(addresses, addr)
+		}
 	}
 }
 
@@ -62,9 +90,13 @@ func cacheDelete(containerID string) {
 	// Initialize container cache if this is the first event
 	cacheOnce.Do(func() {
 		cache = make(map[string]*Info)
+		addresses = make(map[string]string)
 	})
 
 	cacheLock.Lock()
+	if i, ok := cache[containerID]; ok {
+		removeAddresses(i)
+	}
 	delete(cache, containerID)
 	cacheLock.Unlock()
 }
@@ -77,3 +109,24 @@ func GetInfo(containerID string) *Info {
 
 	return cache[containerID]
 }
+
+// GetInfoByAddress returns cached container information for the
+// container with the given IP address or nil if none was found.
+func GetInfoByAddress(addr string) *Info {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	cID, ok := addresses[addr]
+	if !ok {
+		return nil
+	}
+	return cache[cID]
+}
+
+// CacheSize returns the number of containers in the container cache.
+func CacheSize() int {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	return len(cache)
+}
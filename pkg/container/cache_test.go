@@ -0,0 +1,72 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const configV2Networks = `{
+	"ID": "c0ffee",
+	"Name": "/web",
+	"NetworkSettings": {
+		"Networks": {
+			"bridge": {
+				"IPAddress": "172.17.0.2",
+				"GlobalIPv6Address": "2001:0db8:0000:0000:0000:0242:ac11:0002"
+			}
+		}
+	}
+}`
+
+func TestCacheAddresses(t *testing.T) {
+	config := DockerConfigV2{}
+	if err := json.Unmarshal([]byte(configV2Networks), &config); err != nil {
+		t.Fatal(err)
+	}
+	addrs := config.NetworkSettings.Addresses()
+	if len(addrs) != 2 {
+		t.Fatalf("Expected 2 addresses, got %v", addrs)
+	}
+
+	// Containers have no addresses until they start
+	cacheUpdate(config.ID, config.Name, "", "nginx", nil)
+	if i := GetInfoByAddress("172.17.0.2"); i != nil {
+		t.Errorf("Unexpected container %+v", i)
+	}
+
+	cacheUpdate(config.ID, config.Name, "", "nginx", addrs)
+	for _, addr := range []string{"172.17.0.2", "2001:db8::242:ac11:2"} {
+		i := GetInfoByAddress(addr)
+		if i == nil || i.ID != "c0ffee" || i.Name != "/web" ||
+			i.ImageName != "nginx" {
+			t.Errorf("Unexpected container for %s: %+v", addr, i)
+		}
+	}
+
+	// A stopped container's address may be reused by another container
+	cacheUpdate(config.ID, config.Name, "", "nginx", nil)
+	cacheUpdate("decaf", "/db", "", "postgres", []string{"172.17.0.2"})
+	if i := GetInfoByAddress("172.17.0.2"); i == nil || i.ID != "decaf" {
+		t.Errorf("Unexpected container %+v", i)
+	}
+
+	cacheDelete("decaf")
+	cacheDelete("c0ffee")
+	if i := GetInfoByAddress("172.17.0.2"); i != nil {
+		t.Errorf("Unexpected container after delete %+v", i)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -104,6 +105,34 @@ type DockerConfigConfig struct {
 	// XXX: ...
 }
 
+// DockerConfigEndpoint is a structure representing a Docker container's
+// endpoint on a network.
+type DockerConfigEndpoint struct {
+	IPAddress         string `json:"IPAddress"`
+	GlobalIPv6Address string `json:"GlobalIPv6Address"`
+}
+
+// DockerConfigNetworkSettings is a structure representing the network
+// settings of a Docker container.
+type DockerConfigNetworkSettings struct {
+	// XXX: ...
+	Networks map[string]DockerConfigEndpoint `json:"Networks"`
+}
+
+// Addresses returns the IP addresses of a Docker container on all of its
+// networks in the canonical form returned by net.IP.String.
+func (ns *DockerConfigNetworkSettings) Addresses() []string {
+	var addrs []string
+	for _, ep := range ns.Networks {
+		for _, s := range []string{ep.IPAddress, ep.GlobalIPv6Address} {
+			if ip := net.ParseIP(s); ip != nil {
+				addrs = append(addrs, ip.String())
+			}
+		}
+	}
+	return addrs
+}
+
 // DockerConfigV2 is a structure representing a Docker container.
 type DockerConfigV2 struct {
 	// XXX: Fill in as needed...
@@ -113,7 +142,8 @@ type DockerConfigV2 struct {
 	Path   string             `json:"Path"`
 	Config DockerConfigConfig `json:"Config"`
 	// ...
-	Name string `json:"Name"`
+	Name            string                      `json:"Name"`
+	NetworkSettings DockerConfigNetworkSettings `json:"NetworkSettings"`
 }
 
 // ----------------------------------------------------------------------------
@@ -135,7 +165,8 @@ func newDockerEventFromConfigData(configV2Json []byte) (*dockerEvent, error) {
 	//
 	// Update container and process info caches
 	//
-	cacheUpdate(config.ID, name, imageID, imageName)
+	cacheUpdate(config.ID, name, imageID, imageName,
+		config.NetworkSettings.Addresses())
 
 	var state dockerContainerState
 
@@ -345,7 +376,7 @@ func updateCaches(containerPath string) {
 	}
 
 	cacheUpdate(configV2.ID, configV2.Name, configV2.Image,
-		configV2.Config.Image)
+		configV2.Config.Image, configV2.NetworkSettings.Addresses())
 }
 
 func initializeDockerSensor() error {
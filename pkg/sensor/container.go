@@ -23,6 +23,7 @@ import (
 
 	"github.com/gobwas/glob"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 
 	"golang.org/x/sys/unix"
 )
@@ -184,6 +185,13 @@ func (cer *containerEventRepeater) translateContainerEvents(e interface{}) inter
 			Container: ece,
 		}
 
+		// Subscriptions may modify container events, so record a
+		// copy in the event history.
+		if cer.sensor.eventHistory.enabled() {
+			cer.sensor.eventHistory.add(
+				proto.Clone(ev).(*api.TelemetryEvent))
+		}
+
 		return ev
 	}
 
@@ -230,10 +238,12 @@ type containerEventFilter struct {
 	expr *expression.Expression
 }
 
-func (cer *containerEventRepeater) newEventStream(sub *api.Subscription) (*stream.Stream, error) {
-	filters := make(map[api.ContainerEventType]*containerEventFilter)
+type containerEventFilterSet map[api.ContainerEventType]*containerEventFilter
+
+func newContainerEventFilterSet(events []*api.ContainerEventFilter) containerEventFilterSet {
+	filters := make(containerEventFilterSet)
 	exprs := make(map[api.ContainerEventType]*api.Expression)
-	for _, cef := range sub.EventFilter.ContainerEvents {
+	for _, cef := range events {
 		exprs[cef.Type] = expression.LogicalOr(
 			exprs[cef.Type],
 			cef.FilterExpression)
@@ -269,6 +279,42 @@ func (cer *containerEventRepeater) newEventStream(sub *api.Subscription) (*strea
 	for _, t := range badTypes {
 		delete(filters, t)
 	}
+
+	return filters
+}
+
+func (filters containerEventFilterSet) filter(e *api.TelemetryEvent) bool {
+	switch e.Event.(type) {
+	case *api.TelemetryEvent_Container:
+		cev := e.GetContainer()
+		cef, ok := filters[cev.Type]
+		if !ok {
+			return false
+		}
+
+		if cef.expr != nil {
+			containerEventValues := convertEvent(cev)
+			v, err := cef.expr.Evaluate(
+				containerEventTypes,
+				containerEventValues)
+			if err != nil || !expression.IsValueTrue(v) {
+				return false
+			}
+		}
+
+		if cef.view != api.ContainerEventView_FULL {
+			cev.OciConfigJson = ""
+			cev.DockerConfigJson = ""
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (cer *containerEventRepeater) newEventStream(sub *api.Subscription) (*stream.Stream, error) {
+	filters := newContainerEventFilterSet(sub.EventFilter.ContainerEvents)
 	if len(filters) == 0 {
 		return nil, nil
 	}
@@ -279,35 +325,7 @@ func (cer *containerEventRepeater) newEventStream(sub *api.Subscription) (*strea
 	s := cer.repeater.NewStream()
 
 	s = stream.Filter(s, func(i interface{}) bool {
-		e := i.(*api.TelemetryEvent)
-
-		switch e.Event.(type) {
-		case *api.TelemetryEvent_Container:
-			cev := e.GetContainer()
-			cef, ok := filters[cev.Type]
-			if !ok {
-				return false
-			}
-
-			if cef.expr != nil {
-				containerEventValues := convertEvent(cev)
-				v, err := cef.expr.Evaluate(
-					containerEventTypes,
-					containerEventValues)
-				if err != nil || !expression.IsValueTrue(v) {
-					return false
-				}
-			}
-
-			if cef.view != api.ContainerEventView_FULL {
-				cev.OciConfigJson = ""
-				cev.DockerConfigJson = ""
-			}
-
-			return true
-		}
-
-		return false
+		return filters.filter(i.(*api.TelemetryEvent))
 	})
 
 	return s, nil
@@ -396,6 +414,11 @@ func (c *containerFilter) addImageName(iname string) {
 func (c *containerFilter) FilterFunc(i interface{}) bool {
 	e := i.(*api.TelemetryEvent)
 
+	// Lost events are not specific to any container
+	if e.GetLost() != nil {
+		return true
+	}
+
 	//
 	// Fast path: Check if containerId is in containerIds map
 	//
@@ -0,0 +1,91 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// The number of recent samples on each CPU that later samples are compared
+// against. Copies are usually dispatched one after another, but deferred
+// events may be dispatched after other events.
+const eventCopiesPerCPU = 16
+
+// eventCopies detects the copies of a kernel event that are reported when
+// more than one registered event monitors it.
+type eventCopies struct {
+	sync.Mutex
+
+	// The most recent samples seen on each CPU, newest last
+	last map[int32][]*eventSample
+}
+
+// eventSample identifies the copies of a kernel event. The kernel reports a
+// copy for each registered event one after another on the same CPU with the
+// same sample time, so a sample is a copy of a recent one seen on its CPU if
+// it has the same sample time and comes from a registered event that hasn't
+// reported a copy yet. Another sample from the same registered event is a
+// new occurrence, even if it has the same sample time.
+type eventSample struct {
+	time     int64
+	eventIDs []uint64
+}
+
+func newEventCopies() *eventCopies {
+	return &eventCopies{
+		last: make(map[int32][]*eventSample),
+	}
+}
+
+// isCopy returns true if an event decoded from a sample of the registered
+// event with the given id is a copy of a kernel event that has already been
+// seen for another registered event. Samples are identified by their CPU
+// and sample time rather than by the events decoded from them. Events that
+// are not copies become the newest samples seen on their CPUs.
+func (c *eventCopies) isCopy(eventID uint64, e *api.TelemetryEvent) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	last := c.last[e.Cpu]
+	for i := len(last) - 1; i >= 0; i-- {
+		if last[i].time != e.SensorMonotimeNanos {
+			continue
+		}
+		seen := false
+		for _, id := range last[i].eventIDs {
+			if id == eventID {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			last[i].eventIDs = append(last[i].eventIDs, eventID)
+			return true
+		}
+		break
+	}
+
+	if len(last) >= eventCopiesPerCPU {
+		copy(last, last[1:])
+		last = last[:len(last)-1]
+	}
+	c.last[e.Cpu] = append(last, &eventSample{
+		time:     e.SensorMonotimeNanos,
+		eventIDs: []uint64{eventID},
+	})
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventCopies(t *testing.T) {
+	c := newEventCopies()
+
+	// The same kernel event reported for registered events 1 and 2
+	if c.isCopy(1, newHistoryTestEvent(1000000, 1, "/a")) {
+		t.Error("Unexpected copy for first sample")
+	}
+	if !c.isCopy(2, newHistoryTestEvent(1000000, 1, "/a")) {
+		t.Error("Expected copy from second registered event")
+	}
+
+	// Identical events in a tight loop are not copies, even when the
+	// sample times are the same
+	if c.isCopy(1, newHistoryTestEvent(1000000, 1, "/a")) {
+		t.Error("Unexpected copy for repeated sample")
+	}
+	if c.isCopy(2, newHistoryTestEvent(1000010, 1, "/a")) {
+		t.Error("Unexpected copy for later sample")
+	}
+
+	// Copies are identified by sample, not payload
+	if !c.isCopy(3, newHistoryTestEvent(1000010, 1, "/b")) {
+		t.Error("Expected copy for the same sample")
+	}
+
+	// Copies are only reported on the same CPU
+	e := newHistoryTestEvent(1000010, 1, "/a")
+	e.Cpu = 1
+	if c.isCopy(4, e) {
+		t.Error("Unexpected copy for sample on another CPU")
+	}
+
+	// Deferred copies may be seen after other samples
+	if c.isCopy(5, newHistoryTestEvent(1000020, 1, "/a")) {
+		t.Error("Unexpected copy for later sample")
+	}
+	if !c.isCopy(4, newHistoryTestEvent(1000010, 1, "/a")) {
+		t.Error("Expected copy for deferred sample")
+	}
+	for i := int64(0); i < eventCopiesPerCPU; i++ {
+		c.isCopy(1, newHistoryTestEvent(1000100+i, 1, "/a"))
+	}
+	if c.isCopy(6, newHistoryTestEvent(1000010, 1, "/a")) {
+		t.Error("Unexpected copy for forgotten sample")
+	}
+}
+
+func TestDispatchCopies(t *testing.T) {
+	s := &Sensor{
+		eventMap:     newSafeSubscriptionMap(),
+		eventHistory: newEventHistory(0, time.Minute),
+	}
+
+	// Two registered events sharing copies, as for the event filters of a
+	// modifiable subscription, and one that doesn't
+	copies := newEventCopies()
+	data := make(chan interface{}, 4)
+	other := make(chan interface{}, 4)
+	s.eventMap.update(subscriptionMap{
+		1: &subscription{data: data, copies: copies},
+		2: &subscription{data: data, copies: copies},
+		3: &subscription{data: other},
+	})
+
+	for _, eventID := range []uint64{1, 2, 3} {
+		s.dispatchSample(eventID,
+			newHistoryTestEvent(1000000, 1, "/a"), nil)
+	}
+	s.dispatchSample(1, newHistoryTestEvent(1000000, 1, "/a"), nil)
+
+	if len(data) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(data))
+	}
+	if len(other) != 1 {
+		t.Errorf("Expected 1 event, got %d", len(other))
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+// Fetch the IDs in the struct cred passed to commit_creds.
+const credentialsIDFetchargs = "uid=+8(%di):u32 gid=+12(%di):u32 " +
+	"euid=+24(%di):u32 egid=+28(%di):u32 fsuid=+32(%di):u32"
+
+// credentialsFetchargs returns the fetchargs for the fields of the struct
+// cred passed to commit_creds on a kernel of the given version. The
+// capability sets follow securebits, and kernel_cap_t changed from u32[2] to
+// u64 in 6.3, which moved them to the next 8-byte boundary. The capability
+// sets are not fetched when the kernel version is unknown. The ambient
+// capability set is never fetched, because its presence depends on the
+// kernel version.
+func credentialsFetchargs(v kernelVersion) string {
+	switch {
+	case v.isZero():
+		return credentialsIDFetchargs
+	case v.before(kernelVersion{6, 3}):
+		return credentialsIDFetchargs +
+			" cap_inheritable=+44(%di):u64 cap_permitted=+52(%di):u64" +
+			" cap_effective=+60(%di):u64 cap_bounding=+68(%di):u64"
+	default:
+		return credentialsIDFetchargs +
+			" cap_inheritable=+48(%di):u64 cap_permitted=+56(%di):u64" +
+			" cap_effective=+64(%di):u64 cap_bounding=+72(%di):u64"
+	}
+}
+
+var credentialsEventTypes = expression.FieldTypeMap{
+	"uid":   int32(api.ValueType_UINT32),
+	"gid":   int32(api.ValueType_UINT32),
+	"euid":  int32(api.ValueType_UINT32),
+	"egid":  int32(api.ValueType_UINT32),
+	"fsuid": int32(api.ValueType_UINT32),
+}
+
+func credentialsEventValues(cev *api.CredentialsEvent) expression.FieldValueMap {
+	c := cev.New
+	if c == nil {
+		c = &api.Credentials{}
+	}
+	return expression.FieldValueMap{
+		"uid":   c.Uid,
+		"gid":   c.Gid,
+		"euid":  c.Euid,
+		"egid":  c.Egid,
+		"fsuid": c.Fsuid,
+	}
+}
+
+// newCred returns the credentials fetched from a commit_creds sample. The
+// capability sets are zero if they were not fetched.
+func newCred(data perf.TraceEventSampleData) cred {
+	c := cred{
+		initialized: true,
+		uid:         data["uid"].(uint32),
+		gid:         data["gid"].(uint32),
+		euid:        data["euid"].(uint32),
+		egid:        data["egid"].(uint32),
+		fsuid:       data["fsuid"].(uint32),
+	}
+	c.capInheritable, _ = data["cap_inheritable"].(uint64)
+	c.capPermitted, _ = data["cap_permitted"].(uint64)
+	c.capEffective, _ = data["cap_effective"].(uint64)
+	c.capBounding, _ = data["cap_bounding"].(uint64)
+	return c
+}
+
+func (c *cred) credentials() *api.Credentials {
+	return &api.Credentials{
+		Uid:            c.uid,
+		Gid:            c.gid,
+		Euid:           c.euid,
+		Egid:           c.egid,
+		Fsuid:          c.fsuid,
+		CapInheritable: c.capInheritable,
+		CapPermitted:   c.capPermitted,
+		CapEffective:   c.capEffective,
+		CapBounding:    c.capBounding,
+	}
+}
+
+type credentialsFilter struct {
+	sensor *Sensor
+}
+
+func (f *credentialsFilter) decodeCommitCreds(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+
+	c := newCred(data)
+	cev := &api.CredentialsEvent{
+		New: c.credentials(),
+	}
+	if old, ok := f.sensor.processCache.previousCredentials(
+		int(ev.ProcessPid), c); ok {
+		cev.Old = old.credentials()
+	}
+
+	ev.Event = &api.TelemetryEvent_Credentials{
+		Credentials: cev,
+	}
+
+	return ev, nil
+}
+
+// credentialsEventFilterString returns the kernel filter string for a
+// credentials event filter, or an error if the filter is invalid. An empty
+// string is returned for filters that match all events.
+func credentialsEventFilterString(cef *api.CredentialsEventFilter) (string, error) {
+	if cef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(cef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(credentialsEventTypes)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// credentialsEventProbes returns the names of the kprobes that credentials
+// events are collected from.
+func credentialsEventProbes() []string {
+	return []string{commitCredsAddress}
+}
+
+func registerCredentialsEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.CredentialsEventFilter) {
+	filters := make(map[string]int)
+	for _, cef := range events {
+		s, err := credentialsEventFilterString(cef)
+		if err != nil {
+			glog.V(1).Infof("Invalid credentials event filter: %s", err)
+			continue
+		}
+		filters[s]++
+	}
+
+	filterString, active := fullFilterString(filters)
+	if !active {
+		return
+	}
+
+	f := credentialsFilter{
+		sensor: sensor,
+	}
+
+	fetchargs := credentialsFetchargs(runningKernelVersion())
+	eventID, err := sensor.registerKprobe(commitCredsAddress, false,
+		fetchargs, f.decodeCommitCreds, perf.WithFilter(filterString))
+	if err != nil {
+		glog.Warningf("Couldn't register kprobe %s: %s",
+			commitCredsAddress, err)
+		sensor.probeFailed(commitCredsAddress, err)
+		return
+	}
+
+	eventMap[eventID] = &subscription{}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func credentialsSampleData(pid int32, uid uint32) perf.TraceEventSampleData {
+	return perf.TraceEventSampleData{
+		"common_pid":      pid,
+		"usage":           uint64(1),
+		"uid":             uid,
+		"gid":             uid,
+		"euid":            uid,
+		"egid":            uid,
+		"fsuid":           uid,
+		"cap_inheritable": uint64(0),
+		"cap_permitted":   uint64(0),
+		"cap_effective":   uint64(0),
+		"cap_bounding":    uint64(0x3fffffffff),
+	}
+}
+
+func TestCredentialsEvent(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+	}
+	pc := &s.processCache
+	f := credentialsFilter{
+		sensor: s,
+	}
+
+	pc.cache.InsertTask(100, task{pid: 100, tgid: 100})
+	pc.decodeCommitCreds(nil, credentialsSampleData(100, 1000))
+
+	// Children inherit their parent's credentials
+	pc.decodeNewTask(nil, perf.TraceEventSampleData{
+		"common_pid":  int32(100),
+		"pid":         int32(101),
+		"clone_flags": uint64(0),
+		"comm":        []interface{}{int8('s'), int8('h'), int8(0)},
+	})
+
+	check := func(pid int32, uid uint32, oldUID uint32) {
+		data := credentialsSampleData(pid, uid)
+		i, err := f.decodeCommitCreds(&perf.SampleRecord{}, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cev := i.(*api.TelemetryEvent).GetCredentials()
+		if cev == nil || cev.New.Uid != uid ||
+			cev.New.CapBounding != 0x3fffffffff {
+			t.Fatalf("Unexpected credentials event %+v", cev)
+		}
+		if cev.Old == nil || cev.Old.Uid != oldUID {
+			t.Errorf("Expected old uid %d for pid %d, got %+v",
+				oldUID, pid, cev.Old)
+		}
+	}
+
+	// The sensor's event is handled before the cache is updated
+	check(101, 0, 1000)
+	pc.decodeCommitCreds(nil, credentialsSampleData(101, 0))
+
+	// The cache is updated before the sensor's event is handled
+	pc.decodeCommitCreds(nil, credentialsSampleData(100, 0))
+	check(100, 0, 1000)
+
+	// Credentials of unknown processes are unknown
+	i, _ := f.decodeCommitCreds(&perf.SampleRecord{},
+		credentialsSampleData(200, 0))
+	if cev := i.(*api.TelemetryEvent).GetCredentials(); cev.Old != nil {
+		t.Errorf("Expected no old credentials, got %+v", cev.Old)
+	}
+}
+
+func TestCredentialsEventFilterString(t *testing.T) {
+	s, err := credentialsEventFilterString(&api.CredentialsEventFilter{
+		FilterExpression: expression.Equal(
+			expression.Identifier("euid"),
+			expression.Value(uint32(0))),
+	})
+	if err != nil || s != "euid == 0" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+
+	_, err = credentialsEventFilterString(&api.CredentialsEventFilter{
+		FilterExpression: expression.Equal(
+			expression.Identifier("filename"),
+			expression.Value("foo")),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestCredentialsFetchargs(t *testing.T) {
+	// kernel_cap_t changed from u32[2] to u64 in 6.3
+	if f := credentialsFetchargs(kernelVersion{5, 15}); !strings.Contains(f,
+		"cap_inheritable=+44(%di):u64") {
+		t.Errorf("Unexpected fetchargs %q for 5.15", f)
+	}
+	for _, v := range []kernelVersion{{6, 3}, {6, 8}} {
+		f := credentialsFetchargs(v)
+		if !strings.Contains(f, "cap_inheritable=+48(%di):u64") ||
+			!strings.Contains(f, "cap_bounding=+72(%di):u64") {
+			t.Errorf("Unexpected fetchargs %q for %d.%d", f,
+				v.major, v.minor)
+		}
+	}
+
+	// The capability sets are left out when the layout is unknown
+	f := credentialsFetchargs(kernelVersion{})
+	if f != credentialsIDFetchargs {
+		t.Errorf("Unexpected fetchargs %q for unknown kernel", f)
+	}
+
+	data := credentialsSampleData(100, 1000)
+	delete(data, "cap_bounding")
+	if c := newCred(data); c.uid != 1000 || c.capBounding != 0 {
+		t.Errorf("Unexpected credentials %+v", c)
+	}
+}
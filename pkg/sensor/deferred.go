@@ -0,0 +1,126 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The maximum number of events waiting to be finished. Events that are
+// deferred while the queue is full are dispatched unfinished.
+const deferredEventQueueLength = 256
+
+// deferringDecoderFn is the signature of a decoder that may defer the events
+// that it decodes. It is passed the id of the registered event that it
+// decodes samples for.
+type deferringDecoderFn func(uint64, *perf.SampleRecord, perf.TraceEventSampleData) (interface{}, error)
+
+type deferredEvent struct {
+	eventID uint64
+	event   *api.TelemetryEvent
+	finish  func()
+}
+
+// deferredEvents finishes events that need information read from procfs on
+// a background goroutine and then dispatches them, so that samples are not
+// decoded while waiting on procfs. Deferred events are dispatched in the
+// order that they were deferred, but after events decoded at the same time
+// that were not.
+type deferredEvents struct {
+	sync.Mutex
+	queue  chan deferredEvent
+	closed bool
+
+	// Used to dispatch finished events
+	dispatch func(uint64, interface{}, error)
+}
+
+func newDeferredEvents(dispatch func(uint64, interface{}, error)) *deferredEvents {
+	d := &deferredEvents{
+		queue:    make(chan deferredEvent, deferredEventQueueLength),
+		dispatch: dispatch,
+	}
+	go d.run()
+	return d
+}
+
+// run finishes and dispatches deferred events until closed.
+func (d *deferredEvents) run() {
+	for de := range d.queue {
+		de.finish()
+		d.dispatch(de.eventID, de.event, nil)
+	}
+}
+
+func (d *deferredEvents) close() {
+	d.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.Unlock()
+}
+
+// add queues an event to be finished and dispatched. It returns false if
+// the event can't be queued.
+func (d *deferredEvents) add(eventID uint64, ev *api.TelemetryEvent, finish func()) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.closed {
+		return false
+	}
+	select {
+	case d.queue <- deferredEvent{eventID, ev, finish}:
+		return true
+	default:
+		return false
+	}
+}
+
+// deferEvent defers an event decoded from a sample of the registered event
+// with the given id until finish has been called on a background goroutine.
+// It returns what the decoder should return: nil if the event was deferred,
+// or the unfinished event if it couldn't be.
+func (s *Sensor) deferEvent(eventID uint64, ev *api.TelemetryEvent, finish func()) interface{} {
+	if s.deferredEvents != nil && s.deferredEvents.add(eventID, ev, finish) {
+		return nil
+	}
+	return ev
+}
+
+// registerDeferringTracepoint registers a tracepoint with the sensor's
+// EventMonitor, like registerTracepoint, for a decoder that may defer the
+// events that it decodes.
+func (s *Sensor) registerDeferringTracepoint(name string, fn deferringDecoderFn,
+	options ...perf.RegisterEventOption) (uint64, error) {
+
+	// The decoder is created before the event's id is known, but the
+	// event isn't enabled until after it's registered.
+	var id uint64
+	eventID, err := s.registerTracepoint(name,
+		func(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+			return fn(atomic.LoadUint64(&id), sample, data)
+		}, options...)
+	if err == nil {
+		atomic.StoreUint64(&id, eventID)
+	}
+	return eventID, err
+}
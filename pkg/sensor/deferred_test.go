@@ -0,0 +1,66 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// newTestDeferredEvents returns deferred events that are dispatched to the
+// returned channel.
+func newTestDeferredEvents() (*deferredEvents, chan *api.TelemetryEvent) {
+	events := make(chan *api.TelemetryEvent, deferredEventQueueLength)
+	d := newDeferredEvents(func(eventID uint64, sample interface{}, err error) {
+		events <- sample.(*api.TelemetryEvent)
+	})
+	return d, events
+}
+
+// receiveDeferredEvent returns the next deferred event dispatched to events.
+func receiveDeferredEvent(t *testing.T, events chan *api.TelemetryEvent) *api.TelemetryEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for deferred event")
+	}
+	return nil
+}
+
+func TestDeferredEvents(t *testing.T) {
+	d, events := newTestDeferredEvents()
+	s := &Sensor{
+		deferredEvents: d,
+	}
+
+	e := &api.TelemetryEvent{}
+	if i := s.deferEvent(1, e, func() { e.ProcessPid = 100 }); i != nil {
+		t.Errorf("Expected event to be deferred, got %+v", i)
+	}
+	if e = receiveDeferredEvent(t, events); e.ProcessPid != 100 {
+		t.Errorf("Expected finished event, got %+v", e)
+	}
+
+	// Events that can't be deferred are returned unfinished
+	d.close()
+	e = &api.TelemetryEvent{}
+	if i := s.deferEvent(1, e, func() { e.ProcessPid = 100 }); i != e ||
+		e.ProcessPid != 0 {
+		t.Errorf("Expected unfinished event, got %+v", i)
+	}
+}
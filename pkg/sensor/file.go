@@ -16,7 +16,6 @@ package sensor
 
 import (
 	"fmt"
-	"strings"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
@@ -30,6 +29,213 @@ const (
 	fsDoSysOpenKprobeFetchargs = "filename=+0(%si):string flags=%dx:s32 mode=%cx:s32"
 )
 
+var fileEventTypes = expression.FieldTypeMap{
+	"filename":     int32(api.ValueType_STRING),
+	"new_filename": int32(api.ValueType_STRING),
+	"flags":        int32(api.ValueType_SINT32),
+	"mode":         int32(api.ValueType_SINT32),
+	"uid":          int32(api.ValueType_SINT32),
+	"gid":          int32(api.ValueType_SINT32),
+}
+
+// fileEventFields lists the fields available to filters for each type of
+// file event.
+var fileEventFields = map[api.FileEventType][]string{
+	api.FileEventType_FILE_EVENT_TYPE_OPEN:    {"filename", "flags", "mode"},
+	api.FileEventType_FILE_EVENT_TYPE_UNLINK:  {"filename"},
+	api.FileEventType_FILE_EVENT_TYPE_RENAME:  {"filename", "new_filename", "flags"},
+	api.FileEventType_FILE_EVENT_TYPE_CHMOD:   {"filename", "mode"},
+	api.FileEventType_FILE_EVENT_TYPE_CHOWN:   {"filename", "uid", "gid", "flags"},
+	api.FileEventType_FILE_EVENT_TYPE_MKDIR:   {"filename", "mode"},
+	api.FileEventType_FILE_EVENT_TYPE_LINK:    {"filename", "new_filename", "flags"},
+	api.FileEventType_FILE_EVENT_TYPE_SYMLINK: {"filename", "new_filename"},
+}
+
+func fileEventValues(fev *api.FileEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"filename":     fev.Filename,
+		"new_filename": fev.NewFilename,
+		"flags":        fev.Flags,
+		"mode":         fev.Mode,
+		"uid":          fev.Uid,
+		"gid":          fev.Gid,
+	}
+	if fev.Type == api.FileEventType_FILE_EVENT_TYPE_OPEN {
+		values["flags"] = fev.OpenFlags
+		values["mode"] = fev.OpenMode
+	}
+	return values
+}
+
+type fileKprobe struct {
+	symbol    string
+	fetchargs string
+}
+
+// fileKprobeSet is a set of kprobes that file events of one type are
+// collected from together.
+type fileKprobeSet struct {
+	// The kernels that the kprobes apply to are those from minVersion up
+	// to but not including maxVersion. Zero versions are unbounded.
+	minVersion kernelVersion
+	maxVersion kernelVersion
+
+	kprobes []fileKprobe
+}
+
+func (set fileKprobeSet) appliesTo(v kernelVersion) bool {
+	if v.isZero() {
+		return true
+	}
+	if !set.minVersion.isZero() && v.before(set.minVersion) {
+		return false
+	}
+	if !set.maxVersion.isZero() && !v.before(set.maxVersion) {
+		return false
+	}
+	return true
+}
+
+// fileEventKprobes lists the kprobes that file events other than opens are
+// collected from. Kernels before 4.17 implement the system calls directly,
+// and the older system calls call the newer ones (e.g., rename(2) calls
+// renameat2(2)). Later kernels implement them in do_* functions taking the
+// same arguments, until the do_* functions are changed to take a struct
+// filename, whose first member is the name, for io_uring: do_unlinkat always
+// does, do_rmdir and do_renameat2 do from 5.11, and do_mkdirat, do_linkat,
+// and do_symlinkat do from 5.15. Kernel releases are used to choose between
+// the do_* functions, because their symbols don't change with their
+// arguments. The first set of kprobes that applies to the running kernel and
+// can be registered is used.
+//
+// chmod(2) and chown(2) call the fchmodat(2) and fchownat(2) implementations,
+// as does lchown(2) with AT_SYMLINK_NOFOLLOW, so they are reported too.
+// fchmod(2) and fchown(2) change an open file rather than a filename, and are
+// not reported.
+var fileEventKprobes = map[api.FileEventType][]fileKprobeSet{
+	api.FileEventType_FILE_EVENT_TYPE_UNLINK: {
+		{
+			kprobes: []fileKprobe{
+				{"sys_unlink", "filename=+0(%di):string"},
+				{"sys_unlinkat", "filename=+0(%si):string"},
+				{"sys_rmdir", "filename=+0(%di):string"},
+			},
+		},
+		{
+			maxVersion: kernelVersion{5, 11},
+			kprobes: []fileKprobe{
+				{"do_unlinkat", "filename=+0(+0(%si)):string"},
+				{"do_rmdir", "filename=+0(%si):string"},
+			},
+		},
+		{
+			minVersion: kernelVersion{5, 11},
+			kprobes: []fileKprobe{
+				{"do_unlinkat", "filename=+0(+0(%si)):string"},
+				{"do_rmdir", "filename=+0(+0(%si)):string"},
+			},
+		},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_RENAME: {
+		{
+			kprobes: []fileKprobe{
+				{"sys_renameat2", "filename=+0(%si):string new_filename=+0(%cx):string flags=%r8:s32"},
+			},
+		},
+		{
+			maxVersion: kernelVersion{5, 11},
+			kprobes: []fileKprobe{
+				{"do_renameat2", "filename=+0(%si):string new_filename=+0(%cx):string flags=%r8:s32"},
+			},
+		},
+		{
+			minVersion: kernelVersion{5, 11},
+			kprobes: []fileKprobe{
+				{"do_renameat2", "filename=+0(+0(%si)):string new_filename=+0(+0(%cx)):string flags=%r8:s32"},
+			},
+		},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_CHMOD: {
+		{kprobes: []fileKprobe{{"sys_fchmodat", "filename=+0(%si):string mode=%dx:s32"}}},
+		{kprobes: []fileKprobe{{"do_fchmodat", "filename=+0(%si):string mode=%dx:s32"}}},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_CHOWN: {
+		{kprobes: []fileKprobe{{"sys_fchownat", "filename=+0(%si):string uid=%dx:s32 gid=%cx:s32 flags=%r8:s32"}}},
+		{kprobes: []fileKprobe{{"do_fchownat", "filename=+0(%si):string uid=%dx:s32 gid=%cx:s32 flags=%r8:s32"}}},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_MKDIR: {
+		{
+			kprobes: []fileKprobe{
+				{"sys_mkdirat", "filename=+0(%si):string mode=%dx:s32"},
+			},
+		},
+		{
+			maxVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_mkdirat", "filename=+0(%si):string mode=%dx:s32"},
+			},
+		},
+		{
+			minVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_mkdirat", "filename=+0(+0(%si)):string mode=%dx:s32"},
+			},
+		},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_LINK: {
+		{
+			kprobes: []fileKprobe{
+				{"sys_linkat", "filename=+0(%si):string new_filename=+0(%cx):string flags=%r8:s32"},
+			},
+		},
+		{
+			maxVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_linkat", "filename=+0(%si):string new_filename=+0(%cx):string flags=%r8:s32"},
+			},
+		},
+		{
+			minVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_linkat", "filename=+0(+0(%si)):string new_filename=+0(+0(%cx)):string flags=%r8:s32"},
+			},
+		},
+	},
+	api.FileEventType_FILE_EVENT_TYPE_SYMLINK: {
+		{
+			kprobes: []fileKprobe{
+				{"sys_symlinkat", "filename=+0(%di):string new_filename=+0(%dx):string"},
+			},
+		},
+		{
+			maxVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_symlinkat", "filename=+0(%di):string new_filename=+0(%dx):string"},
+			},
+		},
+		{
+			minVersion: kernelVersion{5, 15},
+			kprobes: []fileKprobe{
+				{"do_symlinkat", "filename=+0(+0(%di)):string new_filename=+0(+0(%dx)):string"},
+			},
+		},
+	},
+}
+
+// fileEventKprobeSets returns the sets of kprobes that file events of the
+// given type may be collected from on the running kernel.
+func fileEventKprobeSets(t api.FileEventType) []fileKprobeSet {
+	v := runningKernelVersion()
+
+	var sets []fileKprobeSet
+	for _, set := range fileEventKprobes[t] {
+		if set.appliesTo(v) {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
+
 type fileOpenFilter struct {
 	sensor *Sensor
 }
@@ -48,6 +254,43 @@ func (f *fileOpenFilter) decodeDoSysOpen(sample *perf.SampleRecord, data perf.Tr
 	return ev, nil
 }
 
+// fileEventDecoder decodes the kprobes for file events other than opens.
+type fileEventDecoder struct {
+	sensor    *Sensor
+	eventType api.FileEventType
+}
+
+func (d *fileEventDecoder) decodeFileEvent(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	fev := &api.FileEvent{
+		Type: d.eventType,
+	}
+	if v, ok := data["filename"].(string); ok {
+		fev.Filename = v
+	}
+	if v, ok := data["new_filename"].(string); ok {
+		fev.NewFilename = v
+	}
+	if v, ok := data["mode"].(int32); ok {
+		fev.Mode = v
+	}
+	if v, ok := data["uid"].(int32); ok {
+		fev.Uid = v
+	}
+	if v, ok := data["gid"].(int32); ok {
+		fev.Gid = v
+	}
+	if v, ok := data["flags"].(int32); ok {
+		fev.Flags = v
+	}
+
+	ev := d.sensor.NewEventFromSample(sample, data)
+	ev.Event = &api.TelemetryEvent_File{
+		File: fev,
+	}
+
+	return ev, nil
+}
+
 func rewriteFileEventFilter(fef *api.FileEventFilter) {
 	if fef.Filename != nil {
 		newExpr := expression.Equal(
@@ -78,68 +321,113 @@ func rewriteFileEventFilter(fef *api.FileEventFilter) {
 	if fef.CreateModeMask != nil {
 		newExpr := expression.BitwiseAnd(
 			expression.Identifier("mode"),
-			expression.Value(fef.OpenFlagsMask.Value))
+			expression.Value(fef.CreateModeMask.Value))
 		fef.FilterExpression = expression.LogicalAnd(
 			newExpr, fef.FilterExpression)
 		fef.CreateModeMask = nil
 	}
 }
 
-func registerFileEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.FileEventFilter) {
-	var filterString string
+// fileEventProbes returns the names of the tracepoints and kprobes that file
+// events of the given type are collected from.
+func fileEventProbes(t api.FileEventType) []string {
+	if t == api.FileEventType_FILE_EVENT_TYPE_OPEN {
+		return []string{"fs/do_sys_open", fsDoSysOpenKprobeAddress}
+	}
+
+	var probes []string
+	seen := make(map[string]bool)
+	for _, set := range fileEventKprobeSets(t) {
+		for _, kp := range set.kprobes {
+			if !seen[kp.symbol] {
+				seen[kp.symbol] = true
+				probes = append(probes, kp.symbol)
+			}
+		}
+	}
+	return probes
+}
 
-	wildcard := false
-	filters := make(map[string]bool, len(events))
+// fileEventFilterString returns the kernel filter string for a file event
+// filter, or an error if the filter is invalid. An empty string is returned
+// for filters that match all events of their type.
+func fileEventFilterString(fef *api.FileEventFilter) (string, error) {
+	fields, ok := fileEventFields[fef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported file event type %s",
+			fef.Type)
+	}
+
+	// Translate deprecated fields into an expression
+	rewriteFileEventFilter(fef)
+
+	if fef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(fef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+
+	types := make(expression.FieldTypeMap, len(fields))
+	for _, f := range fields {
+		types[f] = fileEventTypes[f]
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+func registerFileEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.FileEventFilter) {
+	filters := make(map[api.FileEventType]map[string]int)
 	for _, fef := range events {
-		if fef.Type != api.FileEventType_FILE_EVENT_TYPE_OPEN {
+		s, err := fileEventFilterString(fef)
+		if err != nil {
+			glog.V(1).Infof("Invalid file event filter: %s", err)
 			continue
 		}
 
-		// Translate deprecated fields into an expression
-		rewriteFileEventFilter(fef)
-
-		if fef.FilterExpression == nil {
-			wildcard = true
-		} else {
-			expr, err := expression.NewExpression(fef.FilterExpression)
-			if err != nil {
-				glog.V(1).Infof("Invalid file event filter: %s", err)
-				continue
-			}
-			err = expr.ValidateKernelFilter()
-			if err != nil {
-				glog.V(1).Infof("Invalid file event filter as kernel filter: %s", err)
-				continue
-			}
-			s := expr.KernelFilterString()
-			filters[s] = true
+		if filters[fef.Type] == nil {
+			filters[fef.Type] = make(map[string]int)
 		}
+		filters[fef.Type][s]++
 	}
 
-	if !wildcard {
-		if len(filters) == 0 {
-			return
+	for t, typeFilters := range filters {
+		filterString, active := fullFilterString(typeFilters)
+		if !active {
+			continue
 		}
 
-		parts := make([]string, 0, len(filters))
-		for k := range filters {
-			parts = append(parts, fmt.Sprintf("(%s)", k))
+		if t == api.FileEventType_FILE_EVENT_TYPE_OPEN {
+			registerFileOpenEvents(sensor, eventMap, filterString)
+		} else {
+			registerFileKprobes(sensor, eventMap, t, filterString)
 		}
-		filterString = strings.Join(parts, " || ")
-	} else if len(events) == 0 {
-		return
 	}
+}
 
+func registerFileOpenEvents(sensor *Sensor, eventMap subscriptionMap, filterString string) {
 	f := fileOpenFilter{
 		sensor: sensor,
 	}
 
-	eventID, err := sensor.monitor.RegisterTracepoint("fs/do_sys_open", f.decodeDoSysOpen,
-		perf.WithFilter(filterString))
-	if err != nil {
+	eventID, tracepointErr := sensor.registerTracepoint("fs/do_sys_open",
+		f.decodeDoSysOpen, perf.WithFilter(filterString))
+	if tracepointErr != nil {
 		glog.V(1).Infof("Tracepoint fs/do_sys_open not found, adding a kprobe to emulate")
 
-		eventID, err = sensor.monitor.RegisterKprobe(
+		var err error
+		eventID, err = sensor.registerKprobe(
 			fsDoSysOpenKprobeAddress,
 			false,
 			fsDoSysOpenKprobeFetchargs,
@@ -147,9 +435,60 @@ func registerFileEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.
 			perf.WithFilter(filterString))
 		if err != nil {
 			glog.Warning("Couldn't register kprobe fs/do_sys_open")
+			sensor.probeFailed("fs/do_sys_open", tracepointErr)
+			sensor.probeFailed(fsDoSysOpenKprobeAddress, err)
 			return
 		}
+		sensor.probeSucceeded("fs/do_sys_open")
 	}
 
 	eventMap[eventID] = &subscription{}
 }
+
+func registerFileKprobes(sensor *Sensor, eventMap subscriptionMap, t api.FileEventType, filterString string) {
+	d := &fileEventDecoder{
+		sensor:    sensor,
+		eventType: t,
+	}
+
+	// Only report failures if no set of kprobes can be registered, and
+	// then report each of the kprobes that were tried.
+	var (
+		tried []string
+		errs  []error
+	)
+	for _, set := range fileEventKprobeSets(t) {
+		kprobes := set.kprobes
+		eventID, err := sensor.registerKprobe(
+			kprobes[0].symbol, false, kprobes[0].fetchargs,
+			d.decodeFileEvent, perf.WithFilter(filterString))
+		if err != nil {
+			glog.V(1).Infof("Couldn't register kprobe %s: %s",
+				kprobes[0].symbol, err)
+			tried = append(tried, kprobes[0].symbol)
+			errs = append(errs, err)
+			continue
+		}
+		eventMap[eventID] = &subscription{}
+		sensor.probeSucceeded(tried...)
+
+		for _, kp := range kprobes[1:] {
+			eventID, err = sensor.registerKprobe(
+				kp.symbol, false, kp.fetchargs,
+				d.decodeFileEvent, perf.WithFilter(filterString))
+			if err != nil {
+				glog.Warningf("Couldn't register kprobe %s: %s",
+					kp.symbol, err)
+				sensor.probeFailed(kp.symbol, err)
+				continue
+			}
+			eventMap[eventID] = &subscription{}
+		}
+		return
+	}
+
+	glog.Warningf("Couldn't register kprobes for %s events", t)
+	for i, symbol := range tried {
+		sensor.probeFailed(symbol, errs[i])
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestFileEventFilterString(t *testing.T) {
+	tests := []struct {
+		fef      *api.FileEventFilter
+		valid    bool
+		expected string
+	}{
+		{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_UNLINK,
+			},
+			true, "",
+		},
+		{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_RENAME,
+				FilterExpression: expression.Equal(
+					expression.Identifier("new_filename"),
+					expression.Value("/etc/passwd")),
+			},
+			true, `new_filename == "/etc/passwd"`,
+		},
+		{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_CHOWN,
+				FilterExpression: expression.Equal(
+					expression.Identifier("uid"),
+					expression.Value(int32(0))),
+			},
+			true, "uid == 0",
+		},
+		// Unlink events have no uid
+		{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_UNLINK,
+				FilterExpression: expression.Equal(
+					expression.Identifier("uid"),
+					expression.Value(int32(0))),
+			},
+			false, "",
+		},
+		{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_UNKNOWN,
+			},
+			false, "",
+		},
+	}
+
+	for i, test := range tests {
+		s, err := fileEventFilterString(test.fef)
+		if test.valid && err != nil {
+			t.Errorf("Filter %d: unexpected error: %s", i, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("Filter %d: expected an error", i)
+		} else if s != test.expected {
+			t.Errorf("Filter %d: expected %q, got %q", i,
+				test.expected, s)
+		}
+	}
+
+	for t2 := range fileEventFields {
+		if len(fileEventProbes(t2)) == 0 {
+			t.Errorf("No probes for %s events", t2)
+		}
+	}
+}
+
+func TestDecodeFileEvent(t *testing.T) {
+	d := &fileEventDecoder{
+		sensor: &Sensor{
+			processCache: ProcessInfoCache{
+				cache: newMapTaskCache(),
+			},
+		},
+		eventType: api.FileEventType_FILE_EVENT_TYPE_RENAME,
+	}
+
+	i, err := d.decodeFileEvent(&perf.SampleRecord{}, perf.TraceEventSampleData{
+		"common_pid":   int32(100),
+		"filename":     "a",
+		"new_filename": "b",
+		"flags":        int32(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fev := i.(*api.TelemetryEvent).GetFile()
+	if fev == nil || fev.Type != api.FileEventType_FILE_EVENT_TYPE_RENAME ||
+		fev.Filename != "a" || fev.NewFilename != "b" || fev.Flags != 1 {
+		t.Errorf("Unexpected file event %+v", fev)
+	}
+
+	values := fileEventValues(fev)
+	if values["new_filename"] != "b" || values["flags"] != int32(1) {
+		t.Errorf("Unexpected file event values %+v", values)
+	}
+}
+
+func TestFileKprobeSetAppliesTo(t *testing.T) {
+	// The fetchargs of do_rmdir depend on the kernel version
+	rmdir := func(v kernelVersion) string {
+		for _, set := range fileEventKprobes[api.FileEventType_FILE_EVENT_TYPE_UNLINK] {
+			if !set.appliesTo(v) {
+				continue
+			}
+			for _, kp := range set.kprobes {
+				if kp.symbol == "do_rmdir" {
+					return kp.fetchargs
+				}
+			}
+		}
+		return ""
+	}
+
+	if f := rmdir(kernelVersion{4, 19}); f != "filename=+0(%si):string" {
+		t.Errorf("Unexpected do_rmdir fetchargs %q for 4.19", f)
+	}
+	if f := rmdir(kernelVersion{5, 11}); f != "filename=+0(+0(%si)):string" {
+		t.Errorf("Unexpected do_rmdir fetchargs %q for 5.11", f)
+	}
+
+	// Every set applies when the kernel version is unknown
+	set := fileKprobeSet{
+		minVersion: kernelVersion{5, 15},
+	}
+	if !set.appliesTo(kernelVersion{}) || set.appliesTo(kernelVersion{5, 4}) ||
+		!set.appliesTo(kernelVersion{6, 1}) {
+		t.Error("Unexpected kernel versions for kprobe set")
+	}
+}
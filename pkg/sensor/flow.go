@@ -0,0 +1,840 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/stream"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	// The maximum number of threads with system calls in progress tracked
+	// by a flowTable. Entries are removed when the calls return, so the
+	// table is only reset if the exits of calls are missed.
+	flowCallsSize = 65536
+
+	// IPv4 and IPv6 sockets send and receive data through inet_sendmsg()
+	// and inet_recvmsg(), or their IPv6 counterparts in newer kernels,
+	// whichever system call is used. The socket layer above them can't
+	// be probed reliably: since Linux 6.6, sock_sendmsg() is bypassed by
+	// the send calls through an inlined __sock_sendmsg(), and recvmmsg(2)
+	// receives all but the first message through sock_recvmsg_nosec().
+	// The destination of a send is fetched from the kernel copy of the
+	// msghdr, whose first members are the msg_name pointer and
+	// msg_namelen.
+	flowKprobeSendmsgSymbol      = "inet_sendmsg"
+	flowKprobeInet6SendmsgSymbol = "inet6_sendmsg"
+	flowKprobeSendmsgFetchargs   = "sock=%di msg_namelen=+8(%si):s32 sa_family=+0(+0(%si)):u16 sin_port=+2(+0(%si)):u16 sin_addr=+4(+0(%si)):u32 sin6_port=+2(+0(%si)):u16 sin6_addr_high=+8(+0(%si)):u64 sin6_addr_low=+16(+0(%si)):u64"
+
+	flowKprobeRecvmsgSymbol      = "inet_recvmsg"
+	flowKprobeInet6RecvmsgSymbol = "inet6_recvmsg"
+	flowKprobeRecvmsgFetchargs   = "sock=%di"
+
+	flowKretprobeFetchargs = "ret=$retval:s32"
+)
+
+// The system calls that send and receive data on sockets. These are traced
+// to learn the file descriptors of the sockets that the kprobes above see.
+// The kernel can't tell whether read(2), write(2) and friends are called on
+// sockets until after they are entered, so their tracepoints can't be
+// filtered, and every call to them on the host is sampled while flows are
+// tracked. The samples are cheap to decode, and are shared by all of the
+// subscriptions tracking flows, but they are many.
+var flowSyscalls = []string{
+	"read",
+	"readv",
+	"recvfrom",
+	"recvmmsg",
+	"recvmsg",
+	"sendmmsg",
+	"sendmsg",
+	"sendto",
+	"write",
+	"writev",
+}
+
+// The kprobes that see the data sent and received on sockets. The IPv6
+// functions were split from the IPv4 ones in newer kernels, so they are
+// optional; older kernels send and receive on IPv6 sockets through the IPv4
+// functions.
+var flowKprobes = []struct {
+	symbol    string
+	fetchargs string
+	send      bool
+	optional  bool
+}{
+	{flowKprobeSendmsgSymbol, flowKprobeSendmsgFetchargs, true, false},
+	{flowKprobeInet6SendmsgSymbol, flowKprobeSendmsgFetchargs, true, true},
+	{flowKprobeRecvmsgSymbol, flowKprobeRecvmsgFetchargs, false, false},
+	{flowKprobeInet6RecvmsgSymbol, flowKprobeRecvmsgFetchargs, false, true},
+}
+
+// flowEventProbes returns the names of the tracepoints and kprobes that
+// flows are tracked from.
+func flowEventProbes() []string {
+	probes := make([]string, 0, len(flowSyscalls)*2+len(flowKprobes))
+	for _, name := range flowSyscalls {
+		probes = append(probes,
+			fmt.Sprintf("syscalls/sys_enter_%s", name),
+			fmt.Sprintf("syscalls/sys_exit_%s", name))
+	}
+	for _, k := range flowKprobes {
+		if !k.optional {
+			probes = append(probes, k.symbol)
+		}
+	}
+	return probes
+}
+
+func validateFlowEventFilter(ff *api.FlowEventFilter) error {
+	if ff.Interval <= 0 {
+		return errors.New("flow interval must be greater than 0")
+	}
+	return nil
+}
+
+// flowSocket identifies a socket by the process and the file descriptor
+// that data is sent and received through.
+type flowSocket struct {
+	pid int32
+	fd  uint64
+}
+
+// flowPeer is the IPv4 or IPv6 address that data is sent to on a socket
+// that is not connected. It is the zero value for connected sockets and for
+// received data, whose source address is not known.
+type flowPeer struct {
+	family uint16
+	port   uint16
+	addr   uint32
+	high   uint64
+	low    uint64
+}
+
+// newFlowPeer returns the destination of an inet_sendmsg() call.
+func newFlowPeer(data perf.TraceEventSampleData) flowPeer {
+	if data["msg_namelen"].(int32) <= 0 {
+		return flowPeer{}
+	}
+	switch family := data["sa_family"].(uint16); family {
+	case afInet:
+		return flowPeer{
+			family: family,
+			port:   data["sin_port"].(uint16),
+			addr:   data["sin_addr"].(uint32),
+		}
+	case afInet6:
+		return flowPeer{
+			family: family,
+			port:   data["sin6_port"].(uint16),
+			high:   data["sin6_addr_high"].(uint64),
+			low:    data["sin6_addr_low"].(uint64),
+		}
+	}
+	return flowPeer{}
+}
+
+// address returns the network address of a peer, or nil for the zero peer.
+// Ports are in network byte order, as in network events.
+func (p flowPeer) address() *api.NetworkAddress {
+	switch p.family {
+	case afInet:
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+			Address: &api.NetworkAddress_Ipv4Address{
+				Ipv4Address: &api.IPv4AddressAndPort{
+					Address: &api.IPv4Address{
+						Address: p.addr,
+					},
+					Port: uint32(p.port),
+				},
+			},
+		}
+	case afInet6:
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+			Address: &api.NetworkAddress_Ipv6Address{
+				Ipv6Address: &api.IPv6AddressAndPort{
+					Address: &api.IPv6Address{
+						High: p.high,
+						Low:  p.low,
+					},
+					Port: uint32(p.port),
+				},
+			},
+		}
+	}
+	return nil
+}
+
+type flowCounters struct {
+	bytesSent        uint64
+	bytesReceived    uint64
+	messagesSent     uint64
+	messagesReceived uint64
+}
+
+func (c *flowCounters) add(o flowCounters) {
+	c.bytesSent += o.bytesSent
+	c.bytesReceived += o.bytesReceived
+	c.messagesSent += o.messagesSent
+	c.messagesReceived += o.messagesReceived
+}
+
+func (c *flowCounters) isZero() bool {
+	return c.messagesSent == 0 && c.messagesReceived == 0
+}
+
+// flowRecord counts the data sent to one peer of a socket, or sent and
+// received on the socket without a peer address, since the previous
+// summary.
+type flowRecord struct {
+	startTime int64
+	counters  flowCounters
+}
+
+// socketFlows holds the flows of a socket. The kernel address of the socket
+// is recorded so that a file descriptor reused for another socket starts
+// new flows. The inode and addresses of the socket are only read from
+// procfs when its flows are summarized.
+type socketFlows struct {
+	sock  uint64
+	flows map[flowPeer]*flowRecord
+
+	// Only used by summarize
+	inode  uint64
+	local  *api.NetworkAddress
+	remote *api.NetworkAddress
+}
+
+type closedSocketFlows struct {
+	socket flowSocket
+	flows  *socketFlows
+}
+
+// flowCall tracks a system call sending or receiving data in a thread. The
+// file descriptor is known when the system call is entered, and each
+// message sent or received is seen between the entry and return of
+// inet_sendmsg() or inet_recvmsg(), of which there may be several for
+// sendmmsg(2) and recvmmsg(2).
+type flowCall struct {
+	fd     uint64
+	sock   uint64
+	peer   flowPeer
+	send   bool
+	active bool
+}
+
+// flowTracker follows the system calls sending and receiving data on
+// sockets for all of the subscriptions tracking flows, which share a single
+// registration of its events, and counts the data in the flowTable of each
+// subscription.
+type flowTracker struct {
+	sync.Mutex
+	sensor *Sensor
+	calls  map[int32]flowCall // tid : call in progress
+	tables map[*flowTable]struct{}
+
+	// Registration is serialized separately so that samples can be
+	// decoded while events are registered or unregistered. The events
+	// are registered while any subscription is tracking flows.
+	registerLock sync.Mutex
+	eventIDs     []uint64
+}
+
+func newFlowTracker(sensor *Sensor) *flowTracker {
+	return &flowTracker{
+		sensor: sensor,
+		calls:  make(map[int32]flowCall),
+		tables: make(map[*flowTable]struct{}),
+	}
+}
+
+// add starts counting flows in a subscription's table, registering the
+// tracker's events if it is the first.
+func (f *flowTracker) add(t *flowTable) error {
+	f.registerLock.Lock()
+	defer f.registerLock.Unlock()
+
+	f.Lock()
+	first := len(f.tables) == 0
+	f.Unlock()
+	if first {
+		eventIDs, err := registerFlowEvents(f.sensor, f)
+		if err != nil {
+			for _, eventID := range eventIDs {
+				f.sensor.monitor.UnregisterEvent(eventID)
+			}
+			return err
+		}
+		for _, eventID := range eventIDs {
+			f.sensor.monitor.Enable(eventID)
+		}
+		f.eventIDs = eventIDs
+	}
+
+	f.Lock()
+	f.tables[t] = struct{}{}
+	f.Unlock()
+	return nil
+}
+
+// remove stops counting flows in a subscription's table, unregistering the
+// tracker's events if it is the last.
+func (f *flowTracker) remove(t *flowTable) {
+	f.registerLock.Lock()
+	defer f.registerLock.Unlock()
+
+	f.Lock()
+	delete(f.tables, t)
+	last := len(f.tables) == 0
+	if last {
+		f.calls = make(map[int32]flowCall)
+	}
+	f.Unlock()
+	if last {
+		for _, eventID := range f.eventIDs {
+			f.sensor.monitor.UnregisterEvent(eventID)
+		}
+		f.eventIDs = nil
+	}
+}
+
+func (f *flowTracker) decodeSysEnter(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	f.Lock()
+	if len(f.calls) >= flowCallsSize {
+		f.calls = make(map[int32]flowCall)
+	}
+	f.calls[data["common_pid"].(int32)] = flowCall{
+		fd: data["fd"].(uint64),
+	}
+	f.Unlock()
+
+	return nil, nil
+}
+
+func (f *flowTracker) decodeSysExit(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	f.Lock()
+	delete(f.calls, data["common_pid"].(int32))
+	f.Unlock()
+
+	return nil, nil
+}
+
+func (f *flowTracker) makeSockEnterDecoder(send bool) perf.TraceEventDecoderFn {
+	return func(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+		tid := data["common_pid"].(int32)
+
+		f.Lock()
+		defer f.Unlock()
+
+		// Sockets used by the kernel outside of the traced system
+		// calls are ignored
+		call, ok := f.calls[tid]
+		if !ok {
+			return nil, nil
+		}
+		call.sock = data["sock"].(uint64)
+		call.send = send
+		call.active = true
+		if send {
+			call.peer = newFlowPeer(data)
+		} else {
+			call.peer = flowPeer{}
+		}
+		f.calls[tid] = call
+
+		return nil, nil
+	}
+}
+
+func (f *flowTracker) decodeSockReturn(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	tid := data["common_pid"].(int32)
+	ret := data["ret"].(int32)
+
+	f.Lock()
+	defer f.Unlock()
+
+	call, ok := f.calls[tid]
+	if !ok || !call.active {
+		return nil, nil
+	}
+	call.active = false
+	f.calls[tid] = call
+	if ret <= 0 {
+		return nil, nil
+	}
+
+	// Flows belong to processes rather than threads
+	pid := tid
+	if leader, ok := f.sensor.processCache.lookupLeader(int(tid)); ok && leader.pid != 0 {
+		pid = int32(leader.pid)
+	}
+
+	startTime := int64(sample.Time) - f.sensor.bootMonotimeNanos
+	for t := range f.tables {
+		t.count(pid, call, ret, startTime)
+	}
+
+	return nil, nil
+}
+
+// flowTable accumulates the data sent and received by each process on each
+// of its sockets for a subscription.
+type flowTable struct {
+	sync.Mutex
+	sensor  *Sensor
+	sockets map[flowSocket]*socketFlows
+	closed  []closedSocketFlows
+
+	// Used to read files and links in the host's procfs
+	readFile func(string) ([]byte, error)
+	readlink func(string) (string, error)
+}
+
+func newFlowTable(sensor *Sensor) *flowTable {
+	procFS := sys.HostProcFS()
+	return &flowTable{
+		sensor:   sensor,
+		sockets:  make(map[flowSocket]*socketFlows),
+		readFile: procFS.ReadFile,
+		readlink: procFS.Readlink,
+	}
+}
+
+// count adds the data sent or received by a call of a process to its flow,
+// starting the flow at the given time if it is new.
+func (t *flowTable) count(pid int32, call flowCall, ret int32, startTime int64) {
+	t.Lock()
+	defer t.Unlock()
+
+	key := flowSocket{
+		pid: pid,
+		fd:  call.fd,
+	}
+	sf, ok := t.sockets[key]
+	if ok && sf.sock != call.sock {
+		// The file descriptor was closed and reused for another
+		// socket, so the flows of the old socket are finished
+		t.closed = append(t.closed, closedSocketFlows{
+			socket: key,
+			flows:  sf,
+		})
+		ok = false
+	}
+	if !ok {
+		sf = &socketFlows{
+			sock:  call.sock,
+			flows: make(map[flowPeer]*flowRecord),
+		}
+		t.sockets[key] = sf
+	}
+
+	flow, ok := sf.flows[call.peer]
+	if !ok {
+		flow = &flowRecord{
+			startTime: startTime,
+		}
+		sf.flows[call.peer] = flow
+	}
+
+	if call.send {
+		flow.counters.bytesSent += uint64(ret)
+		flow.counters.messagesSent++
+	} else {
+		flow.counters.bytesReceived += uint64(ret)
+		flow.counters.messagesReceived++
+	}
+}
+
+// socketInode returns the inode of the socket open as a file descriptor in a
+// process, or 0 if it is not a socket or can't be read.
+func (t *flowTable) socketInode(key flowSocket) uint64 {
+	link, err := t.readlink(fmt.Sprintf("%d/fd/%d", key.pid, key.fd))
+	if err != nil || !strings.HasPrefix(link, "socket:[") ||
+		!strings.HasSuffix(link, "]") {
+		return 0
+	}
+	inode, err := strconv.ParseUint(link[8:len(link)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return inode
+}
+
+func (t *flowTable) newFlowEvent(eventType api.FlowEventType, key flowSocket, sf *socketFlows, peer flowPeer, flow *flowRecord, counters flowCounters) *api.TelemetryEvent {
+	remote := peer.address()
+	if remote == nil {
+		remote = sf.remote
+	}
+
+	e := t.sensor.NewEvent()
+	t.sensor.setEventProcess(e, key.pid)
+	e.Event = &api.TelemetryEvent_Flow{
+		Flow: &api.FlowEvent{
+			Type:               eventType,
+			Sockfd:             key.fd,
+			Inode:              sf.inode,
+			LocalAddress:       sf.local,
+			RemoteAddress:      remote,
+			BytesSent:          counters.bytesSent,
+			BytesReceived:      counters.bytesReceived,
+			MessagesSent:       counters.messagesSent,
+			MessagesReceived:   counters.messagesReceived,
+			StartMonotimeNanos: flow.startTime,
+		},
+	}
+
+	return e
+}
+
+// removeClosed forgets a socket whose flows were finished by the reuse of
+// its file descriptor. It must be called with the table locked.
+func (t *flowTable) removeClosed(sf *socketFlows) {
+	for i, c := range t.closed {
+		if c.flows == sf {
+			t.closed = append(t.closed[:i], t.closed[i+1:]...)
+			return
+		}
+	}
+}
+
+type flowSummary struct {
+	peer     flowPeer
+	flow     *flowRecord
+	counters flowCounters
+}
+
+// takeCounters returns the counters of the flows of a socket that have sent
+// or received data since they were last summarized, and resets them. It
+// must be called with the table locked.
+func takeCounters(sf *socketFlows) []flowSummary {
+	var summaries []flowSummary
+	for peer, flow := range sf.flows {
+		if flow.counters.isZero() {
+			continue
+		}
+		summaries = append(summaries, flowSummary{
+			peer:     peer,
+			flow:     flow,
+			counters: flow.counters,
+		})
+		flow.counters = flowCounters{}
+	}
+	return summaries
+}
+
+// summarize returns summaries of the flows that have sent or received data
+// since they were last summarized, and final summaries of the flows of
+// sockets that have been closed, which are then forgotten. Counters are
+// taken with the table locked, but procfs is read without it, so that
+// samples are not held up.
+//
+// Sockets are known to be closed when their file descriptors are reused for
+// other sockets that send or receive data, or when the file descriptors no
+// longer refer to them when they are summarized. A file descriptor that is
+// closed and reused for another socket between summaries without the new
+// socket sending or receiving data is not noticed until the next summary.
+func (t *flowTable) summarize() []*api.TelemetryEvent {
+	type socketSummary struct {
+		socket    flowSocket
+		flows     *socketFlows
+		summaries []flowSummary
+	}
+
+	t.Lock()
+	var open []socketSummary
+	for key, sf := range t.sockets {
+		open = append(open, socketSummary{
+			socket:    key,
+			flows:     sf,
+			summaries: takeCounters(sf),
+		})
+	}
+	var closed []socketSummary
+	for _, c := range t.closed {
+		closed = append(closed, socketSummary{
+			socket:    c.socket,
+			flows:     c.flows,
+			summaries: takeCounters(c.flows),
+		})
+	}
+	t.closed = nil
+	t.Unlock()
+
+	var (
+		events []*api.TelemetryEvent
+		gone   []socketSummary
+	)
+	for _, s := range open {
+		sf := s.flows
+		inode := t.socketInode(s.socket)
+		if sf.inode == 0 && inode != 0 {
+			sf.inode = inode
+			sf.local, sf.remote = t.socketAddresses(s.socket.pid, inode)
+		}
+		if inode == 0 || inode != sf.inode {
+			gone = append(gone, s)
+			continue
+		}
+
+		for _, fs := range s.summaries {
+			events = append(events, t.newFlowEvent(
+				api.FlowEventType_FLOW_EVENT_TYPE_UPDATE,
+				s.socket, sf, fs.peer, fs.flow, fs.counters))
+		}
+	}
+
+	if len(gone) > 0 {
+		t.Lock()
+		for i, s := range gone {
+			if t.sockets[s.socket] == s.flows {
+				delete(t.sockets, s.socket)
+			} else {
+				t.removeClosed(s.flows)
+			}
+
+			// Data counted since the counters were taken is
+			// included in the final summary
+			gone[i].summaries = append(s.summaries,
+				takeCounters(s.flows)...)
+		}
+		t.Unlock()
+	}
+
+	for _, s := range append(closed, gone...) {
+		counters := make(map[flowPeer]flowCounters)
+		for _, fs := range s.summaries {
+			c := counters[fs.peer]
+			c.add(fs.counters)
+			counters[fs.peer] = c
+		}
+		for peer, flow := range s.flows.flows {
+			events = append(events, t.newFlowEvent(
+				api.FlowEventType_FLOW_EVENT_TYPE_CLOSE,
+				s.socket, s.flows, peer, flow, counters[peer]))
+		}
+	}
+
+	return events
+}
+
+// parseProcNetAddress parses an address from /proc/net/tcp and friends,
+// which has the form "ADDR:PORT". The address is printed as the hex value of
+// each 32-bit word of the address in memory and the port is in host byte
+// order.
+func parseProcNetAddress(s string, ipv6 bool) *api.NetworkAddress {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil
+	}
+	port, err := strconv.ParseUint(s[i+1:], 16, 16)
+	if err != nil {
+		return nil
+	}
+	addr := s[:i]
+
+	if !ipv6 {
+		a, err := strconv.ParseUint(addr, 16, 32)
+		if err != nil || len(addr) != 8 {
+			return nil
+		}
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+			Address: &api.NetworkAddress_Ipv4Address{
+				Ipv4Address: &api.IPv4AddressAndPort{
+					Address: &api.IPv4Address{
+						Address: uint32(a),
+					},
+					Port: uint32(networkToHost16(uint16(port))),
+				},
+			},
+		}
+	}
+
+	if len(addr) != 32 {
+		return nil
+	}
+	var words [4]uint64
+	for j := range words {
+		words[j], err = strconv.ParseUint(addr[j*8:j*8+8], 16, 32)
+		if err != nil {
+			return nil
+		}
+	}
+	return &api.NetworkAddress{
+		Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+		Address: &api.NetworkAddress_Ipv6Address{
+			Ipv6Address: &api.IPv6AddressAndPort{
+				Address: &api.IPv6Address{
+					High: words[0] | words[1]<<32,
+					Low:  words[2] | words[3]<<32,
+				},
+				Port: uint32(networkToHost16(uint16(port))),
+			},
+		},
+	}
+}
+
+// parseProcNetSockets finds the local and remote addresses of the socket
+// with the given inode in the contents of /proc/net/tcp and friends.
+func parseProcNetSockets(data []byte, inode uint64, ipv6 bool) (*api.NetworkAddress, *api.NetworkAddress, bool) {
+	want := strconv.FormatUint(inode, 10)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 || fields[9] != want {
+			continue
+		}
+		return parseProcNetAddress(fields[1], ipv6),
+			parseProcNetAddress(fields[2], ipv6), true
+	}
+	return nil, nil, false
+}
+
+// socketAddresses returns the local and remote addresses of a TCP or UDP
+// socket of a process, read from the process's network namespace in procfs.
+func (t *flowTable) socketAddresses(pid int32, inode uint64) (*api.NetworkAddress, *api.NetworkAddress) {
+	for _, table := range []struct {
+		name string
+		ipv6 bool
+	}{
+		{"tcp", false},
+		{"tcp6", true},
+		{"udp", false},
+		{"udp6", true},
+	} {
+		data, err := t.readFile(fmt.Sprintf("%d/net/%s",
+			pid, table.name))
+		if err != nil {
+			continue
+		}
+		local, remote, ok := parseProcNetSockets(data, inode, table.ipv6)
+		if ok {
+			return local, remote
+		}
+	}
+	return nil, nil
+}
+
+func registerFlowEvents(sensor *Sensor, f *flowTracker) ([]uint64, error) {
+	var eventIDs []uint64
+
+	for _, name := range flowSyscalls {
+		enter := fmt.Sprintf("syscalls/sys_enter_%s", name)
+		eventID, err := sensor.registerTracepoint(enter, f.decodeSysEnter)
+		if err != nil {
+			sensor.probeFailed(enter, err)
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, eventID)
+
+		exit := fmt.Sprintf("syscalls/sys_exit_%s", name)
+		eventID, err = sensor.registerTracepoint(exit, f.decodeSysExit)
+		if err != nil {
+			sensor.probeFailed(exit, err)
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	for _, k := range flowKprobes {
+		eventID, err := sensor.registerKprobe(k.symbol, false,
+			k.fetchargs, f.makeSockEnterDecoder(k.send))
+		if err != nil && k.optional {
+			glog.V(1).Infof("Couldn't register kprobe %s: %s",
+				k.symbol, err)
+			continue
+		}
+		if err != nil {
+			sensor.probeFailed(k.symbol, err)
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, eventID)
+
+		eventID, err = sensor.registerKprobe(k.symbol, true,
+			flowKretprobeFetchargs, f.decodeSockReturn)
+		if err != nil {
+			sensor.probeFailed(k.symbol, err)
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	return eventIDs, nil
+}
+
+func newFlowSource(sensor *Sensor, filter *api.FlowEventFilter) (*stream.Stream, error) {
+	err := validateFlowEventFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if sensor.flows == nil {
+		return nil, errors.New("flows are not tracked")
+	}
+	t := newFlowTable(sensor)
+	err = sensor.flows.add(t)
+	if err != nil {
+		glog.Warningf("Couldn't register flow events: %s", err)
+		return nil, err
+	}
+
+	ctrl := make(chan interface{})
+	data := make(chan interface{})
+
+	go func() {
+		defer close(data)
+		defer sensor.flows.remove(t)
+
+		ticker := time.NewTicker(time.Duration(filter.Interval))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case _, ok := <-ctrl:
+				if !ok {
+					return
+				}
+
+			case <-ticker.C:
+				for _, e := range t.summarize() {
+					select {
+					case data <- e:
+					case _, ok := <-ctrl:
+						if !ok {
+							return
+						}
+					}
+				}
+			}
+		}
+	}()
+
+	return &stream.Stream{
+		Ctrl: ctrl,
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 15301 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:A8CA 0200000A:01BB 01 00000000:00000000 00:00000000 00000000  1000        0 48211 1 0000000000000000 20 4 30 10 -1
+`
+
+const procNetTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 000080FE00000000FF000000010000FE:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 17624 1 0000000000000000 100 0 0 10 0
+`
+
+func TestParseProcNetSockets(t *testing.T) {
+	local, remote, ok := parseProcNetSockets([]byte(procNetTCP), 48211, false)
+	if !ok {
+		t.Fatal("Expected to find socket 48211")
+	}
+	if a := local.GetIpv4Address(); a == nil ||
+		a.Address.Address != 0x0100007f || a.Port != 0xcaa8 {
+		t.Errorf("Unexpected local address %+v", local)
+	}
+	if a := remote.GetIpv4Address(); a == nil ||
+		a.Address.Address != 0x0200000a || a.Port != 0xbb01 {
+		t.Errorf("Unexpected remote address %+v", remote)
+	}
+
+	local, _, ok = parseProcNetSockets([]byte(procNetTCP6), 17624, true)
+	if !ok {
+		t.Fatal("Expected to find socket 17624")
+	}
+	if a := local.GetIpv6Address(); a == nil ||
+		a.Address.High != 0x80fe ||
+		a.Address.Low != 0x010000feff000000 || a.Port != 0x1600 {
+		t.Errorf("Unexpected local address %+v", local)
+	}
+
+	if _, _, ok = parseProcNetSockets([]byte(procNetTCP), 1, false); ok {
+		t.Error("Expected not to find socket 1")
+	}
+}
+
+func newTestFlowTable(sensor *Sensor, links map[string]string) *flowTable {
+	return &flowTable{
+		sensor:  sensor,
+		sockets: make(map[flowSocket]*socketFlows),
+		readFile: func(name string) ([]byte, error) {
+			if name == "100/net/tcp" {
+				return []byte(procNetTCP), nil
+			}
+			return nil, errors.New("not found")
+		},
+		readlink: func(name string) (string, error) {
+			if link, ok := links[name]; ok {
+				return link, nil
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+// flowSendmsgSample returns an inet_sendmsg sample, sending to an IPv4
+// address if port is not 0.
+func flowSendmsgSample(sock uint64, port uint16) perf.TraceEventSampleData {
+	data := perf.TraceEventSampleData{
+		"common_pid":     int32(100),
+		"sock":           sock,
+		"msg_namelen":    int32(0),
+		"sa_family":      uint16(0),
+		"sin_port":       port,
+		"sin_addr":       uint32(0x0200000a),
+		"sin6_port":      port,
+		"sin6_addr_high": uint64(0),
+		"sin6_addr_low":  uint64(0),
+	}
+	if port != 0 {
+		data["msg_namelen"] = int32(16)
+		data["sa_family"] = uint16(afInet)
+	}
+	return data
+}
+
+func TestFlowTable(t *testing.T) {
+	links := map[string]string{
+		"100/fd/3": "socket:[48211]",
+		"100/fd/4": "socket:[15301]",
+	}
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+	}
+	ft := newTestFlowTable(s, links)
+	tracker := newFlowTracker(s)
+	tracker.tables[ft] = struct{}{}
+
+	sendEnter := tracker.makeSockEnterDecoder(true)
+	recvEnter := tracker.makeSockEnterDecoder(false)
+	enter := func(fd uint64) {
+		tracker.decodeSysEnter(&perf.SampleRecord{}, perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"fd":         fd,
+		})
+	}
+	exit := func() {
+		tracker.decodeSysExit(&perf.SampleRecord{}, perf.TraceEventSampleData{
+			"common_pid": int32(100),
+		})
+	}
+	message := func(sockEnter perf.TraceEventDecoderFn, sock uint64, port uint16, ret int32) {
+		sockEnter(&perf.SampleRecord{}, flowSendmsgSample(sock, port))
+		tracker.decodeSockReturn(&perf.SampleRecord{}, perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"ret":        ret,
+		})
+	}
+	call := func(sockEnter perf.TraceEventDecoderFn, fd, sock uint64, port uint16, ret ...int32) {
+		enter(fd)
+		for _, r := range ret {
+			message(sockEnter, sock, port, r)
+		}
+		exit()
+	}
+
+	// A write(2) and a sendmmsg(2) on a connected socket, and a
+	// read(2) that failed
+	call(sendEnter, 3, 0x1000, 0, 100)
+	call(sendEnter, 3, 0x1000, 0, 30, 20)
+	call(recvEnter, 3, 0x1000, 0, 1000)
+	call(recvEnter, 3, 0x1000, 0, -11)
+
+	// Datagrams sent to two addresses on a socket that isn't connected
+	call(sendEnter, 4, 0x2000, 53, 10)
+	call(sendEnter, 4, 0x2000, 53, 10)
+	call(sendEnter, 4, 0x2000, 123, 48)
+
+	// Sockets used outside of the traced system calls are ignored
+	message(sendEnter, 0x1000, 0, 10)
+
+	events := ft.summarize()
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 flow events, got %d", len(events))
+	}
+	for _, e := range events {
+		fev := e.GetFlow()
+		if fev.Type != api.FlowEventType_FLOW_EVENT_TYPE_UPDATE ||
+			e.ProcessPid != 100 {
+			t.Errorf("Unexpected flow event %+v", e)
+		}
+		switch fev.Sockfd {
+		case 3:
+			if fev.BytesSent != 150 || fev.MessagesSent != 3 ||
+				fev.BytesReceived != 1000 || fev.MessagesReceived != 1 ||
+				fev.Inode != 48211 ||
+				fev.RemoteAddress.GetIpv4Address().GetPort() != 0xbb01 {
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+		case 4:
+			a := fev.RemoteAddress.GetIpv4Address()
+			if fev.Inode != 15301 || a.GetAddress().GetAddress() != 0x0200000a {
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+			switch a.GetPort() {
+			case 53:
+				if fev.BytesSent != 20 || fev.MessagesSent != 2 {
+					t.Errorf("Unexpected flow event %+v", fev)
+				}
+			case 123:
+				if fev.BytesSent != 48 || fev.MessagesSent != 1 {
+					t.Errorf("Unexpected flow event %+v", fev)
+				}
+			default:
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+		default:
+			t.Errorf("Unexpected flow event %+v", fev)
+		}
+	}
+
+	// Idle flows aren't summarized, and the flows of closed sockets are
+	// summarized one last time
+	call(sendEnter, 3, 0x1000, 0, 1)
+	delete(links, "100/fd/3")
+	events = ft.summarize()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 flow event, got %d", len(events))
+	}
+	fev := events[0].GetFlow()
+	if fev.Type != api.FlowEventType_FLOW_EVENT_TYPE_CLOSE ||
+		fev.Sockfd != 3 || fev.BytesSent != 1 {
+		t.Errorf("Unexpected flow event %+v", fev)
+	}
+	if len(ft.sockets) != 1 {
+		t.Errorf("Expected 1 remaining socket, got %d", len(ft.sockets))
+	}
+
+	// A file descriptor reused for another socket starts new flows
+	call(sendEnter, 4, 0x2000, 53, 5)
+	call(sendEnter, 4, 0x3000, 0, 7)
+	links["100/fd/4"] = "socket:[15302]"
+	events = ft.summarize()
+	var closes, updates int
+	for _, e := range events {
+		fev := e.GetFlow()
+		switch fev.Type {
+		case api.FlowEventType_FLOW_EVENT_TYPE_CLOSE:
+			closes++
+			if fev.Inode != 15301 {
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+			if fev.RemoteAddress.GetIpv4Address().GetPort() == 53 &&
+				fev.BytesSent != 5 {
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+		case api.FlowEventType_FLOW_EVENT_TYPE_UPDATE:
+			updates++
+			if fev.Inode != 15302 || fev.BytesSent != 7 {
+				t.Errorf("Unexpected flow event %+v", fev)
+			}
+		}
+	}
+	if closes != 2 || updates != 1 {
+		t.Errorf("Expected 2 closed flows and 1 updated flow, got %d and %d",
+			closes, updates)
+	}
+
+	// Each subscription's table counts the data sent and received since
+	// it was added
+	other := newTestFlowTable(s, links)
+	tracker.tables[other] = struct{}{}
+	call(sendEnter, 4, 0x3000, 0, 9)
+	for _, table := range []*flowTable{ft, other} {
+		events = table.summarize()
+		if len(events) != 1 || events[0].GetFlow().BytesSent != 9 {
+			t.Errorf("Unexpected flow events %+v", events)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/golang/glog"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// GatewayService is a service that can be used with the ServiceManager to
+// serve the REST/JSON gateway to the telemetry service. Telemetry events are
+// streamed to HTTP clients as newline-delimited JSON.
+type GatewayService struct {
+	server *http.Server
+
+	address  string
+	endpoint string
+}
+
+// NewGatewayService creates a new GatewayService instance that serves HTTP
+// requests on the given address and proxies them to the telemetry service
+// listening on the given endpoint.
+func NewGatewayService(address, endpoint string) *GatewayService {
+	return &GatewayService{
+		server:   &http.Server{},
+		address:  address,
+		endpoint: endpoint,
+	}
+}
+
+// Name returns the human-readable name of the GatewayService.
+func (gs *GatewayService) Name() string {
+	return "REST/JSON Telemetry Gateway"
+}
+
+// gatewayDialer is a gRPC dialer that understands "unix:/path/to/sock" as
+// well as TCP addresses.
+func gatewayDialer(addr string, timeout time.Duration) (net.Conn, error) {
+	var network, address string
+
+	parts := strings.Split(addr, ":")
+	if len(parts) > 1 && parts[0] == "unix" {
+		network = "unix"
+		address = parts[1]
+	} else {
+		network = "tcp"
+		address = addr
+	}
+
+	return net.DialTimeout(network, address, timeout)
+}
+
+// loadGatewayTLSConfig loads the TLS configuration that the gateway uses to
+// connect to the telemetry service listening on the given endpoint. The
+// gateway presents its own client certificate and verifies the telemetry
+// service's certificate against the configured CA.
+func loadGatewayTLSConfig(endpoint string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(
+		config.Sensor.GatewayTLSClientCertPath,
+		config.Sensor.GatewayTLSClientKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load gateway key pair: %s", err)
+	}
+
+	certPool, err := loadCACertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	// gRPC verifies the server certificate against the host of a TCP
+	// endpoint, but a unix socket path is not a server name.
+	serverName := config.Sensor.GatewayTLSServerName
+	if len(serverName) == 0 && strings.HasPrefix(endpoint, "unix:") {
+		return nil, errors.New("gateway TLS server name must be set to connect to a unix socket")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      certPool,
+		ServerName:   serverName,
+	}, nil
+}
+
+// Serve is the main entrypoint for the GatewayService. It is normally called
+// by the ServiceManager. It will service requests indefinitely from the calling
+// Goroutine.
+func (gs *GatewayService) Serve() error {
+	glog.V(1).Info("Serving REST/JSON gateway on ", gs.address)
+
+	lis, err := listen(gs.address)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	opts := []grpc.DialOption{
+		grpc.WithDialer(gatewayDialer),
+	}
+
+	if config.Sensor.UseTLS {
+		glog.V(1).Infoln("Starting gateway with TLS credentials")
+
+		tlsConfig, err := loadTLSConfig()
+		if err != nil {
+			return err
+		}
+		clientConfig, err := loadGatewayTLSConfig(gs.endpoint)
+		if err != nil {
+			return err
+		}
+		lis = tls.NewListener(lis, tlsConfig)
+
+		creds := credentials.NewTLS(clientConfig)
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		glog.V(1).Infoln("Starting gateway")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard,
+			&runtime.JSONPb{OrigName: true}))
+	err = api.RegisterTelemetryServiceHandlerFromEndpoint(ctx, mux,
+		gs.endpoint, opts)
+	if err != nil {
+		return err
+	}
+
+	gs.server.Handler = mux
+	err = gs.server.Serve(lis)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	glog.Errorf("REST/JSON gateway error: %s", err)
+
+	return err
+}
+
+// Stop will stop a running GatewayService.
+func (gs *GatewayService) Stop() {
+	gs.server.Shutdown(context.Background())
+}
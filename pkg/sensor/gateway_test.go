@@ -0,0 +1,106 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"google.golang.org/grpc"
+)
+
+func TestGatewayService(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	api.RegisterTelemetryServiceServer(server, &benchmarkTelemetryServer{
+		events: 5,
+	})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	dir, err := ioutil.TempDir("", "gateway_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "gateway.sock")
+	gs := NewGatewayService("unix:"+socketPath, lis.Addr().String())
+	go gs.Serve()
+	defer gs.Stop()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(network, addr string) (net.Conn, error) {
+				return net.Dial("unix", socketPath)
+			},
+		},
+	}
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = client.Post("http://sensor/v0/events",
+			"application/json",
+			strings.NewReader(`{"subscription": {"max_batch_size": 2}}`))
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected HTTP status %s", resp.Status)
+	}
+
+	n := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line struct {
+			Result *struct {
+				Events []json.RawMessage `json:"events"`
+			} `json:"result"`
+		}
+		err = json.Unmarshal(scanner.Bytes(), &line)
+		if err != nil {
+			t.Fatalf("Invalid JSON %q: %s", scanner.Text(), err)
+		}
+		if line.Result == nil {
+			t.Fatalf("Unexpected response %q", scanner.Text())
+		}
+		n += len(line.Result.Events)
+	}
+
+	if n != 5 {
+		t.Errorf("Expected 5 events, got %d", n)
+	}
+}
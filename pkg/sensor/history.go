@@ -0,0 +1,424 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+)
+
+// eventHistory is a bounded, time-ordered record of recent telemetry events.
+// It is used to satisfy subscriptions that request events from the recent
+// past using since_duration. The kernel only reports events that some
+// subscription has asked for, so only events that were collected for a
+// subscription that was active at the time (or container events, which are
+// always collected) are recorded. A client that needs every event across a
+// reconnect should use reliable delivery instead.
+type eventHistory struct {
+	sync.Mutex
+
+	// Events ordered by SensorMonotimeNanos
+	events []*api.TelemetryEvent
+
+	// Used to record each kernel event only once
+	copies *eventCopies
+
+	maxLength int
+	maxAge    int64
+}
+
+func newEventHistory(maxLength int, maxAge time.Duration) *eventHistory {
+	return &eventHistory{
+		copies:    newEventCopies(),
+		maxLength: maxLength,
+		maxAge:    int64(maxAge),
+	}
+}
+
+func (h *eventHistory) enabled() bool {
+	return h.maxLength > 0
+}
+
+// isCopy returns true if an event decoded from a sample of the registered
+// event with the given id is a copy of a kernel event that has already been
+// recorded.
+func (h *eventHistory) isCopy(eventID uint64, e *api.TelemetryEvent) bool {
+	return h.copies.isCopy(eventID, e)
+}
+
+// add records an event in the history. Events recorded in the history must
+// not be modified afterward.
+func (h *eventHistory) add(e *api.TelemetryEvent) {
+	if !h.enabled() {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	// Events nearly always arrive in order, so search for the insertion
+	// point backward from the newest event. In the common case, the event
+	// is simply appended.
+	t := e.SensorMonotimeNanos
+	i := len(h.events)
+	for i > 0 && h.events[i-1].SensorMonotimeNanos > t {
+		i--
+	}
+
+	h.events = append(h.events, e)
+	if i < len(h.events)-1 {
+		copy(h.events[i+1:], h.events[i:])
+		h.events[i] = e
+	}
+
+	// Expire events that are too old or that exceed the maximum length
+	newest := h.events[len(h.events)-1].SensorMonotimeNanos
+	n := len(h.events) - h.maxLength
+	if n < 0 {
+		n = 0
+	}
+	for n < len(h.events) && newest-h.events[n].SensorMonotimeNanos > h.maxAge {
+		n++
+	}
+	if n > 0 {
+		for j := 0; j < n; j++ {
+			h.events[j] = nil
+		}
+		h.events = h.events[n:]
+	}
+}
+
+// between returns the recorded events that occurred at or after start and
+// before end, in the order that they occurred.
+func (h *eventHistory) between(start, end int64) []*api.TelemetryEvent {
+	h.Lock()
+	defer h.Unlock()
+
+	i := sort.Search(len(h.events), func(i int) bool {
+		return h.events[i].SensorMonotimeNanos >= start
+	})
+	j := sort.Search(len(h.events), func(i int) bool {
+		return h.events[i].SensorMonotimeNanos >= end
+	})
+	if i >= j {
+		return nil
+	}
+
+	events := make([]*api.TelemetryEvent, j-i)
+	copy(events, h.events[i:j])
+	return events
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// historyExpressionFilter is the set of filter expressions registered for a
+// single event type. A nil filter expression matches all events.
+type historyExpressionFilter struct {
+	wildcard bool
+	exprs    []*expression.Expression
+}
+
+func (f *historyExpressionFilter) add(expr *api.Expression) {
+	if expr == nil {
+		f.wildcard = true
+		return
+	}
+
+	e, err := expression.NewExpression(expr)
+	if err != nil {
+		glog.V(1).Infof("Invalid event history filter: %s", err)
+		return
+	}
+	f.exprs = append(f.exprs, e)
+}
+
+func (f *historyExpressionFilter) match(types expression.FieldTypeMap, values expression.FieldValueMap) bool {
+	if f.wildcard {
+		return true
+	}
+	for _, e := range f.exprs {
+		v, err := e.Evaluate(types, values)
+		if err == nil && expression.IsValueTrue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type historyKernelCallFilter struct {
+	arguments map[string]string
+	filter    historyExpressionFilter
+}
+
+// historyFilter applies the event filters from a subscription to events
+// recorded in the event history. Filter expressions are evaluated using the
+// same field names that are used when they're applied as kernel filters.
+type historyFilter struct {
+	file        map[api.FileEventType]*historyExpressionFilter
+	process     map[api.ProcessEventType]*historyExpressionFilter
+	syscall     map[api.SyscallEventType]*historyExpressionFilter
+	network     map[api.NetworkEventType]*historyExpressionFilter
+	credentials *historyExpressionFilter
+	signal      map[api.SignalEventType]*historyExpressionFilter
+	namespace   map[api.NamespaceEventType]*historyExpressionFilter
+	module      map[api.KernelModuleEventType]*historyExpressionFilter
+	access      map[api.ProcessAccessEventType]*historyExpressionFilter
+	memory      map[api.MemoryEventType]*historyExpressionFilter
+	kernel      []*historyKernelCallFilter
+	tracepoint  map[string]*historyExpressionFilter
+	container   containerEventFilterSet
+}
+
+func newHistoryFilter(ef *api.EventFilter) *historyFilter {
+	hf := &historyFilter{
+		file:    make(map[api.FileEventType]*historyExpressionFilter),
+		process: make(map[api.ProcessEventType]*historyExpressionFilter),
+		syscall: make(map[api.SyscallEventType]*historyExpressionFilter),
+		network: make(map[api.NetworkEventType]*historyExpressionFilter),
+		signal:  make(map[api.SignalEventType]*historyExpressionFilter),
+		namespace: make(
+			map[api.NamespaceEventType]*historyExpressionFilter),
+		module: make(
+			map[api.KernelModuleEventType]*historyExpressionFilter),
+		access: make(
+			map[api.ProcessAccessEventType]*historyExpressionFilter),
+		memory: make(map[api.MemoryEventType]*historyExpressionFilter),
+		tracepoint: make(
+			map[string]*historyExpressionFilter),
+	}
+
+	for _, fef := range ef.FileEvents {
+		rewriteFileEventFilter(fef)
+		f, ok := hf.file[fef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.file[fef.Type] = f
+		}
+		f.add(fef.FilterExpression)
+	}
+
+	for _, pef := range ef.ProcessEvents {
+		rewriteProcessEventFilter(pef)
+		f, ok := hf.process[pef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.process[pef.Type] = f
+		}
+		if pef.Type == api.ProcessEventType_PROCESS_EVENT_TYPE_FORK {
+			// Filter expressions are not applied to fork events
+			f.add(nil)
+		} else {
+			f.add(pef.FilterExpression)
+		}
+	}
+
+	for _, sef := range ef.SyscallEvents {
+		rewriteSyscallEventFilter(sef)
+		if !containsIDFilter(sef.FilterExpression) {
+			// No wildcard filters for now
+			continue
+		}
+		f, ok := hf.syscall[sef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.syscall[sef.Type] = f
+		}
+		f.add(sef.FilterExpression)
+	}
+
+	for _, nef := range ef.NetworkEvents {
+		f, ok := hf.network[nef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.network[nef.Type] = f
+		}
+		f.add(nef.FilterExpression)
+	}
+
+	for _, cef := range ef.CredentialsEvents {
+		if hf.credentials == nil {
+			hf.credentials = &historyExpressionFilter{}
+		}
+		hf.credentials.add(cef.FilterExpression)
+	}
+
+	for _, sef := range ef.SignalEvents {
+		f, ok := hf.signal[sef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.signal[sef.Type] = f
+		}
+		f.add(sef.FilterExpression)
+	}
+
+	for _, nef := range ef.NamespaceEvents {
+		f, ok := hf.namespace[nef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.namespace[nef.Type] = f
+		}
+		f.add(nef.FilterExpression)
+	}
+
+	for _, kef := range ef.KernelModuleEvents {
+		f, ok := hf.module[kef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.module[kef.Type] = f
+		}
+		f.add(kef.FilterExpression)
+	}
+
+	for _, pef := range ef.ProcessAccessEvents {
+		f, ok := hf.access[pef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.access[pef.Type] = f
+		}
+		f.add(pef.FilterExpression)
+	}
+
+	for _, mef := range ef.MemoryEvents {
+		f, ok := hf.memory[mef.Type]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.memory[mef.Type] = f
+		}
+		f.add(mef.FilterExpression)
+	}
+
+	for _, kef := range ef.KernelEvents {
+		f := &historyKernelCallFilter{
+			arguments: kef.Arguments,
+		}
+		f.filter.add(kef.FilterExpression)
+		hf.kernel = append(hf.kernel, f)
+	}
+
+	for _, tef := range ef.TracepointEvents {
+		f, ok := hf.tracepoint[tef.Name]
+		if !ok {
+			f = &historyExpressionFilter{}
+			hf.tracepoint[tef.Name] = f
+		}
+		f.add(tef.FilterExpression)
+	}
+
+	if len(ef.ContainerEvents) > 0 {
+		hf.container = newContainerEventFilterSet(ef.ContainerEvents)
+	}
+
+	return hf
+}
+
+// filter returns true if the event matches the event filters. Container
+// events that match may be modified according to the requested view, so
+// events taken from the history must be copied first.
+func (hf *historyFilter) filter(e *api.TelemetryEvent) bool {
+	switch ev := e.Event.(type) {
+	case *api.TelemetryEvent_File:
+		if f, ok := hf.file[ev.File.Type]; ok {
+			return f.match(fileEventTypes, fileEventValues(ev.File))
+		}
+
+	case *api.TelemetryEvent_Process:
+		if f, ok := hf.process[ev.Process.Type]; ok {
+			return f.match(processEventTypes, processEventValues(ev.Process))
+		}
+
+	case *api.TelemetryEvent_Syscall:
+		if f, ok := hf.syscall[ev.Syscall.Type]; ok {
+			return f.match(syscallEventTypes, syscallEventValues(ev.Syscall))
+		}
+
+	case *api.TelemetryEvent_Network:
+		if f, ok := hf.network[ev.Network.Type]; ok {
+			return f.match(networkEventFieldTypes(ev.Network.Type),
+				networkEventValues(ev.Network))
+		}
+
+	case *api.TelemetryEvent_Credentials:
+		if hf.credentials != nil {
+			return hf.credentials.match(credentialsEventTypes,
+				credentialsEventValues(ev.Credentials))
+		}
+
+	case *api.TelemetryEvent_Signal:
+		if f, ok := hf.signal[ev.Signal.Type]; ok {
+			return f.match(signalEventTypes[ev.Signal.Type],
+				signalEventValues(ev.Signal))
+		}
+
+	case *api.TelemetryEvent_Namespace:
+		if f, ok := hf.namespace[ev.Namespace.Type]; ok {
+			return f.match(namespaceEventTypes[ev.Namespace.Type],
+				namespaceEventValues(ev.Namespace))
+		}
+
+	case *api.TelemetryEvent_KernelModule:
+		if f, ok := hf.module[ev.KernelModule.Type]; ok {
+			return f.match(kernelModuleEventTypes[ev.KernelModule.Type],
+				kernelModuleEventValues(ev.KernelModule))
+		}
+
+	case *api.TelemetryEvent_ProcessAccess:
+		if f, ok := hf.access[ev.ProcessAccess.Type]; ok {
+			return f.match(processAccessEventTypes[ev.ProcessAccess.Type],
+				processAccessEventValues(ev.ProcessAccess))
+		}
+
+	case *api.TelemetryEvent_Memory:
+		if f, ok := hf.memory[ev.Memory.Type]; ok {
+			return f.match(memoryEventTypes[ev.Memory.Type],
+				memoryEventValues(ev.Memory))
+		}
+
+	case *api.TelemetryEvent_KernelCall:
+		// Kernel function call events do not identify the function
+		// that was called, so match on the fetched arguments instead.
+		types, values := kernelCallEventValues(ev.KernelCall)
+	kernelFilters:
+		for _, f := range hf.kernel {
+			for name := range f.arguments {
+				if _, ok := ev.KernelCall.Arguments[name]; !ok {
+					continue kernelFilters
+				}
+			}
+			if f.filter.match(types, values) {
+				return true
+			}
+		}
+
+	case *api.TelemetryEvent_Tracepoint:
+		if f, ok := hf.tracepoint[ev.Tracepoint.Name]; ok {
+			types, values := tracepointEventValues(ev.Tracepoint)
+			return f.match(types, values)
+		}
+
+	case *api.TelemetryEvent_Container:
+		if hf.container != nil {
+			return hf.container.filter(e)
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func newHistoryTestEvent(monotime int64, pid int32, filename string) *api.TelemetryEvent {
+	return &api.TelemetryEvent{
+		SensorMonotimeNanos: monotime,
+		ProcessPid:          pid,
+		Event: &api.TelemetryEvent_File{
+			File: &api.FileEvent{
+				Type:     api.FileEventType_FILE_EVENT_TYPE_OPEN,
+				Filename: filename,
+			},
+		},
+	}
+}
+
+func TestEventHistoryOrder(t *testing.T) {
+	h := newEventHistory(10, time.Minute)
+
+	h.add(newHistoryTestEvent(1000000, 1, "/a"))
+	h.add(newHistoryTestEvent(3000000, 1, "/c"))
+	h.add(newHistoryTestEvent(2000000, 1, "/b"))
+
+	events := h.between(0, 4000000)
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	for i, name := range []string{"/a", "/b", "/c"} {
+		if events[i].GetFile().Filename != name {
+			t.Errorf("Expected event %d to be %s, got %s",
+				i, name, events[i].GetFile().Filename)
+		}
+	}
+
+	events = h.between(2000000, 3000000)
+	if len(events) != 1 || events[0].GetFile().Filename != "/b" {
+		t.Errorf("Expected only /b, got %v", events)
+	}
+}
+
+func TestEventHistoryLimits(t *testing.T) {
+	h := newEventHistory(2, time.Millisecond)
+
+	h.add(newHistoryTestEvent(1000000, 1, "/a"))
+	h.add(newHistoryTestEvent(1500000, 1, "/b"))
+	h.add(newHistoryTestEvent(1600000, 1, "/c"))
+
+	events := h.between(0, 10000000)
+	if len(events) != 2 || events[0].GetFile().Filename != "/b" {
+		t.Errorf("Expected /b and /c, got %v", events)
+	}
+
+	h.add(newHistoryTestEvent(2700000, 1, "/d"))
+
+	events = h.between(0, 10000000)
+	if len(events) != 1 || events[0].GetFile().Filename != "/d" {
+		t.Errorf("Expected only /d, got %v", events)
+	}
+
+	h = newEventHistory(0, time.Minute)
+	h.add(newHistoryTestEvent(1000000, 1, "/a"))
+	if events = h.between(0, 10000000); len(events) != 0 {
+		t.Errorf("Expected disabled history to be empty, got %v", events)
+	}
+}
+
+func TestHistoryFilter(t *testing.T) {
+	hf := newHistoryFilter(&api.EventFilter{
+		FileEvents: []*api.FileEventFilter{
+			&api.FileEventFilter{
+				Type: api.FileEventType_FILE_EVENT_TYPE_OPEN,
+				FilterExpression: expression.Like(
+					expression.Identifier("filename"),
+					expression.Value("/etc/*")),
+			},
+		},
+	})
+
+	if !hf.filter(newHistoryTestEvent(0, 1, "/etc/passwd")) {
+		t.Error("Expected /etc/passwd to match")
+	}
+	if hf.filter(newHistoryTestEvent(0, 1, "/tmp/passwd")) {
+		t.Error("Unexpected match for /tmp/passwd")
+	}
+	if hf.filter(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Process{
+			Process: &api.ProcessEvent{
+				Type: api.ProcessEventType_PROCESS_EVENT_TYPE_FORK,
+			},
+		},
+	}) {
+		t.Error("Unexpected match for process event")
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+	"github.com/capsule8/capsule8/pkg/version"
+
+	"github.com/golang/glog"
+
+	"golang.org/x/sys/unix"
+)
+
+// probeFailures records the kernel probes and tracepoints that a sensor could
+// not register, so that clients can tell which events may be unavailable.
+type probeFailures struct {
+	sync.Mutex
+	failures map[string]string
+}
+
+func (pf *probeFailures) add(name string, err error) {
+	pf.Lock()
+	if pf.failures == nil {
+		pf.failures = make(map[string]string)
+	}
+	pf.failures[name] = err.Error()
+	pf.Unlock()
+}
+
+func (pf *probeFailures) remove(names ...string) {
+	pf.Lock()
+	for _, name := range names {
+		delete(pf.failures, name)
+	}
+	pf.Unlock()
+}
+
+func (pf *probeFailures) getMap() map[string]string {
+	pf.Lock()
+	defer pf.Unlock()
+
+	failures := make(map[string]string, len(pf.failures))
+	for k, v := range pf.failures {
+		failures[k] = v
+	}
+	return failures
+}
+
+// activeSubscriptions records the subscriptions that are currently active.
+type activeSubscriptions struct {
+	sync.Mutex
+	nextID        uint64
+	subscriptions map[uint64]*api.Subscription
+}
+
+// add records an active subscription. It returns an ID used to remove it
+// later.
+func (as *activeSubscriptions) add(sub *api.Subscription) uint64 {
+	as.Lock()
+	defer as.Unlock()
+
+	if as.subscriptions == nil {
+		as.subscriptions = make(map[uint64]*api.Subscription)
+	}
+	id := as.nextID
+	as.nextID++
+	as.subscriptions[id] = sub
+
+	return id
+}
+
+func (as *activeSubscriptions) remove(id uint64) {
+	as.Lock()
+	delete(as.subscriptions, id)
+	as.Unlock()
+}
+
+// update replaces an active subscription after it has been modified.
+func (as *activeSubscriptions) update(id uint64, sub *api.Subscription) {
+	as.Lock()
+	if _, ok := as.subscriptions[id]; ok {
+		as.subscriptions[id] = sub
+	}
+	as.Unlock()
+}
+
+func (as *activeSubscriptions) getList() []*api.Subscription {
+	as.Lock()
+	defer as.Unlock()
+
+	subs := make([]*api.Subscription, 0, len(as.subscriptions))
+	for _, sub := range as.subscriptions {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// probeFailed records that a kernel probe or tracepoint could not be
+// registered.
+func (s *Sensor) probeFailed(name string, err error) {
+	s.probeFailures.add(name, err)
+}
+
+// probeSucceeded clears the failures recorded for kernel probes or
+// tracepoints that have since been registered, e.g. because a kernel module
+// providing them has been loaded.
+func (s *Sensor) probeSucceeded(names ...string) {
+	s.probeFailures.remove(names...)
+}
+
+// registerTracepoint registers a tracepoint with the sensor's EventMonitor,
+// clearing any failure previously recorded for it.
+func (s *Sensor) registerTracepoint(name string, fn perf.TraceEventDecoderFn,
+	options ...perf.RegisterEventOption) (uint64, error) {
+
+	eventID, err := s.monitor.RegisterTracepoint(name, fn, options...)
+	if err == nil {
+		s.probeSucceeded(name)
+	}
+	return eventID, err
+}
+
+// registerKprobe registers a kprobe with the sensor's EventMonitor, clearing
+// any failure previously recorded for it.
+func (s *Sensor) registerKprobe(address string, onReturn bool, output string,
+	fn perf.TraceEventDecoderFn, options ...perf.RegisterEventOption) (uint64, error) {
+
+	eventID, err := s.monitor.RegisterKprobe(address, onReturn, output, fn,
+		options...)
+	if err == nil {
+		s.probeSucceeded(address)
+	}
+	return eventID, err
+}
+
+func kernelRelease() string {
+	var uts unix.Utsname
+	err := unix.Uname(&uts)
+	if err != nil {
+		glog.Warningf("Couldn't get kernel release: %s", err)
+		return ""
+	}
+
+	var release []byte
+	for _, c := range uts.Release {
+		if c == 0 {
+			break
+		}
+		release = append(release, byte(c))
+	}
+	return string(release)
+}
+
+// kernelVersion is the major and minor version of a Linux kernel release.
+type kernelVersion struct {
+	major int
+	minor int
+}
+
+func (v kernelVersion) isZero() bool {
+	return v.major == 0 && v.minor == 0
+}
+
+func (v kernelVersion) before(other kernelVersion) bool {
+	return v.major < other.major ||
+		(v.major == other.major && v.minor < other.minor)
+}
+
+// parseKernelVersion returns the version of a kernel release, such as
+// "5.15.0-91-generic". The zero version is returned if the release can't be
+// parsed.
+func parseKernelVersion(release string) kernelVersion {
+	var v kernelVersion
+	if n, _ := fmt.Sscanf(release, "%d.%d", &v.major, &v.minor); n != 2 {
+		return kernelVersion{}
+	}
+	return v
+}
+
+var (
+	kernelVersionOnce    sync.Once
+	kernelVersionRunning kernelVersion
+)
+
+// runningKernelVersion returns the version of the running kernel, or the
+// zero version if it is unknown.
+func runningKernelVersion() kernelVersion {
+	kernelVersionOnce.Do(func() {
+		kernelVersionRunning = parseKernelVersion(kernelRelease())
+	})
+	return kernelVersionRunning
+}
+
+// tracingDir returns the tracefs mount used by the sensor's EventMonitor.
+func (s *Sensor) tracingDir() string {
+	if len(s.traceFSMountPoint) > 0 {
+		return s.traceFSMountPoint
+	}
+	return sys.TracingDir()
+}
+
+// Info returns the identity, capabilities, and health of the sensor.
+func (s *Sensor) Info() *api.GetSensorInfoResponse {
+	hostname, err := os.Hostname()
+	if err != nil {
+		glog.Warningf("Couldn't get hostname: %s", err)
+	}
+
+	perfEventMount := s.perfEventMountPoint
+	if len(perfEventMount) == 0 {
+		perfEventMount = sys.PerfEventDir()
+	}
+
+	return &api.GetSensorInfoResponse{
+		SensorId:            s.ID,
+		Version:             version.Version,
+		Build:               version.Build,
+		Hostname:            hostname,
+		KernelRelease:       kernelRelease(),
+		BootId:              proc.BootID(),
+		TracefsMount:        s.tracingDir(),
+		PerfEventMount:      perfEventMount,
+		UnavailableProbes:   s.probeFailures.getMap(),
+		Events:              atomic.LoadUint64(&s.Metrics.Events),
+		Subscriptions:       atomic.LoadInt32(&s.Metrics.Subscriptions),
+		ActiveSubscriptions: s.activeSubscriptions.getList(),
+	}
+}
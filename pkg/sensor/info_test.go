@@ -0,0 +1,86 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestProbeFailures(t *testing.T) {
+	var pf probeFailures
+
+	if m := pf.getMap(); len(m) != 0 {
+		t.Fatalf("Expected no failures, got %v", m)
+	}
+
+	pf.add("syscalls/sys_exit_bind", errors.New("no such file"))
+	m := pf.getMap()
+	if m["syscalls/sys_exit_bind"] != "no such file" {
+		t.Errorf("Unexpected failures %v", m)
+	}
+
+	// The returned map must not alias the internal state
+	m["foo"] = "bar"
+	if len(pf.getMap()) != 1 {
+		t.Errorf("Expected 1 failure, got %v", pf.getMap())
+	}
+
+	// Failures are cleared when the probe is later registered
+	pf.remove("syscalls/sys_exit_bind")
+	if m = pf.getMap(); len(m) != 0 {
+		t.Errorf("Expected no failures, got %v", m)
+	}
+}
+
+func TestActiveSubscriptions(t *testing.T) {
+	var as activeSubscriptions
+
+	a := as.add(&api.Subscription{ProcessLineageDepth: 1})
+	b := as.add(&api.Subscription{ProcessLineageDepth: 2})
+	if a == b {
+		t.Fatalf("Expected distinct IDs, got %d", a)
+	}
+	if n := len(as.getList()); n != 2 {
+		t.Fatalf("Expected 2 subscriptions, got %d", n)
+	}
+
+	as.remove(a)
+	subs := as.getList()
+	if len(subs) != 1 || subs[0].ProcessLineageDepth != 2 {
+		t.Errorf("Unexpected subscriptions %v", subs)
+	}
+}
+
+func TestParseKernelVersion(t *testing.T) {
+	releases := map[string]kernelVersion{
+		"5.15.0-91-generic": {5, 15},
+		"4.9.0":             {4, 9},
+		"3.10":              {3, 10},
+		"unknown":           {},
+	}
+	for release, expected := range releases {
+		if v := parseKernelVersion(release); v != expected {
+			t.Errorf("Expected %v for %q, got %v", expected, release, v)
+		}
+	}
+
+	if !(kernelVersion{4, 17}).before(kernelVersion{5, 0}) ||
+		(kernelVersion{5, 11}).before(kernelVersion{5, 11}) {
+		t.Error("Unexpected kernel version ordering")
+	}
+}
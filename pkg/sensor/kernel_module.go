@@ -0,0 +1,167 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	moduleLoadTracepoint = "module/module_load"
+	moduleFreeTracepoint = "module/module_free"
+)
+
+// The fields of each kernel module tracepoint that may be used in filters
+var kernelModuleEventTypes = map[api.KernelModuleEventType]expression.FieldTypeMap{
+	api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD: expression.FieldTypeMap{
+		"name":   int32(api.ValueType_STRING),
+		"taints": int32(api.ValueType_UINT32),
+	},
+	api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD: expression.FieldTypeMap{
+		"name": int32(api.ValueType_STRING),
+	},
+}
+
+var kernelModuleEventTracepoints = map[api.KernelModuleEventType]string{
+	api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD:   moduleLoadTracepoint,
+	api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD: moduleFreeTracepoint,
+}
+
+func kernelModuleEventValues(kev *api.KernelModuleEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"name": kev.Name,
+	}
+	if kev.Type == api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD {
+		values["taints"] = kev.Taints
+	}
+	return values
+}
+
+type kernelModuleFilter struct {
+	sensor *Sensor
+}
+
+func (f *kernelModuleFilter) decodeModuleLoad(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+	ev.Event = &api.TelemetryEvent_KernelModule{
+		KernelModule: &api.KernelModuleEvent{
+			Type:   api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+			Name:   data["name"].(string),
+			Taints: data["taints"].(uint32),
+		},
+	}
+
+	return ev, nil
+}
+
+func (f *kernelModuleFilter) decodeModuleFree(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+	ev.Event = &api.TelemetryEvent_KernelModule{
+		KernelModule: &api.KernelModuleEvent{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+			Name: data["name"].(string),
+		},
+	}
+
+	return ev, nil
+}
+
+// kernelModuleEventFilterString returns the kernel filter string for a kernel
+// module event filter, or an error if the filter is invalid. An empty string
+// is returned for filters that match all events.
+func kernelModuleEventFilterString(kef *api.KernelModuleEventFilter) (string, error) {
+	types, ok := kernelModuleEventTypes[kef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported kernel module event type %s",
+			kef.Type)
+	}
+
+	if kef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(kef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// kernelModuleEventProbes returns the names of the tracepoints that kernel
+// module events of the given type are collected from.
+func kernelModuleEventProbes(t api.KernelModuleEventType) []string {
+	if name, ok := kernelModuleEventTracepoints[t]; ok {
+		return []string{name}
+	}
+	return nil
+}
+
+func registerKernelModuleEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.KernelModuleEventFilter) {
+	filters := make(map[api.KernelModuleEventType]map[string]int)
+	for _, kef := range events {
+		s, err := kernelModuleEventFilterString(kef)
+		if err != nil {
+			glog.V(1).Infof("Invalid kernel module event filter: %s", err)
+			continue
+		}
+		if filters[kef.Type] == nil {
+			filters[kef.Type] = make(map[string]int)
+		}
+		filters[kef.Type][s]++
+	}
+
+	f := kernelModuleFilter{
+		sensor: sensor,
+	}
+
+	decoders := map[api.KernelModuleEventType]perf.TraceEventDecoderFn{
+		api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD:   f.decodeModuleLoad,
+		api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD: f.decodeModuleFree,
+	}
+
+	for t, m := range filters {
+		filterString, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+
+		eventName := kernelModuleEventTracepoints[t]
+		eventID, err := sensor.registerTracepoint(eventName,
+			decoders[t], perf.WithFilter(filterString))
+		if err != nil {
+			glog.V(1).Infof("Couldn't get %s event id: %v",
+				eventName, err)
+			sensor.probeFailed(eventName, err)
+			continue
+		}
+
+		eventMap[eventID] = &subscription{}
+	}
+}
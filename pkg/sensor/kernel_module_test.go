@@ -0,0 +1,94 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestKernelModuleEvents(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+	}
+	f := kernelModuleFilter{
+		sensor: s,
+	}
+
+	i, err := f.decodeModuleLoad(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"name":       "rootkit",
+			"taints":     uint32(1<<12 | 1<<13),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kev := i.(*api.TelemetryEvent).GetKernelModule()
+	if kev == nil ||
+		kev.Type != api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD ||
+		kev.Name != "rootkit" || kev.Taints != 1<<12|1<<13 {
+		t.Errorf("Unexpected kernel module load event %+v", kev)
+	}
+
+	i, err = f.decodeModuleFree(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"name":       "rootkit",
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kev = i.(*api.TelemetryEvent).GetKernelModule()
+	if kev == nil ||
+		kev.Type != api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD ||
+		kev.Name != "rootkit" {
+		t.Errorf("Unexpected kernel module unload event %+v", kev)
+	}
+}
+
+func TestKernelModuleEventFilterString(t *testing.T) {
+	s, err := kernelModuleEventFilterString(&api.KernelModuleEventFilter{
+		Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+		FilterExpression: expression.NotEqual(
+			expression.Identifier("taints"),
+			expression.Value(uint32(0))),
+	})
+	if err != nil || s != "taints != 0" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+
+	// Taints are only reported for load events
+	_, err = kernelModuleEventFilterString(&api.KernelModuleEventFilter{
+		Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+		FilterExpression: expression.NotEqual(
+			expression.Identifier("taints"),
+			expression.Value(uint32(0))),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+
+	_, err = kernelModuleEventFilterString(&api.KernelModuleEventFilter{})
+	if err == nil {
+		t.Error("Expected error for unknown event type")
+	}
+}
@@ -37,11 +37,11 @@ type kprobeFilter struct {
 
 var validSymbolRegex = regexp.MustCompile("^[A-Za-z_]{1}[\\w]*$")
 
-func newKprobeFilter(kef *api.KernelFunctionCallFilter) *kprobeFilter {
+func newKprobeFilter(kef *api.KernelFunctionCallFilter) (*kprobeFilter, error) {
 	// The symbol must begin with [A-Za-z_] and contain only [A-Za-z0-9_]
 	// We do not accept addresses or offsets
 	if !validSymbolRegex.MatchString(kef.Symbol) {
-		return nil
+		return nil, fmt.Errorf("invalid kprobe symbol %q", kef.Symbol)
 	}
 
 	var filterString string
@@ -49,8 +49,11 @@ func newKprobeFilter(kef *api.KernelFunctionCallFilter) *kprobeFilter {
 	if kef.FilterExpression != nil {
 		expr, err := expression.NewExpression(kef.FilterExpression)
 		if err != nil {
-			glog.V(1).Infof("Bad kprobe filter expression: %s", err)
-			return nil
+			return nil, err
+		}
+		err = expr.ValidateKernelFilter()
+		if err != nil {
+			return nil, fmt.Errorf("invalid kernel filter: %s", err)
 		}
 
 		filterString = expr.KernelFilterString()
@@ -68,14 +71,29 @@ func newKprobeFilter(kef *api.KernelFunctionCallFilter) *kprobeFilter {
 	case api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_EXIT:
 		filter.onReturn = true
 	default:
-		return nil
+		return nil, fmt.Errorf("unsupported kernel function call event type %s",
+			kef.Type)
 	}
 
-	return filter
+	return filter, nil
 }
 
 func (f *kprobeFilter) decodeKprobe(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
-	args := make(map[string]*api.KernelFunctionCallEvent_FieldValue)
+	ev := f.sensor.NewEventFromSample(sample, data)
+	ev.Event = &api.TelemetryEvent_KernelCall{
+		KernelCall: &api.KernelFunctionCallEvent{
+			Arguments: sampleFieldValues(data),
+		},
+	}
+
+	return ev, nil
+}
+
+// sampleFieldValues converts the fields of a trace event sample to typed
+// field values. Arrays of bytes are converted to BYTES values, and fields of
+// any other type that can't be represented are left out.
+func sampleFieldValues(data perf.TraceEventSampleData) map[string]*api.KernelFunctionCallEvent_FieldValue {
+	args := make(map[string]*api.KernelFunctionCallEvent_FieldValue, len(data))
 	for k, v := range data {
 		value := &api.KernelFunctionCallEvent_FieldValue{}
 		switch v := v.(type) {
@@ -109,18 +127,83 @@ func (f *kprobeFilter) decodeKprobe(sample *perf.SampleRecord, data perf.TraceEv
 		case uint64:
 			value.FieldType = api.KernelFunctionCallEvent_UINT64
 			value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: v}
+		case []interface{}:
+			b, ok := sampleArrayBytes(v)
+			if !ok {
+				continue
+			}
+			value.FieldType = api.KernelFunctionCallEvent_BYTES
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_BytesValue{BytesValue: b}
+		default:
+			continue
 		}
 		args[k] = value
 	}
 
-	ev := f.sensor.NewEventFromSample(sample, data)
-	ev.Event = &api.TelemetryEvent_KernelCall{
-		KernelCall: &api.KernelFunctionCallEvent{
-			Arguments: args,
-		},
+	return args
+}
+
+// sampleArrayBytes converts an array of 8-bit integers decoded from a trace
+// event sample to bytes.
+func sampleArrayBytes(array []interface{}) ([]byte, bool) {
+	b := make([]byte, len(array))
+	for i, v := range array {
+		switch v := v.(type) {
+		case uint8:
+			b[i] = v
+		case int8:
+			b[i] = byte(v)
+		default:
+			return nil, false
+		}
 	}
+	return b, true
+}
 
-	return ev, nil
+func kernelCallEventValues(kev *api.KernelFunctionCallEvent) (expression.FieldTypeMap, expression.FieldValueMap) {
+	return fieldValueMapValues(kev.Arguments)
+}
+
+// fieldValueMapValues returns the types and values of typed field values
+// for evaluating filter expressions. BYTES values can't be used in
+// expressions, so they are left out.
+func fieldValueMapValues(fields map[string]*api.KernelFunctionCallEvent_FieldValue) (expression.FieldTypeMap, expression.FieldValueMap) {
+	types := make(expression.FieldTypeMap, len(fields))
+	values := make(expression.FieldValueMap, len(fields))
+
+	for k, v := range fields {
+		switch v.FieldType {
+		case api.KernelFunctionCallEvent_STRING:
+			types[k] = int32(api.ValueType_STRING)
+			values[k] = v.GetStringValue()
+		case api.KernelFunctionCallEvent_SINT8:
+			types[k] = int32(api.ValueType_SINT8)
+			values[k] = int8(v.GetSignedValue())
+		case api.KernelFunctionCallEvent_SINT16:
+			types[k] = int32(api.ValueType_SINT16)
+			values[k] = int16(v.GetSignedValue())
+		case api.KernelFunctionCallEvent_SINT32:
+			types[k] = int32(api.ValueType_SINT32)
+			values[k] = int32(v.GetSignedValue())
+		case api.KernelFunctionCallEvent_SINT64:
+			types[k] = int32(api.ValueType_SINT64)
+			values[k] = v.GetSignedValue()
+		case api.KernelFunctionCallEvent_UINT8:
+			types[k] = int32(api.ValueType_UINT8)
+			values[k] = uint8(v.GetUnsignedValue())
+		case api.KernelFunctionCallEvent_UINT16:
+			types[k] = int32(api.ValueType_UINT16)
+			values[k] = uint16(v.GetUnsignedValue())
+		case api.KernelFunctionCallEvent_UINT32:
+			types[k] = int32(api.ValueType_UINT32)
+			values[k] = uint32(v.GetUnsignedValue())
+		case api.KernelFunctionCallEvent_UINT64:
+			types[k] = int32(api.ValueType_UINT64)
+			values[k] = v.GetUnsignedValue()
+		}
+	}
+
+	return types, values
 }
 
 func (f *kprobeFilter) fetchargs() string {
@@ -134,14 +217,14 @@ func (f *kprobeFilter) fetchargs() string {
 
 func registerKernelEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.KernelFunctionCallFilter) {
 	for _, kef := range events {
-		f := newKprobeFilter(kef)
-		if f == nil {
-			glog.V(1).Infof("Invalid kprobe symbol: %s", kef.Symbol)
+		f, err := newKprobeFilter(kef)
+		if err != nil {
+			glog.V(1).Infof("Invalid kernel function call filter: %s", err)
 			continue
 		}
 
 		f.sensor = sensor
-		eventID, err := sensor.monitor.RegisterKprobe(
+		eventID, err := sensor.registerKprobe(
 			f.symbol, f.onReturn, f.fetchargs(),
 			f.decodeKprobe,
 			perf.WithFilter(f.filter))
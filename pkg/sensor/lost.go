@@ -0,0 +1,58 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func (s *Sensor) newLostEvent(ls *perf.LostSamples) *api.TelemetryEvent {
+	e := s.NewEvent()
+	e.SensorMonotimeNanos = int64(ls.EndTime) - s.bootMonotimeNanos
+	e.Cpu = int32(ls.CPU)
+
+	lost := &api.LostEvent{
+		Type:             api.LostEventType_LOST_EVENT_TYPE_RING_BUFFER,
+		Lost:             ls.Lost,
+		EndMonotimeNanos: e.SensorMonotimeNanos,
+	}
+	if ls.StartTime != 0 {
+		lost.StartMonotimeNanos = int64(ls.StartTime) - s.bootMonotimeNanos
+	}
+	e.Event = &api.TelemetryEvent_Lost{
+		Lost: lost,
+	}
+
+	return e
+}
+
+// dispatchLostSamples sends a lost event to every subscription with events
+// registered with the sensor's EventMonitor. All of those events share the
+// same ring buffers, so any of them may have been lost.
+func (s *Sensor) dispatchLostSamples(ls *perf.LostSamples) {
+	e := s.newLostEvent(ls)
+
+	// Each subscription may have several events, but they all share the
+	// subscription's data channel.
+	sent := make(map[chan interface{}]bool)
+	for _, sub := range s.eventMap.getMap() {
+		if sub != nil && sub.data != nil && !sent[sub.data] {
+			sent[sub.data] = true
+			sub.data <- e
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestDispatchLostSamples(t *testing.T) {
+	s := &Sensor{
+		bootMonotimeNanos: 1000,
+		eventMap:          newSafeSubscriptionMap(),
+	}
+
+	// Two events for one subscription and one for another
+	data1 := make(chan interface{}, 4)
+	data2 := make(chan interface{}, 4)
+	s.eventMap.update(subscriptionMap{
+		1: &subscription{data: data1},
+		2: &subscription{data: data1},
+		3: &subscription{data: data2},
+	})
+
+	s.dispatchSample(0, &perf.LostSamples{
+		CPU:       3,
+		Lost:      42,
+		StartTime: 5000,
+		EndTime:   9000,
+	}, nil)
+
+	for _, data := range []chan interface{}{data1, data2} {
+		if len(data) != 1 {
+			t.Fatalf("Expected 1 lost event, got %d", len(data))
+		}
+		e := (<-data).(*api.TelemetryEvent)
+		lost := e.GetLost()
+		if lost == nil {
+			t.Fatalf("Expected lost event, got %+v", e)
+		}
+		if lost.Lost != 42 || e.Cpu != 3 ||
+			lost.StartMonotimeNanos != 4000 ||
+			lost.EndMonotimeNanos != 8000 ||
+			e.SensorMonotimeNanos != 8000 {
+			t.Errorf("Unexpected lost event %+v", e)
+		}
+	}
+}
+
+func TestFilterLost(t *testing.T) {
+	s := &Sensor{}
+	ms := &ModifiableSubscription{}
+
+	e1 := s.newLostEvent(&perf.LostSamples{Lost: 1})
+	e2 := s.newLostEvent(&perf.LostSamples{Lost: 2})
+
+	expected := []struct {
+		e    *api.TelemetryEvent
+		pass bool
+	}{
+		{e1, true},
+		{e1, false},
+		{e2, true},
+		{s.NewEvent(), true},
+		{e1, false},
+		{e2, false},
+	}
+	for i, x := range expected {
+		if ms.filterLost(x.e) != x.pass {
+			t.Errorf("Expected filterLost %v for event %d", x.pass, i)
+		}
+	}
+}
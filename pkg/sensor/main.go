@@ -40,6 +40,19 @@ func Main() {
 		defer sensor.Stop()
 		service := NewTelemetryService(sensor, config.Sensor.ListenAddr)
 		manager.RegisterService(service)
+
+		if len(config.Sensor.GatewayListenAddr) > 0 {
+			gateway := NewGatewayService(
+				config.Sensor.GatewayListenAddr,
+				config.Sensor.ListenAddr)
+			manager.RegisterService(gateway)
+		}
+
+		if len(config.Sensor.MetricsListenAddr) > 0 {
+			metrics := NewMetricsService(sensor,
+				config.Sensor.MetricsListenAddr)
+			manager.RegisterService(metrics)
+		}
 	}
 
 	manager.Run()
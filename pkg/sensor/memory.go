@@ -0,0 +1,397 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	mmapTracepoint     = "syscalls/sys_enter_mmap"
+	mprotectTracepoint = "syscalls/sys_enter_mprotect"
+
+	mapAnonymous = 0x20
+
+	// Only calls requesting PROT_EXEC are reported
+	protExecFilter = "prot & 4"
+
+	// How long the mappings read for a process are used before they are
+	// read again
+	memoryMappingCacheInterval = time.Second
+
+	// The maximum number of processes whose mappings are cached before
+	// the cache is reset
+	memoryMappingCacheSize = 1024
+
+	// The maximum number of processes waiting for their mappings to be
+	// read. Lookups for other processes are dropped until there is room.
+	memoryMappingQueueLength = 64
+)
+
+// The fields of each memory tracepoint that may be used in filters
+var memoryEventTypes = map[api.MemoryEventType]expression.FieldTypeMap{
+	api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP: expression.FieldTypeMap{
+		"addr":  int32(api.ValueType_UINT64),
+		"len":   int32(api.ValueType_UINT64),
+		"prot":  int32(api.ValueType_UINT64),
+		"flags": int32(api.ValueType_UINT64),
+		"fd":    int32(api.ValueType_UINT64),
+		"off":   int32(api.ValueType_UINT64),
+	},
+	api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT: expression.FieldTypeMap{
+		"start": int32(api.ValueType_UINT64),
+		"len":   int32(api.ValueType_UINT64),
+		"prot":  int32(api.ValueType_UINT64),
+	},
+}
+
+var memoryEventTracepoints = map[api.MemoryEventType]string{
+	api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP:     mmapTracepoint,
+	api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT: mprotectTracepoint,
+}
+
+func memoryEventValues(mev *api.MemoryEvent) expression.FieldValueMap {
+	switch mev.Type {
+	case api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP:
+		return expression.FieldValueMap{
+			"addr":  mev.Addr,
+			"len":   mev.Length,
+			"prot":  mev.Prot,
+			"flags": mev.Flags,
+			"fd":    uint64(mev.Fd),
+			"off":   mev.Offset,
+		}
+	case api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT:
+		return expression.FieldValueMap{
+			"start": mev.Addr,
+			"len":   mev.Length,
+			"prot":  mev.Prot,
+		}
+	}
+	return expression.FieldValueMap{}
+}
+
+type memoryMapping struct {
+	start    uint64
+	end      uint64
+	filename string
+}
+
+// parseMemoryMappings returns the mappings listed in the contents of a
+// /proc/PID/maps file.
+func parseMemoryMappings(maps []byte) []memoryMapping {
+	var mappings []memoryMapping
+
+	scanner := bufio.NewScanner(bytes.NewReader(maps))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		bounds := strings.SplitN(fields[0], "-", 2)
+		if len(bounds) != 2 {
+			continue
+		}
+		start, err := strconv.ParseUint(bounds[0], 16, 64)
+		if err != nil {
+			continue
+		}
+		end, err := strconv.ParseUint(bounds[1], 16, 64)
+		if err != nil {
+			continue
+		}
+		mappings = append(mappings, memoryMapping{
+			start:    start,
+			end:      end,
+			filename: strings.Join(fields[5:], " "),
+		})
+	}
+	return mappings
+}
+
+// findMappedFilename returns the pathname of the mapping containing addr.
+func findMappedFilename(mappings []memoryMapping, addr uint64) (string, bool) {
+	for _, m := range mappings {
+		if addr >= m.start && addr < m.end {
+			return m.filename, true
+		}
+	}
+	return "", false
+}
+
+// parseMappedFilename returns the pathname of the mapping containing addr
+// from the contents of a /proc/PID/maps file.
+func parseMappedFilename(maps []byte, addr uint64) string {
+	filename, _ := findMappedFilename(parseMemoryMappings(maps), addr)
+	return filename
+}
+
+type processMappings struct {
+	mappings []memoryMapping
+	readAt   time.Time
+	pending  bool
+}
+
+// memoryMappingCache caches the memory mappings of processes, so that the
+// files backing the memory in mprotect events can be reported without
+// reading procfs while decoding samples. Mappings are read by a background
+// goroutine when a lookup finds that they are missing or stale, so lookups
+// return what was last read, if anything.
+type memoryMappingCache struct {
+	sync.Mutex
+	processes map[int32]*processMappings // pid : mappings
+	queue     chan int32
+	closed    bool
+
+	// Used to read files in the host's procfs
+	readFile func(string) ([]byte, error)
+}
+
+func newMemoryMappingCache() *memoryMappingCache {
+	c := &memoryMappingCache{
+		processes: make(map[int32]*processMappings),
+		queue:     make(chan int32, memoryMappingQueueLength),
+		readFile:  sys.HostProcFS().ReadFile,
+	}
+	go c.run()
+	return c
+}
+
+// run reads the mappings of the processes queued by lookups until the cache
+// is closed.
+func (c *memoryMappingCache) run() {
+	for pid := range c.queue {
+		maps, err := c.readFile(fmt.Sprintf("%d/maps", pid))
+
+		c.Lock()
+		if err != nil {
+			delete(c.processes, pid)
+		} else {
+			if len(c.processes) >= memoryMappingCacheSize {
+				c.processes = make(map[int32]*processMappings)
+			}
+			c.processes[pid] = &processMappings{
+				mappings: parseMemoryMappings(maps),
+				readAt:   time.Now(),
+			}
+		}
+		c.Unlock()
+	}
+}
+
+func (c *memoryMappingCache) close() {
+	c.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.Unlock()
+}
+
+// lookup returns the pathname of the mapping containing addr in a process,
+// as last read from procfs, and queues the process's mappings to be read
+// again if they are missing or stale.
+func (c *memoryMappingCache) lookup(pid int32, addr uint64) string {
+	c.Lock()
+	defer c.Unlock()
+
+	pm, ok := c.processes[pid]
+	if !ok {
+		pm = &processMappings{}
+	}
+
+	filename, found := findMappedFilename(pm.mappings, addr)
+	if (!found || time.Since(pm.readAt) >= memoryMappingCacheInterval) &&
+		!pm.pending && !c.closed {
+		select {
+		case c.queue <- pid:
+			pm.pending = true
+			if !ok && len(c.processes) < memoryMappingCacheSize {
+				c.processes[pid] = pm
+			}
+		default:
+		}
+	}
+
+	return filename
+}
+
+type memoryFilter struct {
+	sensor *Sensor
+
+	// Used to read links in the host's procfs
+	readlink func(string) (string, error)
+}
+
+// decodeSysEnterMmap decodes mmap events, deferring those that map files
+// until the file has been read from procfs.
+func (f *memoryFilter) decodeSysEnterMmap(eventID uint64, sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	pid := data["common_pid"].(int32)
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	mev := &api.MemoryEvent{
+		Type:   api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP,
+		Addr:   data["addr"].(uint64),
+		Length: data["len"].(uint64),
+		Prot:   data["prot"].(uint64),
+		Flags:  data["flags"].(uint64),
+		Fd:     int32(data["fd"].(uint64)),
+		Offset: data["off"].(uint64),
+	}
+	ev.Event = &api.TelemetryEvent_Memory{
+		Memory: mev,
+	}
+
+	if mev.Flags&mapAnonymous != 0 || mev.Fd < 0 {
+		return ev, nil
+	}
+	return f.sensor.deferEvent(eventID, ev, func() {
+		filename, err := f.readlink(fmt.Sprintf("%d/fd/%d", pid, mev.Fd))
+		if err == nil {
+			mev.Filename = filename
+		}
+	}), nil
+}
+
+func (f *memoryFilter) decodeSysEnterMprotect(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	pid := data["common_pid"].(int32)
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	mev := &api.MemoryEvent{
+		Type:   api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT,
+		Addr:   data["start"].(uint64),
+		Length: data["len"].(uint64),
+		Prot:   data["prot"].(uint64),
+	}
+	if f.sensor.memoryMappings != nil {
+		mev.Filename = f.sensor.memoryMappings.lookup(pid, mev.Addr)
+	}
+
+	ev.Event = &api.TelemetryEvent_Memory{
+		Memory: mev,
+	}
+
+	return ev, nil
+}
+
+// memoryEventFilterString returns the kernel filter string for a memory
+// event filter, or an error if the filter is invalid. An empty string is
+// returned for filters that match all events.
+func memoryEventFilterString(mef *api.MemoryEventFilter) (string, error) {
+	types, ok := memoryEventTypes[mef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported memory event type %s",
+			mef.Type)
+	}
+
+	if mef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(mef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// memoryEventProbes returns the names of the tracepoints that memory events
+// of the given type are collected from.
+func memoryEventProbes(t api.MemoryEventType) []string {
+	if name, ok := memoryEventTracepoints[t]; ok {
+		return []string{name}
+	}
+	return nil
+}
+
+// protExecFilterString restricts a kernel filter string for mmap or
+// mprotect to calls requesting executable memory.
+func protExecFilterString(filterString string) string {
+	if len(filterString) == 0 {
+		return protExecFilter
+	}
+	return fmt.Sprintf("%s && (%s)", protExecFilter, filterString)
+}
+
+func registerMemoryEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.MemoryEventFilter) {
+	filters := make(map[api.MemoryEventType]map[string]int)
+	for _, mef := range events {
+		s, err := memoryEventFilterString(mef)
+		if err != nil {
+			glog.V(1).Infof("Invalid memory event filter: %s", err)
+			continue
+		}
+		if filters[mef.Type] == nil {
+			filters[mef.Type] = make(map[string]int)
+		}
+		filters[mef.Type][s]++
+	}
+
+	f := memoryFilter{
+		sensor:   sensor,
+		readlink: sys.HostProcFS().Readlink,
+	}
+
+	for t, m := range filters {
+		filterString, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+
+		var (
+			eventID uint64
+			err     error
+		)
+		eventName := memoryEventTracepoints[t]
+		filter := perf.WithFilter(protExecFilterString(filterString))
+		if t == api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP {
+			eventID, err = sensor.registerDeferringTracepoint(
+				eventName, f.decodeSysEnterMmap, filter)
+		} else {
+			eventID, err = sensor.registerTracepoint(eventName,
+				f.decodeSysEnterMprotect, filter)
+		}
+		if err != nil {
+			glog.V(1).Infof("Couldn't get %s event id: %v",
+				eventName, err)
+			sensor.probeFailed(eventName, err)
+			continue
+		}
+
+		eventMap[eventID] = &subscription{}
+	}
+}
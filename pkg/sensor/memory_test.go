@@ -0,0 +1,217 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestParseMappedFilename(t *testing.T) {
+	maps := []byte(`00400000-0040b000 r-xp 00000000 08:01 1835023                            /bin/cat
+0060a000-0060b000 r--p 0000a000 08:01 1835023                            /bin/cat
+01c2e000-01c4f000 rw-p 00000000 00:00 0                                  [heap]
+7f3c1b5e6000-7f3c1b5e7000 rwxp 00000000 00:00 0
+7f3c1b7a5000-7f3c1b7a6000 r-xp 00000000 08:01 1049 /tmp/a file
+`)
+
+	expected := []struct {
+		addr     uint64
+		filename string
+	}{
+		{0x400000, "/bin/cat"},
+		{0x60a800, "/bin/cat"},
+		{0x1c30000, "[heap]"},
+		{0x7f3c1b5e6000, ""},
+		{0x7f3c1b7a5000, "/tmp/a file"},
+		{0x1000, ""},
+	}
+	for _, e := range expected {
+		if f := parseMappedFilename(maps, e.addr); f != e.filename {
+			t.Errorf("Expected %q for %x, got %q", e.filename, e.addr, f)
+		}
+	}
+}
+
+func TestMemoryMappingCache(t *testing.T) {
+	reads := make(chan string, memoryMappingQueueLength)
+	c := &memoryMappingCache{
+		processes: make(map[int32]*processMappings),
+		queue:     make(chan int32, memoryMappingQueueLength),
+		readFile: func(name string) ([]byte, error) {
+			reads <- name
+			if name != "100/maps" {
+				return nil, fmt.Errorf("%s not found", name)
+			}
+			return []byte("00400000-0040b000 r-xp 00000000 08:01 1835023 /bin/cat\n"), nil
+		},
+	}
+
+	// Nothing is read while looking up, so the first lookup misses
+	if f := c.lookup(100, 0x400000); f != "" {
+		t.Errorf("Expected no filename before reading maps, got %q", f)
+	}
+	if f := c.lookup(100, 0x400000); f != "" {
+		t.Errorf("Expected no filename before reading maps, got %q", f)
+	}
+	if n := len(c.queue); n != 1 {
+		t.Fatalf("Expected 1 queued process, got %d", n)
+	}
+
+	go c.run()
+	defer c.close()
+
+	if name := <-reads; name != "100/maps" {
+		t.Fatalf("Expected 100/maps to be read, got %s", name)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if f := c.lookup(100, 0x400000); f == "/bin/cat" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for mappings to be cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Fresh mappings are not read again
+	c.lookup(100, 0x400000)
+	select {
+	case name := <-reads:
+		t.Errorf("Unexpected read of %s", name)
+	default:
+	}
+
+	// Processes whose mappings can't be read are not cached
+	c.lookup(200, 0x400000)
+	if name := <-reads; name != "200/maps" {
+		t.Fatalf("Expected 200/maps to be read, got %s", name)
+	}
+	deadline = time.Now().Add(time.Second)
+	for {
+		c.Lock()
+		_, ok := c.processes[200]
+		c.Unlock()
+		if !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for process 200 to be removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMemoryEvents(t *testing.T) {
+	d, events := newTestDeferredEvents()
+	defer d.close()
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+		deferredEvents: d,
+	}
+	f := memoryFilter{
+		sensor: s,
+		readlink: func(name string) (string, error) {
+			if name != "100/fd/3" {
+				return "", fmt.Errorf("Unexpected readlink of %s", name)
+			}
+			return "/lib/libc.so.6", nil
+		},
+	}
+
+	// Anonymous mappings are not deferred
+	i, err := f.decodeSysEnterMmap(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"addr":       uint64(0),
+			"len":        uint64(4096),
+			"prot":       uint64(7),
+			"flags":      uint64(0x22),
+			"fd":         uint64(0xffffffffffffffff),
+			"off":        uint64(0),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mev := i.(*api.TelemetryEvent).GetMemory()
+	if mev == nil ||
+		mev.Type != api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP ||
+		mev.Length != 4096 || mev.Prot != 7 || mev.Fd != -1 ||
+		len(mev.Filename) != 0 {
+		t.Errorf("Unexpected mmap event %+v", mev)
+	}
+
+	values := memoryEventValues(mev)
+	if values["prot"] != uint64(7) || values["fd"] != uint64(0xffffffffffffffff) {
+		t.Errorf("Unexpected memory event values %v", values)
+	}
+
+	// Mappings of files are deferred until the file has been read
+	i, err = f.decodeSysEnterMmap(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"addr":       uint64(0),
+			"len":        uint64(8192),
+			"prot":       uint64(5),
+			"flags":      uint64(0x2),
+			"fd":         uint64(3),
+			"off":        uint64(0),
+		})
+	if err != nil || i != nil {
+		t.Fatalf("Expected deferred event, got %+v %v", i, err)
+	}
+	mev = receiveDeferredEvent(t, events).GetMemory()
+	if mev == nil || mev.Fd != 3 || mev.Filename != "/lib/libc.so.6" {
+		t.Errorf("Unexpected mmap event %+v", mev)
+	}
+}
+
+func TestMemoryEventFilterString(t *testing.T) {
+	// Writable and executable memory
+	s, err := memoryEventFilterString(&api.MemoryEventFilter{
+		Type: api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT,
+		FilterExpression: expression.BitwiseAnd(
+			expression.Identifier("prot"),
+			expression.Value(uint64(2))),
+	})
+	if err != nil || s != "prot & 2" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+	if s = protExecFilterString(s); s != "prot & 4 && (prot & 2)" {
+		t.Errorf("Unexpected filter string %q", s)
+	}
+	if s = protExecFilterString(""); s != "prot & 4" {
+		t.Errorf("Unexpected filter string %q", s)
+	}
+
+	_, err = memoryEventFilterString(&api.MemoryEventFilter{
+		Type: api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT,
+		FilterExpression: expression.Equal(
+			expression.Identifier("fd"),
+			expression.Value(uint64(3))),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
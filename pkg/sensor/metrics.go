@@ -14,6 +14,24 @@
 
 package sensor
 
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/container"
+	"github.com/golang/glog"
+
+	"golang.org/x/net/context"
+)
+
 // MetricsCounters is used for tracking metrics information in the sensor
 type MetricsCounters struct {
 	// Number of events created during the sample period
@@ -22,3 +40,257 @@ type MetricsCounters struct {
 	// Number of subscriptions
 	Subscriptions int32
 }
+
+// labeledCounters is a set of counters distinguished by a label value, such
+// as the type of an event.
+type labeledCounters struct {
+	sync.Mutex
+	counts map[string]uint64
+}
+
+func (lc *labeledCounters) add(label string, n uint64) {
+	lc.Lock()
+	if lc.counts == nil {
+		lc.counts = make(map[string]uint64)
+	}
+	lc.counts[label] += n
+	lc.Unlock()
+}
+
+func (lc *labeledCounters) remove(label string) {
+	lc.Lock()
+	delete(lc.counts, label)
+	lc.Unlock()
+}
+
+func (lc *labeledCounters) getMap() map[string]uint64 {
+	lc.Lock()
+	defer lc.Unlock()
+
+	counts := make(map[string]uint64, len(lc.counts))
+	for k, v := range lc.counts {
+		counts[k] = v
+	}
+	return counts
+}
+
+// telemetryEventType returns the name of the type of a telemetry event, as
+// used to label metrics.
+func telemetryEventType(e *api.TelemetryEvent) string {
+	switch e.Event.(type) {
+	case *api.TelemetryEvent_Syscall:
+		return "syscall"
+	case *api.TelemetryEvent_Process:
+		return "process"
+	case *api.TelemetryEvent_File:
+		return "file"
+	case *api.TelemetryEvent_KernelCall:
+		return "kernel_call"
+	case *api.TelemetryEvent_Tracepoint:
+		return "tracepoint"
+	case *api.TelemetryEvent_Network:
+		return "network"
+	case *api.TelemetryEvent_Credentials:
+		return "credentials"
+	case *api.TelemetryEvent_Signal:
+		return "signal"
+	case *api.TelemetryEvent_Namespace:
+		return "namespace"
+	case *api.TelemetryEvent_KernelModule:
+		return "kernel_module"
+	case *api.TelemetryEvent_ProcessAccess:
+		return "process_access"
+	case *api.TelemetryEvent_Container:
+		return "container"
+	case *api.TelemetryEvent_Memory:
+		return "memory"
+	case *api.TelemetryEvent_Flow:
+		return "flow"
+	case *api.TelemetryEvent_Lost:
+		return "lost"
+	case *api.TelemetryEvent_Chargen:
+		return "chargen"
+	case *api.TelemetryEvent_Ticker:
+		return "ticker"
+	}
+
+	return "unknown"
+}
+
+// countEmittedEvent counts an event that is emitted to a subscription.
+func (s *Sensor) countEmittedEvent(e interface{}) {
+	if ev, ok := e.(*api.TelemetryEvent); ok && ev != nil {
+		s.emittedEvents.add(telemetryEventType(ev), 1)
+	}
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricsWriter writes metrics in the Prometheus text exposition format.
+type metricsWriter struct {
+	w io.Writer
+}
+
+func (mw metricsWriter) header(name, metricType, help string) {
+	fmt.Fprintf(mw.w, "# HELP %s %s\n# TYPE %s %s\n",
+		name, help, name, metricType)
+}
+
+func (mw metricsWriter) sample(name, label, labelValue, value string) {
+	if len(label) > 0 {
+		fmt.Fprintf(mw.w, "%s{%s=\"%s\"} %s\n", name, label,
+			labelValueReplacer.Replace(labelValue), value)
+	} else {
+		fmt.Fprintf(mw.w, "%s %s\n", name, value)
+	}
+}
+
+func (mw metricsWriter) counter(name, help string, value uint64) {
+	mw.header(name, "counter", help)
+	mw.sample(name, "", "", strconv.FormatUint(value, 10))
+}
+
+func (mw metricsWriter) gauge(name, help string, value int64) {
+	mw.header(name, "gauge", help)
+	mw.sample(name, "", "", strconv.FormatInt(value, 10))
+}
+
+func (mw metricsWriter) labeledCounter(name, help, label string, values map[string]uint64) {
+	mw.header(name, "counter", help)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		mw.sample(name, label, k, strconv.FormatUint(values[k], 10))
+	}
+}
+
+// writeMetrics writes the sensor's current metrics to w in the Prometheus
+// text exposition format.
+func (s *Sensor) writeMetrics(w io.Writer) {
+	mw := metricsWriter{w: w}
+
+	mw.counter("capsule8_sensor_events_created_total",
+		"Number of telemetry events created.",
+		atomic.LoadUint64(&s.Metrics.Events))
+	mw.labeledCounter("capsule8_sensor_events_emitted_total",
+		"Number of telemetry events emitted to subscriptions by event type.",
+		"type", s.emittedEvents.getMap())
+	mw.labeledCounter("capsule8_sensor_delivery_spool_events_dropped_total",
+		"Number of unacknowledged telemetry events discarded from full reliable delivery spools by delivery ID. Subscriptions without reliable delivery do not discard events; they apply backpressure, which is counted by capsule8_sensor_perf_lost_samples_total.",
+		"delivery_id", s.spoolDroppedEvents.getMap())
+
+	mw.counter("capsule8_sensor_subscriptions_total",
+		"Number of subscriptions created.",
+		uint64(atomic.LoadInt32(&s.Metrics.Subscriptions)))
+	mw.gauge("capsule8_sensor_active_subscriptions",
+		"Number of subscriptions that are currently active.",
+		int64(len(s.activeSubscriptions.getList())))
+
+	s.monitorLock.Lock()
+	monitor := s.monitor
+	s.monitorLock.Unlock()
+
+	if monitor != nil {
+		stats := monitor.Stats()
+
+		lost := make(map[string]uint64, len(stats.LostSamples))
+		for cpu, n := range stats.LostSamples {
+			lost[strconv.Itoa(cpu)] = n
+		}
+		mw.labeledCounter("capsule8_sensor_perf_lost_samples_total",
+			"Number of perf samples reported lost by the kernel by CPU.",
+			"cpu", lost)
+		mw.counter("capsule8_sensor_perf_decode_errors_total",
+			"Number of perf samples that could not be decoded.",
+			stats.DecodeErrors)
+
+		name := "capsule8_sensor_ring_buffer_read_seconds"
+		mw.header(name, "summary",
+			"Time spent reading perf ring buffers after polling.")
+		mw.sample(name+"_sum", "", "", strconv.FormatFloat(
+			stats.RingBufferReadTime.Seconds(), 'g', -1, 64))
+		mw.sample(name+"_count", "", "",
+			strconv.FormatUint(stats.RingBufferReads, 10))
+	}
+
+	pcs := s.processCache.stats()
+	mw.gauge("capsule8_sensor_process_cache_size",
+		"Number of processes in the process info cache.",
+		int64(pcs.size))
+	mw.labeledCounter("capsule8_sensor_process_cache_lookups_total",
+		"Number of process info cache lookups by result.",
+		"result", map[string]uint64{
+			"hit":  pcs.hits,
+			"miss": pcs.misses,
+		})
+
+	mw.gauge("capsule8_sensor_container_cache_size",
+		"Number of containers in the container info cache.",
+		int64(container.CacheSize()))
+}
+
+// MetricsService is a service that can be used with the ServiceManager to
+// serve sensor metrics over HTTP in the Prometheus text exposition format.
+type MetricsService struct {
+	server *http.Server
+
+	address string
+	sensor  *Sensor
+}
+
+// NewMetricsService creates a new MetricsService instance that serves the
+// metrics of the given sensor on the given address at /metrics.
+func NewMetricsService(sensor *Sensor, address string) *MetricsService {
+	ms := &MetricsService{
+		server:  &http.Server{},
+		address: address,
+		sensor:  sensor,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", ms.serveMetrics)
+	ms.server.Handler = mux
+
+	return ms
+}
+
+// Name returns the human-readable name of the MetricsService.
+func (ms *MetricsService) Name() string {
+	return "Sensor Metrics"
+}
+
+func (ms *MetricsService) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	ms.sensor.writeMetrics(w)
+}
+
+// Serve is the main entrypoint for the MetricsService. It is normally called
+// by the ServiceManager. It will service requests indefinitely from the calling
+// Goroutine.
+func (ms *MetricsService) Serve() error {
+	glog.V(1).Info("Serving sensor metrics on ", ms.address)
+
+	lis, err := listen(ms.address)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	err = ms.server.Serve(lis)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	glog.Errorf("Metrics service error: %s", err)
+
+	return err
+}
+
+// Stop will stop a running MetricsService.
+func (ms *MetricsService) Stop() {
+	ms.server.Shutdown(context.Background())
+}
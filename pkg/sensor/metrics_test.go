@@ -0,0 +1,82 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestMetricsService(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache:   newMapTaskCache(),
+			lookups: &processCacheLookups{},
+		},
+	}
+	s.processCache.cache.InsertTask(1, task{pid: 1, tgid: 1})
+	s.processCache.ProcessID(1)
+	s.processCache.ProcessID(2)
+	s.processCache.ProcessID(3)
+
+	s.NewEvent()
+	s.countEmittedEvent(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Ticker{
+			Ticker: &api.TickerEvent{},
+		},
+	})
+	s.countEmittedEvent(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Chargen{
+			Chargen: &api.ChargenEvent{},
+		},
+	})
+	s.countEmittedEvent(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Chargen{
+			Chargen: &api.ChargenEvent{},
+		},
+	})
+	s.spoolDroppedEvents.add("delivery \"1\"", 5)
+
+	ms := NewMetricsService(s, "127.0.0.1:0")
+	w := httptest.NewRecorder()
+	ms.server.Handler.ServeHTTP(w,
+		httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+
+	body := w.Body.String()
+	expected := []string{
+		"# TYPE capsule8_sensor_events_created_total counter\n",
+		"capsule8_sensor_events_created_total 1\n",
+		"capsule8_sensor_events_emitted_total{type=\"chargen\"} 2\n" +
+			"capsule8_sensor_events_emitted_total{type=\"ticker\"} 1\n",
+		"capsule8_sensor_delivery_spool_events_dropped_total{delivery_id=\"delivery \\\"1\\\"\"} 5\n",
+		"capsule8_sensor_active_subscriptions 0\n",
+		"capsule8_sensor_process_cache_size 1\n",
+		"capsule8_sensor_process_cache_lookups_total{result=\"hit\"} 1\n",
+		"capsule8_sensor_process_cache_lookups_total{result=\"miss\"} 2\n",
+		"capsule8_sensor_container_cache_size 0\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(body, e) {
+			t.Errorf("Expected %q in metrics:\n%s", e, body)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/stream"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+)
+
+// splitEventFilter splits an event filter into one event filter for each of
+// the filters that it contains.
+func splitEventFilter(ef *api.EventFilter) []*api.EventFilter {
+	var filters []*api.EventFilter
+
+	for _, f := range ef.GetSyscallEvents() {
+		filters = append(filters, &api.EventFilter{
+			SyscallEvents: []*api.SyscallEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetProcessEvents() {
+		filters = append(filters, &api.EventFilter{
+			ProcessEvents: []*api.ProcessEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetFileEvents() {
+		filters = append(filters, &api.EventFilter{
+			FileEvents: []*api.FileEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetKernelEvents() {
+		filters = append(filters, &api.EventFilter{
+			KernelEvents: []*api.KernelFunctionCallFilter{f},
+		})
+	}
+	for _, f := range ef.GetTracepointEvents() {
+		filters = append(filters, &api.EventFilter{
+			TracepointEvents: []*api.TracepointEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetNetworkEvents() {
+		filters = append(filters, &api.EventFilter{
+			NetworkEvents: []*api.NetworkEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetCredentialsEvents() {
+		filters = append(filters, &api.EventFilter{
+			CredentialsEvents: []*api.CredentialsEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetSignalEvents() {
+		filters = append(filters, &api.EventFilter{
+			SignalEvents: []*api.SignalEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetNamespaceEvents() {
+		filters = append(filters, &api.EventFilter{
+			NamespaceEvents: []*api.NamespaceEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetKernelModuleEvents() {
+		filters = append(filters, &api.EventFilter{
+			KernelModuleEvents: []*api.KernelModuleEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetProcessAccessEvents() {
+		filters = append(filters, &api.EventFilter{
+			ProcessAccessEvents: []*api.ProcessAccessEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetMemoryEvents() {
+		filters = append(filters, &api.EventFilter{
+			MemoryEvents: []*api.MemoryEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetFlowEvents() {
+		filters = append(filters, &api.EventFilter{
+			FlowEvents: []*api.FlowEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetContainerEvents() {
+		filters = append(filters, &api.EventFilter{
+			ContainerEvents: []*api.ContainerEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetChargenEvents() {
+		filters = append(filters, &api.EventFilter{
+			ChargenEvents: []*api.ChargenEventFilter{f},
+		})
+	}
+	for _, f := range ef.GetTickerEvents() {
+		filters = append(filters, &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{f},
+		})
+	}
+
+	return filters
+}
+
+// mergeEventFilters combines event filters into a single event filter.
+func mergeEventFilters(filters []*api.EventFilter) *api.EventFilter {
+	ef := &api.EventFilter{}
+	for _, f := range filters {
+		ef.SyscallEvents = append(ef.SyscallEvents, f.SyscallEvents...)
+		ef.ProcessEvents = append(ef.ProcessEvents, f.ProcessEvents...)
+		ef.FileEvents = append(ef.FileEvents, f.FileEvents...)
+		ef.KernelEvents = append(ef.KernelEvents, f.KernelEvents...)
+		ef.TracepointEvents = append(ef.TracepointEvents, f.TracepointEvents...)
+		ef.NetworkEvents = append(ef.NetworkEvents, f.NetworkEvents...)
+		ef.CredentialsEvents = append(ef.CredentialsEvents, f.CredentialsEvents...)
+		ef.SignalEvents = append(ef.SignalEvents, f.SignalEvents...)
+		ef.NamespaceEvents = append(ef.NamespaceEvents, f.NamespaceEvents...)
+		ef.KernelModuleEvents = append(ef.KernelModuleEvents, f.KernelModuleEvents...)
+		ef.ProcessAccessEvents = append(ef.ProcessAccessEvents, f.ProcessAccessEvents...)
+		ef.MemoryEvents = append(ef.MemoryEvents, f.MemoryEvents...)
+		ef.FlowEvents = append(ef.FlowEvents, f.FlowEvents...)
+		ef.ContainerEvents = append(ef.ContainerEvents, f.ContainerEvents...)
+		ef.ChargenEvents = append(ef.ChargenEvents, f.ChargenEvents...)
+		ef.TickerEvents = append(ef.TickerEvents, f.TickerEvents...)
+	}
+	return ef
+}
+
+// modifiableEventFilter is a single event filter in a modifiable
+// subscription along with the streams of events matching it.
+type modifiableEventFilter struct {
+	filter  *api.EventFilter
+	sources []*stream.Stream
+}
+
+// ModifiableSubscription is a telemetry subscription whose event filters,
+// container filter, and modifier can be changed while it is active. Each
+// event filter has its own event sources, so that adding or removing an event
+// filter does not affect the events matching the others. Kernel events that
+// match more than one event filter are only sent once.
+type ModifiableSubscription struct {
+	sync.Mutex
+	sensor  *Sensor
+	joiner  *stream.Joiner
+	closed  bool
+	id      uint64
+	sub     *api.Subscription
+	filters []*modifiableEventFilter
+	copies  *eventCopies
+
+	// The container filter and modifier are applied to events as they
+	// pass through the stream, so they have their own lock.
+	filterLock      sync.Mutex
+	containerFilter *containerFilter
+	throttle        time.Duration
+	lastEvent       time.Time
+	limit           *api.LimitModifier
+	count           int64
+
+	// Sequence number of the last lost event passed through the stream
+	lostSequenceNumber uint64
+}
+
+// NewModifiableSubscription creates a new telemetry subscription from the
+// given api.Subscription descriptor that can be modified while it is active.
+// NewModifiableSubscription returns a stream.Stream of api.Events matching the
+// specified filters. Closing the Stream cancels the subscription. If the
+// subscription specifies a for_duration, the Stream is closed when that
+// duration has elapsed.
+func (s *Sensor) NewModifiableSubscription(sub *api.Subscription) (*stream.Stream, *ModifiableSubscription, error) {
+	glog.V(1).Infof("Subscribing to modifiable %+v", sub)
+
+	sub = proto.Clone(sub).(*api.Subscription)
+
+	now := s.currentMonotimeNanos()
+	historyStart, historyEnd, expiry := subscriptionWindow(sub, now)
+
+	eventStream, joiner := stream.NewJoiner()
+	joiner.Off()
+
+	ms := &ModifiableSubscription{
+		sensor: s,
+		joiner: joiner,
+		sub:    sub,
+		copies: newEventCopies(),
+	}
+
+	ms.setContainerFilter(sub.ContainerFilter)
+	ms.setModifier(sub.Modifier)
+
+	if sub.ForDuration == nil || expiry > 0 {
+		for _, ef := range splitEventFilter(sub.EventFilter) {
+			err := ms.addEventFilter(ef)
+			if err != nil {
+				joiner.Close()
+				return nil, nil, err
+			}
+		}
+	}
+
+	if historyEnd != 0 {
+		events := s.historyEvents(sub, historyStart, historyEnd)
+		eventStream = stream.Prepend(eventStream, events)
+	}
+
+	eventStream = stream.Filter(eventStream, ms.filterLost)
+	eventStream = stream.Filter(eventStream, ms.filterContainer)
+	eventStream = stream.Filter(eventStream, ms.applyModifier)
+	eventStream = stream.Do(eventStream, s.countEmittedEvent)
+
+	ms.id = s.activeSubscriptions.add(proto.Clone(sub).(*api.Subscription))
+	eventStream = stream.Finally(eventStream, func() {
+		s.activeSubscriptions.remove(ms.id)
+	})
+
+	atomic.AddInt32(&s.Metrics.Subscriptions, 1)
+	joiner.On()
+
+	// The joiner may only be closed while the subscription is locked, so
+	// that it isn't closed while it is being modified. Closing the stream
+	// or the subscription expiring closes the subscription.
+	ctrl := make(chan interface{})
+	go func() {
+		var timeout <-chan time.Time
+		if sub.ForDuration != nil {
+			glog.V(2).Infof("Subscription expires in %s", expiry)
+			timer := time.NewTimer(expiry)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		for done := false; !done; {
+			select {
+			case _, ok := <-ctrl:
+				done = !ok
+			case <-timeout:
+				done = true
+			}
+		}
+		ms.close()
+	}()
+
+	return &stream.Stream{
+		Ctrl: ctrl,
+		Data: eventStream.Data,
+	}, ms, nil
+}
+
+func (ms *ModifiableSubscription) close() {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if !ms.closed {
+		ms.closed = true
+		ms.joiner.Close()
+	}
+}
+
+// addEventFilter creates the event sources for a single event filter and
+// adds them to the subscription. The subscription must be locked unless it
+// is being created.
+func (ms *ModifiableSubscription) addEventFilter(ef *api.EventFilter) error {
+	// Registering events rewrites the filters, so register a copy.
+	sources, err := ms.sensor.eventSources(&api.Subscription{
+		EventFilter:         proto.Clone(ef).(*api.EventFilter),
+		ProcessLineageDepth: ms.sub.ProcessLineageDepth,
+	}, ms.copies)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		ms.joiner.Add(source)
+	}
+
+	ms.filters = append(ms.filters, &modifiableEventFilter{
+		filter:  ef,
+		sources: sources,
+	})
+
+	return nil
+}
+
+// removeEventFilter removes the event filter at the given index in the
+// subscription's filters and closes its event sources. The subscription must
+// be locked.
+func (ms *ModifiableSubscription) removeEventFilter(index int) {
+	mef := ms.filters[index]
+	ms.filters = append(ms.filters[:index], ms.filters[index+1:]...)
+
+	for _, source := range mef.sources {
+		ms.joiner.Remove(source)
+		source.Close()
+
+		// The joiner no longer receives from the source, so discard
+		// any events that are still in flight.
+		go func(source *stream.Stream) {
+			for range source.Data {
+			}
+		}(source)
+	}
+}
+
+func (ms *ModifiableSubscription) setContainerFilter(cf *api.ContainerFilter) {
+	ms.filterLock.Lock()
+	if cf == nil {
+		ms.containerFilter = nil
+	} else {
+		ms.containerFilter = newContainerFilter(cf)
+	}
+	ms.filterLock.Unlock()
+}
+
+func (ms *ModifiableSubscription) setModifier(modifier *api.Modifier) {
+	ms.filterLock.Lock()
+	ms.throttle = 0
+	ms.limit = nil
+	ms.count = 0
+	if modifier != nil {
+		if modifier.Throttle != nil {
+			ms.throttle = stream.ThrottleInterval(*modifier.Throttle)
+		}
+		ms.limit = modifier.Limit
+	}
+	ms.filterLock.Unlock()
+}
+
+// filterLost passes only the first copy of each lost event. Every event
+// filter has its own event sources, and each of them receives a copy of the
+// lost event. Lost events are created in order, so each copy after the first
+// has a sequence number that is no greater than the last one passed.
+func (ms *ModifiableSubscription) filterLost(i interface{}) bool {
+	e := i.(*api.TelemetryEvent)
+	if e.GetLost() == nil {
+		return true
+	}
+
+	ms.filterLock.Lock()
+	defer ms.filterLock.Unlock()
+
+	if e.SensorSequenceNumber <= ms.lostSequenceNumber {
+		return false
+	}
+	ms.lostSequenceNumber = e.SensorSequenceNumber
+	return true
+}
+
+func (ms *ModifiableSubscription) filterContainer(i interface{}) bool {
+	ms.filterLock.Lock()
+	defer ms.filterLock.Unlock()
+
+	if ms.containerFilter == nil {
+		return true
+	}
+	if !ms.containerFilter.FilterFunc(i) {
+		return false
+	}
+	ms.containerFilter.DoFunc(i)
+	return true
+}
+
+func (ms *ModifiableSubscription) applyModifier(i interface{}) bool {
+	ms.filterLock.Lock()
+
+	if ms.limit != nil {
+		if ms.count >= ms.limit.Limit {
+			ms.filterLock.Unlock()
+
+			// The subscription may be locked while it is
+			// waiting for events to be received downstream, so
+			// close it asynchronously.
+			go ms.close()
+			return false
+		}
+		ms.count++
+	}
+
+	var wait time.Duration
+	if ms.throttle > 0 {
+		now := time.Now()
+		wait = ms.throttle - now.Sub(ms.lastEvent)
+		if wait < 0 {
+			wait = 0
+		}
+		ms.lastEvent = now.Add(wait)
+	}
+
+	ms.filterLock.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return true
+}
+
+// Modify applies a modification to an active subscription. Event filters
+// that are added are enabled before event filters that are removed are
+// disabled, so events matching filters that are not removed are not lost.
+func (ms *ModifiableSubscription) Modify(mod *api.SubscriptionModification) error {
+	if mod.AddEventFilter != nil {
+		err := validationError(ms.sensor.validateSubscription(&api.Subscription{
+			EventFilter: mod.AddEventFilter,
+		}))
+		if err != nil {
+			return err
+		}
+	}
+
+	ms.Lock()
+	defer ms.Unlock()
+
+	if ms.closed {
+		return errors.New("subscription is closed")
+	}
+
+	// Find each of the filters being removed before making any changes,
+	// so that an invalid modification is not partially applied.
+	removed := make(map[int]bool)
+	for _, ef := range splitEventFilter(mod.RemoveEventFilter) {
+		found := false
+		for i, mef := range ms.filters {
+			if !removed[i] && proto.Equal(mef.filter, ef) {
+				removed[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("event filter %s is not in the subscription",
+				proto.CompactTextString(ef))
+		}
+	}
+	indices := make([]int, 0, len(removed))
+	for i := len(ms.filters) - 1; i >= 0; i-- {
+		if removed[i] {
+			indices = append(indices, i)
+		}
+	}
+
+	// Registering an added filter may still fail, so remove the filters
+	// that have already been added if it does.
+	n := len(ms.filters)
+	for _, ef := range splitEventFilter(mod.AddEventFilter) {
+		err := ms.addEventFilter(proto.Clone(ef).(*api.EventFilter))
+		if err != nil {
+			for i := len(ms.filters) - 1; i >= n; i-- {
+				ms.removeEventFilter(i)
+			}
+			return err
+		}
+	}
+
+	// Remove from the end, so that the remaining indices stay valid.
+	for _, i := range indices {
+		ms.removeEventFilter(i)
+	}
+
+	filters := make([]*api.EventFilter, len(ms.filters))
+	for i, mef := range ms.filters {
+		filters[i] = mef.filter
+	}
+	ms.sub.EventFilter = mergeEventFilters(filters)
+
+	if mod.ClearContainerFilter {
+		ms.sub.ContainerFilter = nil
+		ms.setContainerFilter(nil)
+	} else if mod.ContainerFilter != nil {
+		ms.sub.ContainerFilter = mod.ContainerFilter
+		ms.setContainerFilter(mod.ContainerFilter)
+	}
+
+	if mod.ClearModifier {
+		ms.sub.Modifier = nil
+		ms.setModifier(nil)
+	} else if mod.Modifier != nil {
+		ms.sub.Modifier = mod.Modifier
+		ms.setModifier(mod.Modifier)
+	}
+
+	ms.sensor.activeSubscriptions.update(ms.id,
+		proto.Clone(ms.sub).(*api.Subscription))
+
+	glog.V(1).Infof("Modified subscription to %+v", ms.sub)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// waitForEvent receives events from data until one matches f.
+func waitForEvent(t *testing.T, data <-chan interface{}, f func(*api.TelemetryEvent) bool) {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e, ok := <-data:
+			if !ok {
+				t.Fatal("Subscription closed unexpectedly")
+			}
+			if f(e.(*api.TelemetryEvent)) {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for event")
+		}
+	}
+}
+
+func isTickerEvent(e *api.TelemetryEvent) bool {
+	return e.GetTicker() != nil
+}
+
+func isChargenEvent(e *api.TelemetryEvent) bool {
+	return e.GetChargen() != nil
+}
+
+func TestModifiableSubscription(t *testing.T) {
+	s := &Sensor{}
+
+	tickerFilter := &api.EventFilter{
+		TickerEvents: []*api.TickerEventFilter{
+			&api.TickerEventFilter{
+				Interval: int64(time.Millisecond),
+			},
+		},
+	}
+
+	eventStream, ms, err := s.NewModifiableSubscription(&api.Subscription{
+		EventFilter: tickerFilter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitForEvent(t, eventStream.Data, isTickerEvent)
+
+	err = ms.Modify(&api.SubscriptionModification{
+		AddEventFilter: &api.EventFilter{
+			ChargenEvents: []*api.ChargenEventFilter{
+				&api.ChargenEventFilter{
+					Length: 1,
+				},
+			},
+		},
+		RemoveEventFilter: tickerFilter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitForEvent(t, eventStream.Data, isChargenEvent)
+
+	// Once the ticker has been removed, only chargen events remain
+	n := 0
+	waitForEvent(t, eventStream.Data, func(e *api.TelemetryEvent) bool {
+		if isTickerEvent(e) {
+			n = 0
+		} else {
+			n++
+		}
+		return n == 100
+	})
+
+	subs := s.activeSubscriptions.getList()
+	if len(subs) != 1 ||
+		len(subs[0].EventFilter.TickerEvents) != 0 ||
+		len(subs[0].EventFilter.ChargenEvents) != 1 {
+		t.Errorf("Unexpected active subscriptions %v", subs)
+	}
+
+	// Removing a filter that isn't in the subscription is an error
+	err = ms.Modify(&api.SubscriptionModification{
+		RemoveEventFilter: tickerFilter,
+	})
+	if err == nil {
+		t.Error("Expected error removing filter that isn't present")
+	}
+
+	// Invalid filters can't be added
+	err = ms.Modify(&api.SubscriptionModification{
+		AddEventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{},
+			},
+		},
+	})
+	if err == nil {
+		t.Error("Expected error adding invalid filter")
+	}
+
+	err = ms.Modify(&api.SubscriptionModification{
+		Modifier: &api.Modifier{
+			Limit: &api.LimitModifier{
+				Limit: 10,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The limit closes the subscription, although events that were
+	// already counted may still be received.
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-eventStream.Data:
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for subscription to close")
+		}
+		break
+	}
+
+	if len(s.activeSubscriptions.getList()) != 0 {
+		t.Error("Expected no active subscriptions")
+	}
+
+	err = ms.Modify(&api.SubscriptionModification{
+		ClearModifier: true,
+	})
+	if err == nil {
+		t.Error("Expected error modifying closed subscription")
+	}
+
+	eventStream.Close()
+}
@@ -0,0 +1,441 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	namespaceCloneTracepoint       = "task/task_newtask"
+	namespaceUnshareTracepoint     = "syscalls/sys_enter_unshare"
+	namespaceUnshareExitTracepoint = "syscalls/sys_exit_unshare"
+	namespaceSetnsTracepoint       = "syscalls/sys_enter_setns"
+	namespaceSetnsExitTracepoint   = "syscalls/sys_exit_setns"
+
+	// The maximum number of unshare(2) and setns(2) calls in progress
+	// tracked by a namespaceFilter before they are forgotten.
+	namespaceCallCacheSize = 4096
+
+	cloneNewNS     = 0x00020000
+	cloneNewCgroup = 0x02000000
+	cloneNewUTS    = 0x04000000
+	cloneNewIPC    = 0x08000000
+	cloneNewUser   = 0x10000000
+	cloneNewPID    = 0x20000000
+	cloneNewNet    = 0x40000000
+
+	cloneNewNamespaces = cloneNewNS | cloneNewCgroup | cloneNewUTS |
+		cloneNewIPC | cloneNewUser | cloneNewPID | cloneNewNet
+)
+
+// namespaceTypes maps the clone flags that create new namespaces to the
+// names of the namespaces in /proc/PID/ns.
+var namespaceTypes = []struct {
+	flag uint64
+	name string
+}{
+	{cloneNewNS, "mnt"},
+	{cloneNewCgroup, "cgroup"},
+	{cloneNewUTS, "uts"},
+	{cloneNewIPC, "ipc"},
+	{cloneNewUser, "user"},
+	{cloneNewPID, "pid"},
+	{cloneNewNet, "net"},
+}
+
+// The fields of each namespace event probe that may be used in filters
+var namespaceEventTypes = map[api.NamespaceEventType]expression.FieldTypeMap{
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE: expression.FieldTypeMap{
+		"clone_flags": int32(api.ValueType_UINT64),
+	},
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE: expression.FieldTypeMap{
+		"unshare_flags": int32(api.ValueType_UINT64),
+	},
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS: expression.FieldTypeMap{
+		"fd":     int32(api.ValueType_UINT64),
+		"nstype": int32(api.ValueType_UINT64),
+	},
+}
+
+var namespaceEventTracepoints = map[api.NamespaceEventType]string{
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE:   namespaceCloneTracepoint,
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE: namespaceUnshareTracepoint,
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS:   namespaceSetnsTracepoint,
+}
+
+// Namespaces only change when unshare(2) and setns(2) succeed, so their
+// events are reported when they return.
+var namespaceEventExitTracepoints = map[api.NamespaceEventType]string{
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE: namespaceUnshareExitTracepoint,
+	api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS:   namespaceSetnsExitTracepoint,
+}
+
+func namespaceEventValues(nev *api.NamespaceEvent) expression.FieldValueMap {
+	switch nev.Type {
+	case api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE:
+		return expression.FieldValueMap{
+			"clone_flags": nev.Flags,
+		}
+	case api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE:
+		return expression.FieldValueMap{
+			"unshare_flags": nev.Flags,
+		}
+	case api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS:
+		return expression.FieldValueMap{
+			"fd":     uint64(nev.SetnsFd),
+			"nstype": nev.Flags,
+		}
+	}
+	return expression.FieldValueMap{}
+}
+
+// parseNamespaceLink parses the destination of a namespace link in procfs,
+// which has the form "type:[inode]".
+func parseNamespaceLink(link string) (string, uint64, bool) {
+	i := strings.Index(link, ":[")
+	if i <= 0 || !strings.HasSuffix(link, "]") {
+		return "", 0, false
+	}
+	inode, err := strconv.ParseUint(link[i+2:len(link)-1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return link[:i], inode, true
+}
+
+// readHostNamespaces returns the inode numbers of the namespaces of the
+// host's init process by type, reading the links in procfs with readlink.
+// The host's namespaces don't change, so they're read once when the sensor
+// starts.
+func readHostNamespaces(readlink func(string) (string, error)) map[string]uint64 {
+	namespaces := make(map[string]uint64, len(namespaceTypes))
+	for _, t := range namespaceTypes {
+		link, err := readlink(fmt.Sprintf("1/ns/%s", t.name))
+		if err != nil {
+			glog.V(1).Infof("Couldn't read host %s namespace: %s",
+				t.name, err)
+			continue
+		}
+		if _, inode, ok := parseNamespaceLink(link); ok {
+			namespaces[t.name] = inode
+		}
+	}
+	return namespaces
+}
+
+// readNamespace returns the namespace indicated by a link in the host's
+// procfs, or nil if it can't be read. It is compared against the host's
+// namespaces to identify processes joining them.
+func (s *Sensor) readNamespace(relativePath string) *api.Namespace {
+	link, err := sys.HostProcFS().Readlink(relativePath)
+	if err != nil {
+		return nil
+	}
+	nsType, inode, ok := parseNamespaceLink(link)
+	if !ok {
+		return nil
+	}
+
+	ns := &api.Namespace{
+		Type:  nsType,
+		Inode: inode,
+	}
+	if hostInode, ok := s.hostNamespaces[nsType]; ok {
+		ns.Host = inode == hostInode
+	}
+
+	return ns
+}
+
+// newNamespaces returns the namespaces of a process created by the given
+// clone flags.
+func (s *Sensor) newNamespaces(pid int, flags uint64, pidForChildren bool) []*api.Namespace {
+	var namespaces []*api.Namespace
+	for _, t := range namespaceTypes {
+		if flags&t.flag == 0 {
+			continue
+		}
+		name := t.name
+		if t.flag == cloneNewPID && pidForChildren {
+			// unshare(CLONE_NEWPID) only applies to children
+			name = "pid_for_children"
+		}
+		ns := s.readNamespace(fmt.Sprintf("%d/ns/%s", pid, name))
+		if ns != nil {
+			ns.Type = t.name
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// namespaceFilter decodes namespace events. The arguments of unshare(2) and
+// setns(2) calls that pass the kernel filter are recorded by thread when the
+// calls are entered, and events are reported for the calls that return
+// successfully. Events are deferred until their namespaces have been read
+// from procfs.
+type namespaceFilter struct {
+	sync.Mutex
+	sensor *Sensor
+	calls  map[int32]perf.TraceEventSampleData // tid : arguments
+}
+
+func (f *namespaceFilter) decodeTaskNewtask(eventID uint64, sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	childPid := data["pid"].(int32)
+	flags := data["clone_flags"].(uint64)
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	nev := &api.NamespaceEvent{
+		Type:          api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE,
+		Flags:         flags,
+		CloneChildPid: childPid,
+	}
+	if id, ok := f.sensor.processCache.ProcessID(int(childPid)); ok {
+		nev.CloneChildId = id
+	}
+
+	ev.Event = &api.TelemetryEvent_Namespace{
+		Namespace: nev,
+	}
+
+	return f.sensor.deferEvent(eventID, ev, func() {
+		nev.Namespaces = f.sensor.newNamespaces(int(childPid), flags,
+			false)
+	}), nil
+}
+
+func (f *namespaceFilter) decodeSysEnter(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	f.Lock()
+	if f.calls == nil || len(f.calls) >= namespaceCallCacheSize {
+		f.calls = make(map[int32]perf.TraceEventSampleData)
+	}
+	f.calls[data["common_pid"].(int32)] = data
+	f.Unlock()
+
+	return nil, nil
+}
+
+// callArgs returns the arguments of the call that a thread is returning
+// from, if the call was entered and succeeded.
+func (f *namespaceFilter) callArgs(data perf.TraceEventSampleData) (perf.TraceEventSampleData, bool) {
+	tid := data["common_pid"].(int32)
+
+	f.Lock()
+	args, ok := f.calls[tid]
+	delete(f.calls, tid)
+	f.Unlock()
+
+	if !ok || data["ret"].(int64) != 0 {
+		return nil, false
+	}
+	return args, true
+}
+
+func (f *namespaceFilter) decodeSysExitUnshare(eventID uint64, sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	args, ok := f.callArgs(data)
+	if !ok {
+		return nil, nil
+	}
+	flags := args["unshare_flags"].(uint64)
+	pid := data["common_pid"].(int32)
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	nev := &api.NamespaceEvent{
+		Type:  api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE,
+		Flags: flags,
+	}
+	ev.Event = &api.TelemetryEvent_Namespace{
+		Namespace: nev,
+	}
+
+	return f.sensor.deferEvent(eventID, ev, func() {
+		nev.Namespaces = f.sensor.newNamespaces(int(pid), flags, true)
+	}), nil
+}
+
+func (f *namespaceFilter) decodeSysExitSetns(eventID uint64, sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	args, ok := f.callArgs(data)
+	if !ok {
+		return nil, nil
+	}
+	fd := int32(args["fd"].(uint64))
+	pid := data["common_pid"].(int32)
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	nev := &api.NamespaceEvent{
+		Type:    api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS,
+		Flags:   args["nstype"].(uint64),
+		SetnsFd: fd,
+	}
+	ev.Event = &api.TelemetryEvent_Namespace{
+		Namespace: nev,
+	}
+
+	// The descriptor may have been closed or replaced since the call
+	// returned, so this is best-effort.
+	return f.sensor.deferEvent(eventID, ev, func() {
+		ns := f.sensor.readNamespace(fmt.Sprintf("%d/fd/%d", pid, fd))
+		if ns != nil {
+			nev.Namespaces = []*api.Namespace{ns}
+		}
+	}), nil
+}
+
+// namespaceEventFilterString returns the kernel filter string for a namespace
+// event filter, or an error if the filter is invalid. An empty string is
+// returned for filters that match all events.
+func namespaceEventFilterString(nef *api.NamespaceEventFilter) (string, error) {
+	types, ok := namespaceEventTypes[nef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported namespace event type %s",
+			nef.Type)
+	}
+
+	if nef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(nef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// namespaceEventProbes returns the names of the tracepoints that namespace
+// events of the given type are collected from.
+func namespaceEventProbes(t api.NamespaceEventType) []string {
+	name, ok := namespaceEventTracepoints[t]
+	if !ok {
+		return nil
+	}
+	if exit, ok := namespaceEventExitTracepoints[t]; ok {
+		return []string{name, exit}
+	}
+	return []string{name}
+}
+
+// cloneFilterString restricts a kernel filter string for task_newtask to
+// tasks created with new namespaces.
+func cloneFilterString(filterString string) string {
+	s := fmt.Sprintf("clone_flags & %d", cloneNewNamespaces)
+	if len(filterString) == 0 {
+		return s
+	}
+	return fmt.Sprintf("%s && (%s)", s, filterString)
+}
+
+func registerNamespaceEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.NamespaceEventFilter) {
+	filters := make(map[api.NamespaceEventType]map[string]int)
+	for _, nef := range events {
+		s, err := namespaceEventFilterString(nef)
+		if err != nil {
+			glog.V(1).Infof("Invalid namespace event filter: %s", err)
+			continue
+		}
+		if filters[nef.Type] == nil {
+			filters[nef.Type] = make(map[string]int)
+		}
+		filters[nef.Type][s]++
+	}
+
+	f := &namespaceFilter{
+		sensor: sensor,
+	}
+
+	// Clone events are reported when the task is created, and unshare and
+	// setns events when the calls return, so their decoders defer them.
+	decoders := map[api.NamespaceEventType]perf.TraceEventDecoderFn{
+		api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE: f.decodeSysEnter,
+		api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS:   f.decodeSysEnter,
+	}
+	deferringDecoders := map[api.NamespaceEventType]deferringDecoderFn{
+		api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE:   f.decodeTaskNewtask,
+		api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE: f.decodeSysExitUnshare,
+		api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS:   f.decodeSysExitSetns,
+	}
+
+	for t, m := range filters {
+		filterString, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+		if t == api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE {
+			filterString = cloneFilterString(filterString)
+		}
+
+		exitName, exit := namespaceEventExitTracepoints[t]
+		var exitID uint64
+		if exit {
+			var err error
+			exitID, err = sensor.registerDeferringTracepoint(exitName,
+				deferringDecoders[t])
+			if err != nil {
+				glog.V(1).Infof("Couldn't get %s event id: %v",
+					exitName, err)
+				sensor.probeFailed(exitName, err)
+				continue
+			}
+		}
+
+		var (
+			eventID uint64
+			err     error
+		)
+		eventName := namespaceEventTracepoints[t]
+		if exit {
+			eventID, err = sensor.registerTracepoint(eventName,
+				decoders[t], perf.WithFilter(filterString))
+		} else {
+			eventID, err = sensor.registerDeferringTracepoint(
+				eventName, deferringDecoders[t],
+				perf.WithFilter(filterString))
+		}
+		if err != nil {
+			glog.V(1).Infof("Couldn't get %s event id: %v",
+				eventName, err)
+			sensor.probeFailed(eventName, err)
+			if exit {
+				sensor.monitor.UnregisterEvent(exitID)
+			}
+			continue
+		}
+
+		eventMap[eventID] = &subscription{}
+		if exit {
+			eventMap[exitID] = &subscription{}
+		}
+	}
+}
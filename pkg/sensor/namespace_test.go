@@ -0,0 +1,178 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"os"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestParseNamespaceLink(t *testing.T) {
+	nsType, inode, ok := parseNamespaceLink("net:[4026531993]")
+	if !ok || nsType != "net" || inode != 4026531993 {
+		t.Errorf("Unexpected namespace %s %d %v", nsType, inode, ok)
+	}
+
+	for _, link := range []string{"/dev/null", "socket:[x]", ":[1]", "net:[1"} {
+		if _, _, ok = parseNamespaceLink(link); ok {
+			t.Errorf("Expected %q not to be a namespace link", link)
+		}
+	}
+}
+
+func TestReadHostNamespaces(t *testing.T) {
+	namespaces := readHostNamespaces(func(path string) (string, error) {
+		if path == "1/ns/net" {
+			return "net:[4026531993]", nil
+		}
+		return "", os.ErrNotExist
+	})
+	if len(namespaces) != 1 || namespaces["net"] != 4026531993 {
+		t.Errorf("Unexpected host namespaces %v", namespaces)
+	}
+}
+
+func TestNamespaceEvents(t *testing.T) {
+	d, events := newTestDeferredEvents()
+	defer d.close()
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+		deferredEvents: d,
+	}
+	f := namespaceFilter{
+		sensor: s,
+	}
+
+	flags := uint64(cloneNewNet | cloneNewUTS | 0x11)
+	i, err := f.decodeTaskNewtask(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid":  int32(os.Getpid()),
+			"pid":         int32(os.Getpid()),
+			"clone_flags": flags,
+		})
+	if err != nil || i != nil {
+		t.Fatalf("Expected deferred event, got %+v %v", i, err)
+	}
+	nev := receiveDeferredEvent(t, events).GetNamespace()
+	if nev == nil ||
+		nev.Type != api.NamespaceEventType_NAMESPACE_EVENT_TYPE_CLONE ||
+		nev.Flags != flags || nev.CloneChildPid != int32(os.Getpid()) {
+		t.Fatalf("Unexpected namespace event %+v", nev)
+	}
+
+	// Namespaces are read from procfs, which may not be available
+	if len(nev.Namespaces) > 0 {
+		if len(nev.Namespaces) != 2 ||
+			nev.Namespaces[0].Type != "uts" ||
+			nev.Namespaces[1].Type != "net" ||
+			nev.Namespaces[1].Inode == 0 {
+			t.Errorf("Unexpected namespaces %+v", nev.Namespaces)
+		}
+	}
+}
+
+func TestNamespaceExitEvents(t *testing.T) {
+	d, events := newTestDeferredEvents()
+	defer d.close()
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+		deferredEvents: d,
+	}
+	f := namespaceFilter{
+		sensor: s,
+	}
+	tid := int32(os.Getpid())
+
+	// Failed calls and calls that weren't entered are not reported
+	f.decodeSysEnter(&perf.SampleRecord{}, perf.TraceEventSampleData{
+		"common_pid":    tid,
+		"unshare_flags": uint64(cloneNewUTS),
+	})
+	i, _ := f.decodeSysExitUnshare(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": tid,
+			"ret":        int64(-1),
+		})
+	if i != nil {
+		t.Errorf("Unexpected event for failed call %+v", i)
+	}
+	i, _ = f.decodeSysExitUnshare(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": tid,
+			"ret":        int64(0),
+		})
+	if i != nil {
+		t.Errorf("Unexpected event for call not entered %+v", i)
+	}
+
+	f.decodeSysEnter(&perf.SampleRecord{}, perf.TraceEventSampleData{
+		"common_pid": tid,
+		"fd":         uint64(3),
+		"nstype":     uint64(cloneNewNet),
+	})
+	i, err := f.decodeSysExitSetns(1, &perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": tid,
+			"ret":        int64(0),
+		})
+	if err != nil || i != nil {
+		t.Fatalf("Expected deferred event, got %+v %v", i, err)
+	}
+	nev := receiveDeferredEvent(t, events).GetNamespace()
+	if nev == nil ||
+		nev.Type != api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS ||
+		nev.Flags != cloneNewNet || nev.SetnsFd != 3 {
+		t.Errorf("Unexpected namespace event %+v", nev)
+	}
+}
+
+func TestNamespaceEventFilterString(t *testing.T) {
+	s, err := namespaceEventFilterString(&api.NamespaceEventFilter{
+		Type: api.NamespaceEventType_NAMESPACE_EVENT_TYPE_SETNS,
+		FilterExpression: expression.Equal(
+			expression.Identifier("nstype"),
+			expression.Value(uint64(cloneNewNet))),
+	})
+	if err != nil || s != "nstype == 1073741824" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+
+	_, err = namespaceEventFilterString(&api.NamespaceEventFilter{
+		Type: api.NamespaceEventType_NAMESPACE_EVENT_TYPE_UNSHARE,
+		FilterExpression: expression.Equal(
+			expression.Identifier("nstype"),
+			expression.Value(uint64(0))),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+
+	if s = cloneFilterString(""); s != "clone_flags & 2114060288" {
+		t.Errorf("Unexpected clone filter string %q", s)
+	}
+	if s = cloneFilterString("clone_flags == 1"); s !=
+		"clone_flags & 2114060288 && (clone_flags == 1)" {
+		t.Errorf("Unexpected clone filter string %q", s)
+	}
+}
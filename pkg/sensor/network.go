@@ -15,11 +15,14 @@
 package sensor
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net"
 	"strings"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/container"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
@@ -28,18 +31,154 @@ import (
 
 const (
 	networkKprobeBindSymbol    = "sys_bind"
-	networkKprobeBindFetchargs = "fd=%di sa_family=+0(%si):u16 sin_port=+2(%si):u16 sin_addr=+4(%si):u32 sun_path=+2(%si):string sin6_port=+2(%si):u16 sin6_addr_high=+8(%si):u64 sin6_addr_low=+16(%si):u64"
+	networkKprobeBindFetchargs = "fd=%di sa_family=+0(%si):u16 sin_port=+2(%si):u16 sin_addr=+4(%si):u32 sun_path=+2(%si):string sun_path_abstract=+3(%si):string sin6_port=+2(%si):u16 sin6_addr_high=+8(%si):u64 sin6_addr_low=+16(%si):u64"
 
 	networkKprobeConnectSymbol    = "sys_connect"
-	networkKprobeConnectFetchargs = "fd=%di sa_family=+0(%si):u16 sin_port=+2(%si):u16 sin_addr=+4(%si):u32 sun_path=+2(%si):string sin6_port=+2(%si):u16 sin6_addr_high=+8(%si):u64 sin6_addr_low=+16(%si):u64"
+	networkKprobeConnectFetchargs = "fd=%di sa_family=+0(%si):u16 sin_port=+2(%si):u16 sin_addr=+4(%si):u32 sun_path=+2(%si):string sun_path_abstract=+3(%si):string sin6_port=+2(%si):u16 sin6_addr_high=+8(%si):u64 sin6_addr_low=+16(%si):u64"
 
 	networkKprobeSendmsgSymbol    = "sys_sendmsg"
 	networkKprobeSendmsgFetchargs = "fd=%di sa_family=+0(+0(%si)):u16 sin_port=+2(+0(%si)):u16 sin_addr=+4(+0(%si)):u32 sun_path=+2(+0(%si)):string sin6_port=+2(+0(%si)):u16 sin6_addr_high=+8(+0(%si)):u64 sin6_addr_low=+16(+0(%si)):u64"
 
 	networkKprobeSendtoSymbol    = "sys_sendto"
 	networkKprobeSendtoFetchargs = "fd=%di sa_family=+0(%r8):u16 sin_port=+2(%r8):u16 sin_addr=+4(%r8):u32 sun_path=+2(%r8):string sin6_port=+2(%r8):u16 sin6_addr_high=+8(%r8):u64 sin6_addr_low=+16(%r8):u64"
+
+	afNetlink = 16
+	afPacket  = 17
+	afVsock   = 40
 )
 
+var networkEventTypes = expression.FieldTypeMap{
+	"fd":             int32(api.ValueType_UINT64),
+	"ret":            int32(api.ValueType_SINT64),
+	"backlog":        int32(api.ValueType_UINT64),
+	"sa_family":      int32(api.ValueType_UINT16),
+	"sin_port":       int32(api.ValueType_UINT16),
+	"sin_addr":       int32(api.ValueType_UINT32),
+	"sun_path":       int32(api.ValueType_STRING),
+	"sin6_port":      int32(api.ValueType_UINT16),
+	"sin6_addr_high": int32(api.ValueType_UINT64),
+	"sin6_addr_low":  int32(api.ValueType_UINT64),
+	"family":         int32(api.ValueType_UINT64),
+	"type":           int32(api.ValueType_UINT64),
+	"protocol":       int32(api.ValueType_UINT64),
+}
+
+// networkEventFieldTypes returns the fields that may be used in filters for
+// network events of the given type.
+func networkEventFieldTypes(t api.NetworkEventType) expression.FieldTypeMap {
+	if isTCPEventType(t) {
+		return tcpEventTypes
+	}
+	return networkEventTypes
+}
+
+func networkEventValues(nev *api.NetworkEvent) expression.FieldValueMap {
+	if isTCPEventType(nev.Type) {
+		return tcpEventValues(nev)
+	}
+
+	values := expression.FieldValueMap{
+		"fd":  nev.Sockfd,
+		"ret": nev.Result,
+	}
+
+	switch nev.Type {
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_LISTEN_ATTEMPT:
+		values["backlog"] = nev.Backlog
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_SOCKET:
+		values["family"] = uint64(nev.SocketFamily)
+		values["type"] = uint64(nev.SocketType)
+		values["protocol"] = uint64(nev.SocketProtocol)
+	}
+
+	switch nev.Address.GetFamily() {
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_LOCAL:
+		values["sa_family"] = uint16(1) // AF_LOCAL
+		values["sun_path"] = nev.Address.GetLocalAddress()
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET:
+		a := nev.Address.GetIpv4Address()
+		values["sa_family"] = uint16(2) // AF_INET
+		values["sin_addr"] = a.GetAddress().GetAddress()
+		values["sin_port"] = uint16(a.GetPort())
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6:
+		a := nev.Address.GetIpv6Address()
+		values["sa_family"] = uint16(10) // AF_INET6
+		values["sin6_addr_high"] = a.GetAddress().GetHigh()
+		values["sin6_addr_low"] = a.GetAddress().GetLow()
+		values["sin6_port"] = uint16(a.GetPort())
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_NETLINK:
+		values["sa_family"] = uint16(afNetlink)
+		values["sin_addr"] = nev.Address.GetNetlinkAddress().GetPid()
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_PACKET:
+		a := nev.Address.GetPacketAddress()
+		values["sa_family"] = uint16(afPacket)
+		values["sin_port"] = uint16(a.GetProtocol())
+		values["sin_addr"] = uint32(a.GetIfindex())
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_VSOCK:
+		values["sa_family"] = uint16(afVsock)
+		values["sin_addr"] = nev.Address.GetVsockAddress().GetPort()
+	}
+	return values
+}
+
+// sockaddrAddress returns the network address for the address families that
+// are not fetched into fields of their own. Their fields overlap those of
+// the IPv4 and IPv6 families, which are fetched from the same offsets for
+// every address.
+func sockaddrAddress(family uint16, data perf.TraceEventSampleData) *api.NetworkAddress {
+	// The 4 bytes at offset 4 and the 16 bytes at offset 8
+	u32 := data["sin_addr"].(uint32)
+	high := data["sin6_addr_high"].(uint64)
+	low := data["sin6_addr_low"].(uint64)
+
+	switch family {
+	case afNetlink:
+		// struct sockaddr_nl
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_NETLINK,
+			Address: &api.NetworkAddress_NetlinkAddress{
+				NetlinkAddress: &api.NetlinkAddress{
+					Pid:    u32,
+					Groups: uint32(high),
+				},
+			},
+		}
+	case afPacket:
+		// struct sockaddr_ll
+		halen := int(high>>24) & 0xff
+		if halen > 8 {
+			halen = 8
+		}
+		addr := make([]byte, 8)
+		binary.LittleEndian.PutUint32(addr[0:4], uint32(high>>32))
+		binary.LittleEndian.PutUint32(addr[4:8], uint32(low))
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_PACKET,
+			Address: &api.NetworkAddress_PacketAddress{
+				PacketAddress: &api.PacketAddress{
+					Protocol: uint32(data["sin_port"].(uint16)),
+					Ifindex:  int32(u32),
+					Hatype:   uint32(uint16(high)),
+					Pkttype:  uint32(high>>16) & 0xff,
+					Address:  addr[:halen],
+				},
+			},
+		}
+	case afVsock:
+		// struct sockaddr_vm
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_VSOCK,
+			Address: &api.NetworkAddress_VsockAddress{
+				VsockAddress: &api.VsockAddress{
+					Cid:  uint32(high),
+					Port: u32,
+				},
+			},
+		}
+	}
+	return nil
+}
+
 type networkFilter struct {
 	sensor *Sensor
 }
@@ -57,6 +196,8 @@ func (f *networkFilter) newNetworkEvent(eventType api.NetworkEventType, sample *
 			break
 		case 10: // AF_INET6
 			break
+		case afNetlink, afPacket, afVsock:
+			break
 		default:
 			return nil
 		}
@@ -104,6 +245,8 @@ func (f *networkFilter) newNetworkEvent(eventType api.NetworkEventType, sample *
 					},
 				},
 			}
+		case afNetlink, afPacket, afVsock:
+			network.Address = sockaddrAddress(family, data)
 		default:
 			// This shouldn't be reachable
 			return nil
@@ -123,6 +266,17 @@ func (f *networkFilter) newNetworkEvent(eventType api.NetworkEventType, sample *
 	return event
 }
 
+func (f *networkFilter) decodeSysEnterSocket(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	event := f.newNetworkEvent(api.NetworkEventType_NETWORK_EVENT_TYPE_SOCKET, sample, data)
+
+	network := event.Event.(*api.TelemetryEvent_Network).Network
+	network.SocketFamily = uint32(data["family"].(uint64))
+	network.SocketType = uint32(data["type"].(uint64))
+	network.SocketProtocol = uint32(data["protocol"].(uint64))
+
+	return event, nil
+}
+
 func (f *networkFilter) decodeSysEnterAccept(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
 	event := f.newNetworkEvent(api.NetworkEventType_NETWORK_EVENT_TYPE_ACCEPT_ATTEMPT, sample, data)
 	return event, nil
@@ -145,9 +299,48 @@ func (f *networkFilter) decodeSysExitBind(sample *perf.SampleRecord, data perf.T
 
 func (f *networkFilter) decodeSysConnect(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
 	event := f.newNetworkEvent(api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT, sample, data)
+	if event != nil {
+		f.setUnixSocketPeer(event.GetNetwork(), data)
+		setRemoteContainer(event.GetNetwork())
+	}
 	return event, nil
 }
 
+// networkAddressIP returns the IP address of an IPv4 or IPv6 network
+// address, or nil for other families.
+func networkAddressIP(addr *api.NetworkAddress) net.IP {
+	switch addr.GetFamily() {
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET:
+		ip := make(net.IP, net.IPv4len)
+		binary.LittleEndian.PutUint32(ip,
+			addr.GetIpv4Address().GetAddress().GetAddress())
+		return ip
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6:
+		a := addr.GetIpv6Address().GetAddress()
+		ip := make(net.IP, net.IPv6len)
+		binary.LittleEndian.PutUint64(ip[0:8], a.GetHigh())
+		binary.LittleEndian.PutUint64(ip[8:16], a.GetLow())
+		return ip
+	}
+	return nil
+}
+
+// setRemoteContainer adds the container on this host that owns the address
+// of a connect attempt to its network event.
+func setRemoteContainer(network *api.NetworkEvent) {
+	ip := networkAddressIP(network.Address)
+	if ip == nil || ip.IsUnspecified() {
+		return
+	}
+	info := container.GetInfoByAddress(ip.String())
+	if info == nil {
+		return
+	}
+	network.RemoteContainerId = info.ID
+	network.RemoteContainerName = info.Name
+	network.RemoteImageName = info.ImageName
+}
+
 func (f *networkFilter) decodeSysExitConnect(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
 	event := f.newNetworkEvent(api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_RESULT, sample, data)
 	return event, nil
@@ -200,19 +393,84 @@ type networkFilterSet struct {
 	sendtoResultFilters    map[string]int
 	recvfromAttemptFilters map[string]int
 	recvfromResultFilters  map[string]int
+	socketFilters          map[string]int
+	tcpFilters             map[api.NetworkEventType]map[string]int
 }
 
-func (nfs *networkFilterSet) add(nef *api.NetworkEventFilter) {
-	var filterString string
+// networkEventProbes returns the names of the tracepoints and kprobes that
+// network events of the given type are collected from.
+func networkEventProbes(t api.NetworkEventType) []string {
+	switch t {
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_ACCEPT_ATTEMPT:
+		return []string{"syscalls/sys_enter_accept", "syscalls/sys_enter_accept4"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_ACCEPT_RESULT:
+		return []string{"syscalls/sys_exit_accept", "syscalls/sys_exit_accept4"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_BIND_ATTEMPT:
+		return []string{networkKprobeBindSymbol}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_BIND_RESULT:
+		return []string{"syscalls/sys_exit_bind"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT:
+		return []string{networkKprobeConnectSymbol}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_RESULT:
+		return []string{"syscalls/sys_exit_connect"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_LISTEN_ATTEMPT:
+		return []string{"syscalls/sys_enter_listen"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_LISTEN_RESULT:
+		return []string{"syscalls/sys_exit_listen"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_ATTEMPT:
+		return []string{"syscalls/sys_enter_recvfrom", "syscalls/sys_enter_recvmsg"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT:
+		return []string{"syscalls/sys_exit_recvfrom", "syscalls/sys_exit_recvmsg"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_ATTEMPT:
+		return []string{networkKprobeSendmsgSymbol, networkKprobeSendtoSymbol}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_RESULT:
+		return []string{"syscalls/sys_exit_sendmsg", "syscalls/sys_exit_sendto"}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_STATE_CHANGE:
+		return []string{tcpSetStateTracepoint}
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_SOCKET:
+		return []string{"syscalls/sys_enter_socket"}
+	}
+	return nil
+}
 
-	if nef.FilterExpression != nil {
-		expr, err := expression.NewExpression(nef.FilterExpression)
+// networkEventFilterString returns the kernel filter string for a network
+// event filter, or an error if the filter is invalid. An empty string is
+// returned for filters that match all events of their type.
+func networkEventFilterString(nef *api.NetworkEventFilter) (string, error) {
+	if networkEventProbes(nef.Type) == nil {
+		return "", fmt.Errorf("unsupported network event type %s",
+			nef.Type)
+	}
+
+	if nef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(nef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	if isTCPEventType(nef.Type) {
+		err = expr.Validate(tcpEventTypes)
 		if err != nil {
-			glog.V(1).Infof("Bad network filter expression: %s", err)
-			return
+			return "", err
 		}
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
 
-		filterString = expr.KernelFilterString()
+func (nfs *networkFilterSet) add(nef *api.NetworkEventFilter) {
+	filterString, err := networkEventFilterString(nef)
+	if err != nil {
+		glog.V(1).Infof("Invalid network event filter: %s", err)
+		return
 	}
 
 	switch nef.Type {
@@ -276,6 +534,21 @@ func (nfs *networkFilterSet) add(nef *api.NetworkEventFilter) {
 			nfs.sendtoResultFilters = make(map[string]int)
 		}
 		nfs.sendtoResultFilters[filterString]++
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_SOCKET:
+		if nfs.socketFilters == nil {
+			nfs.socketFilters = make(map[string]int)
+		}
+		nfs.socketFilters[filterString]++
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_STATE_CHANGE:
+		if nfs.tcpFilters == nil {
+			nfs.tcpFilters = make(map[api.NetworkEventType]map[string]int)
+		}
+		if nfs.tcpFilters[nef.Type] == nil {
+			nfs.tcpFilters[nef.Type] = make(map[string]int)
+		}
+		nfs.tcpFilters[nef.Type][filterString]++
 	}
 }
 
@@ -299,33 +572,43 @@ func fullFilterString(filters map[string]int) (string, bool) {
 	return "", false
 }
 
-func registerEvent(monitor *perf.EventMonitor, eventMap subscriptionMap, name string, fn perf.TraceEventDecoderFn, filters map[string]int) {
+// registerEvent registers a tracepoint for a subscription's network events,
+// returning the subscription's entry for it, or nil if it isn't registered.
+func registerEvent(sensor *Sensor, eventMap subscriptionMap, name string, fn perf.TraceEventDecoderFn, filters map[string]int) *subscription {
 	f, active := fullFilterString(filters)
 	if !active {
-		return
+		return nil
 	}
 
-	eventID, err := monitor.RegisterTracepoint(name, fn, perf.WithFilter(f))
+	eventID, err := sensor.registerTracepoint(name, fn, perf.WithFilter(f))
 	if err != nil {
 		glog.Warningf("Could not register tracepoint %s: %v", name, err)
-	} else {
-		eventMap[eventID] = &subscription{}
+		sensor.probeFailed(name, err)
+		return nil
 	}
+	sub := &subscription{}
+	eventMap[eventID] = sub
+	return sub
 }
 
-func registerKprobe(monitor *perf.EventMonitor, eventMap subscriptionMap, symbol string, fetchargs string, fn perf.TraceEventDecoderFn, filters map[string]int) {
+// registerKprobe registers a kprobe for a subscription's network events,
+// returning the subscription's entry for it, or nil if it isn't registered.
+func registerKprobe(sensor *Sensor, eventMap subscriptionMap, symbol string, fetchargs string, fn perf.TraceEventDecoderFn, filters map[string]int) *subscription {
 	f, active := fullFilterString(filters)
 	if !active {
-		return
+		return nil
 	}
 
-	eventID, err := monitor.RegisterKprobe(symbol, false, fetchargs, fn,
+	eventID, err := sensor.registerKprobe(symbol, false, fetchargs, fn,
 		perf.WithFilter(f))
 	if err != nil {
 		glog.Warningf("Could not register network kprobe %s", symbol)
-	} else {
-		eventMap[eventID] = &subscription{}
+		sensor.probeFailed(symbol, err)
+		return nil
 	}
+	sub := &subscription{}
+	eventMap[eventID] = sub
+	return sub
 }
 
 func registerNetworkEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.NetworkEventFilter) {
@@ -338,33 +621,55 @@ func registerNetworkEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 		sensor: sensor,
 	}
 
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_enter_accept", f.decodeSysEnterAccept, nfs.acceptAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_accept", f.decodeSysExitAccept, nfs.acceptResultFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_enter_accept4", f.decodeSysEnterAccept, nfs.acceptAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_accept4", f.decodeSysExitAccept, nfs.acceptResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_socket", f.decodeSysEnterSocket, nfs.socketFilters)
 
-	registerKprobe(sensor.monitor, eventMap, networkKprobeBindSymbol, networkKprobeBindFetchargs, f.decodeSysBind, nfs.bindAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_bind", f.decodeSysExitBind, nfs.bindResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_accept", f.decodeSysEnterAccept, nfs.acceptAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_accept", f.decodeSysExitAccept, nfs.acceptResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_accept4", f.decodeSysEnterAccept, nfs.acceptAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_accept4", f.decodeSysExitAccept, nfs.acceptResultFilters)
 
-	registerKprobe(sensor.monitor, eventMap, networkKprobeConnectSymbol, networkKprobeConnectFetchargs, f.decodeSysConnect, nfs.connectAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_connect", f.decodeSysExitConnect, nfs.connectResultFilters)
+	registerKprobe(sensor, eventMap, networkKprobeBindSymbol, networkKprobeBindFetchargs, f.decodeSysBind, nfs.bindAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_bind", f.decodeSysExitBind, nfs.bindResultFilters)
+
+	// The peers of unix domain socket connect attempts are learned from
+	// binds and listens for as long as connect attempts are registered
+	sub := registerKprobe(sensor, eventMap, networkKprobeConnectSymbol, networkKprobeConnectFetchargs, f.decodeSysConnect, nfs.connectAttemptFilters)
+	if sub != nil && sensor.unixSockets != nil && sensor.unixSockets.register() {
+		sub.unregister = func(uint64, *subscription) {
+			sensor.unixSockets.unregister()
+		}
+	}
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_connect", f.decodeSysExitConnect, nfs.connectResultFilters)
 
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_enter_listen", f.decodeSysEnterListen, nfs.listenAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_listen", f.decodeSysExitListen, nfs.listenResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_listen", f.decodeSysEnterListen, nfs.listenAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_listen", f.decodeSysExitListen, nfs.listenResultFilters)
 
 	// There are two additional system calls added in Linux 3.0 that are of
 	// interest, but there's no way to get all of the data without eBPF
 	// support, so don't bother with them for now.
 
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_enter_recvfrom", f.decodeSysEnterRecvfrom, nfs.recvfromAttemptFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_enter_recvmsg", f.decodeSysEnterRecvfrom, nfs.recvfromAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_recvfrom", f.decodeSysEnterRecvfrom, nfs.recvfromAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_enter_recvmsg", f.decodeSysEnterRecvfrom, nfs.recvfromAttemptFilters)
+
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_recvfrom", f.decodeSysExitRecvfrom, nfs.recvfromResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_recvmsg", f.decodeSysExitRecvfrom, nfs.recvfromResultFilters)
 
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_recvfrom", f.decodeSysExitRecvfrom, nfs.recvfromResultFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_recvmsg", f.decodeSysExitRecvfrom, nfs.recvfromResultFilters)
+	registerKprobe(sensor, eventMap, networkKprobeSendmsgSymbol, networkKprobeSendmsgFetchargs, f.decodeSysSendto, nfs.sendtoAttemptFilters)
+	registerKprobe(sensor, eventMap, networkKprobeSendtoSymbol, networkKprobeSendtoFetchargs, f.decodeSysSendto, nfs.sendtoAttemptFilters)
 
-	registerKprobe(sensor.monitor, eventMap, networkKprobeSendmsgSymbol, networkKprobeSendmsgFetchargs, f.decodeSysSendto, nfs.sendtoAttemptFilters)
-	registerKprobe(sensor.monitor, eventMap, networkKprobeSendtoSymbol, networkKprobeSendtoFetchargs, f.decodeSysSendto, nfs.sendtoAttemptFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_sendmsg", f.decodeSysExitSendto, nfs.sendtoResultFilters)
+	registerEvent(sensor, eventMap, "syscalls/sys_exit_sendto", f.decodeSysExitSendto, nfs.sendtoResultFilters)
 
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_sendmsg", f.decodeSysExitSendto, nfs.sendtoResultFilters)
-	registerEvent(sensor.monitor, eventMap, "syscalls/sys_exit_sendto", f.decodeSysExitSendto, nfs.sendtoResultFilters)
+	// All TCP connection events come from the same tracepoint, so they
+	// share a single registration filtered by the states of interest.
+	// The owners of sockets are tracked for as long as it is registered.
+	if filter, active := tcpFilterString(nfs.tcpFilters); active {
+		sub = registerEvent(sensor, eventMap, tcpSetStateTracepoint,
+			f.decodeInetSockSetState, map[string]int{filter: 1})
+		if sub != nil && sensor.tcpSockets != nil && sensor.tcpSockets.register() {
+			sub.unregister = func(uint64, *subscription) {
+				sensor.tcpSockets.unregister()
+			}
+		}
+	}
 }
@@ -0,0 +1,155 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bytes"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func newTestNetworkFilter() *networkFilter {
+	return &networkFilter{
+		sensor: &Sensor{
+			processCache: ProcessInfoCache{
+				cache: newMapTaskCache(),
+			},
+		},
+	}
+}
+
+func sockaddrSample(family uint16, port uint16, addr uint32, high, low uint64) perf.TraceEventSampleData {
+	return perf.TraceEventSampleData{
+		"common_pid":     int32(100),
+		"fd":             uint64(3),
+		"sa_family":      family,
+		"sin_port":       port,
+		"sin_addr":       addr,
+		"sun_path":       "",
+		"sin6_port":      port,
+		"sin6_addr_high": high,
+		"sin6_addr_low":  low,
+	}
+}
+
+func TestSockaddrAddresses(t *testing.T) {
+	f := newTestNetworkFilter()
+
+	// sockaddr_nl with nl_pid 0 and nl_groups 0x11
+	i, _ := f.decodeSysConnect(&perf.SampleRecord{},
+		sockaddrSample(afNetlink, 0, 0, 0x11, 0))
+	addr := i.(*api.TelemetryEvent).GetNetwork().Address
+	if a := addr.GetNetlinkAddress(); a == nil ||
+		addr.Family != api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_NETLINK ||
+		a.Pid != 0 || a.Groups != 0x11 {
+		t.Errorf("Unexpected netlink address %+v", addr)
+	}
+
+	// sockaddr_ll for ETH_P_ALL on ifindex 2, ARPHRD_ETHER,
+	// PACKET_HOST, with a 6 byte hardware address
+	i, _ = f.decodeSysBind(&perf.SampleRecord{},
+		sockaddrSample(afPacket, 0x0300, 2,
+			0x5634120206000001, 0x9abc))
+	addr = i.(*api.TelemetryEvent).GetNetwork().Address
+	a := addr.GetPacketAddress()
+	if a == nil ||
+		addr.Family != api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_PACKET ||
+		a.Protocol != 0x0300 || a.Ifindex != 2 || a.Hatype != 1 ||
+		a.Pkttype != 0 ||
+		!bytes.Equal(a.Address, []byte{0x02, 0x12, 0x34, 0x56, 0xbc, 0x9a}) {
+		t.Errorf("Unexpected packet address %+v", addr)
+	}
+
+	// sockaddr_vm for port 1024 on cid 3
+	i, _ = f.decodeSysConnect(&perf.SampleRecord{},
+		sockaddrSample(afVsock, 0, 1024, 3, 0))
+	addr = i.(*api.TelemetryEvent).GetNetwork().Address
+	if a := addr.GetVsockAddress(); a == nil ||
+		addr.Family != api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_VSOCK ||
+		a.Cid != 3 || a.Port != 1024 {
+		t.Errorf("Unexpected vsock address %+v", addr)
+	}
+
+	// Unsupported families are dropped
+	i, _ = f.decodeSysConnect(&perf.SampleRecord{},
+		sockaddrSample(31, 0, 0, 0, 0))
+	if i.(*api.TelemetryEvent) != nil {
+		t.Errorf("Expected no event for AF_BLUETOOTH, got %+v", i)
+	}
+}
+
+func TestSocketEvents(t *testing.T) {
+	f := newTestNetworkFilter()
+
+	// socket(AF_PACKET, SOCK_RAW|SOCK_CLOEXEC, htons(ETH_P_ALL))
+	i, err := f.decodeSysEnterSocket(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"family":     uint64(afPacket),
+			"type":       uint64(0x80003),
+			"protocol":   uint64(0x0300),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nev := i.(*api.TelemetryEvent).GetNetwork()
+	if nev == nil ||
+		nev.Type != api.NetworkEventType_NETWORK_EVENT_TYPE_SOCKET ||
+		nev.SocketFamily != afPacket || nev.SocketType != 0x80003 ||
+		nev.SocketProtocol != 0x0300 || nev.Address != nil {
+		t.Errorf("Unexpected socket event %+v", nev)
+	}
+
+	values := networkEventValues(nev)
+	if values["family"] != uint64(afPacket) ||
+		values["type"] != uint64(0x80003) ||
+		values["protocol"] != uint64(0x0300) {
+		t.Errorf("Unexpected socket event values %+v", values)
+	}
+}
+
+func TestNetworkAddressIP(t *testing.T) {
+	data := sockaddrSample(afInet, 0x5000, 0x020011ac, 0, 0)
+	nev := newTestNetworkFilter().newNetworkEvent(
+		api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT,
+		&perf.SampleRecord{}, data).GetNetwork()
+	if ip := networkAddressIP(nev.Address); ip.String() != "172.17.0.2" {
+		t.Errorf("Unexpected IPv4 address %s", ip)
+	}
+
+	// ::ffff:172.17.0.2
+	data = sockaddrSample(afInet6, 0x5000, 0, 0, 0x020011acffff0000)
+	nev = newTestNetworkFilter().newNetworkEvent(
+		api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT,
+		&perf.SampleRecord{}, data).GetNetwork()
+	if ip := networkAddressIP(nev.Address); ip.String() != "172.17.0.2" {
+		t.Errorf("Unexpected IPv4-mapped address %s", ip)
+	}
+
+	data = sockaddrSample(afInet6, 0x5000, 0, 0xb80d0120, 0x0200000000000000)
+	nev = newTestNetworkFilter().newNetworkEvent(
+		api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT,
+		&perf.SampleRecord{}, data).GetNetwork()
+	if ip := networkAddressIP(nev.Address); ip.String() != "2001:db8::2" {
+		t.Errorf("Unexpected IPv6 address %s", ip)
+	}
+
+	if ip := networkAddressIP(nil); ip != nil {
+		t.Errorf("Unexpected address %s", ip)
+	}
+}
@@ -17,13 +17,16 @@ package sensor
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"syscall"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/gobwas/glob"
 	"github.com/golang/glog"
 
 	"golang.org/x/sys/unix"
@@ -34,6 +37,31 @@ const (
 	exitFetchargs = "code=%di:s64"
 )
 
+// redactedValue replaces the values of redacted environment variables.
+const redactedValue = "[REDACTED]"
+
+var (
+	execEnvironmentFilter     *environmentFilter
+	execEnvironmentFilterOnce sync.Once
+)
+
+var processEventTypes = expression.FieldTypeMap{
+	"filename": int32(api.ValueType_STRING),
+	"code":     int32(api.ValueType_SINT64),
+}
+
+func processEventValues(pev *api.ProcessEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{}
+
+	switch pev.Type {
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
+		values["filename"] = pev.ExecFilename
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
+		values["code"] = int64(pev.ExitCode)
+	}
+	return values
+}
+
 type processFilter struct {
 	sensor *Sensor
 }
@@ -62,10 +90,15 @@ func (f *processFilter) decodeSchedProcessExec(sample *perf.SampleRecord, data p
 	filename := data["filename"].(string)
 
 	// Get the command-line from the process info cache. If it's not there
-	// for whatever reason, fallback to using procfs
+	// for whatever reason, or it may have been cut short, fallback to
+	// using procfs
 	commandLine, _ := f.sensor.processCache.ProcessCommandLine(int(hostPid))
-	if commandLine == nil || len(commandLine) == 0 {
+	if len(commandLine) == 0 {
 		commandLine = sys.HostProcFS().CommandLine(int(hostPid))
+	} else if commandLineTruncated(commandLine) {
+		if cl := sys.HostProcFS().CommandLine(int(hostPid)); len(cl) > len(commandLine) {
+			commandLine = cl
+		}
 	}
 
 	ev := f.sensor.NewEventFromSample(sample, data)
@@ -74,6 +107,11 @@ func (f *processFilter) decodeSchedProcessExec(sample *perf.SampleRecord, data p
 		ExecFilename:    filename,
 		ExecCommandLine: commandLine,
 	}
+	if config.Sensor.ExecEnvironment {
+		environment, _ := f.sensor.processCache.ProcessEnvironment(
+			int(hostPid))
+		processEvent.ExecEnvironment = execEnvironment(environment)
+	}
 
 	ev.Event = &api.TelemetryEvent_Process{
 		Process: processEvent,
@@ -82,6 +120,82 @@ func (f *processFilter) decodeSchedProcessExec(sample *perf.SampleRecord, data p
 	return ev, nil
 }
 
+// environmentFilter selects the environment variables captured in exec
+// events and redacts the values of those that may hold secrets. Names are
+// matched against the redaction patterns without regard to case, so that
+// secrets in variables like github_token are redacted too.
+type environmentFilter struct {
+	allow  []glob.Glob
+	redact []glob.Glob
+}
+
+func compileGlobs(patterns []string) []glob.Glob {
+	var globs []glob.Glob
+	for _, p := range patterns {
+		g, err := glob.Compile(p)
+		if err != nil {
+			glog.Warningf("Invalid environment variable pattern %q: %s",
+				p, err)
+			continue
+		}
+		globs = append(globs, g)
+	}
+	return globs
+}
+
+func matchGlobs(globs []glob.Glob, s string) bool {
+	for _, g := range globs {
+		if g.Match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func newEnvironmentFilter(allow, redact []string) *environmentFilter {
+	upper := make([]string, len(redact))
+	for i, p := range redact {
+		upper[i] = strings.ToUpper(p)
+	}
+
+	return &environmentFilter{
+		allow:  compileGlobs(allow),
+		redact: compileGlobs(upper),
+	}
+}
+
+// filter returns the environment variables from environment that are
+// allowed, with the values of redacted variables replaced.
+func (f *environmentFilter) filter(environment []string) []string {
+	var result []string
+	for _, v := range environment {
+		name := v
+		if i := strings.IndexByte(v, '='); i >= 0 {
+			name = v[:i]
+		}
+		if len(f.allow) > 0 && !matchGlobs(f.allow, name) {
+			continue
+		}
+		if matchGlobs(f.redact, strings.ToUpper(name)) {
+			v = name + "=" + redactedValue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// execEnvironment returns the environment captured from execve(), filtered
+// as configured.
+func execEnvironment(environment []string) []string {
+	execEnvironmentFilterOnce.Do(func() {
+		execEnvironmentFilter = newEnvironmentFilter(
+			config.Sensor.ExecEnvironmentAllow,
+			config.Sensor.ExecEnvironmentRedact)
+	})
+
+	return execEnvironmentFilter.filter(environment)
+}
+
 func (f *processFilter) decodeDoExit(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
 	var exitStatus int
 	var exitSignal syscall.Signal
@@ -156,6 +270,52 @@ func rewriteProcessEventFilter(pef *api.ProcessEventFilter) {
 	}
 }
 
+// processEventProbes returns the names of the tracepoints and kprobes that
+// process events of the given type are collected from.
+func processEventProbes(t api.ProcessEventType) []string {
+	switch t {
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_FORK:
+		return []string{"sched/sched_process_fork"}
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
+		return []string{"sched/sched_process_exec"}
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
+		return []string{exitSymbol}
+	}
+	return nil
+}
+
+// processEventFilterString returns the kernel filter string for a process
+// event filter, or an error if the filter is invalid. An empty string is
+// returned for filters that match all events of their type.
+func processEventFilterString(pef *api.ProcessEventFilter) (string, error) {
+	// Translate deprecated fields into an expression
+	rewriteProcessEventFilter(pef)
+
+	switch pef.Type {
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_FORK:
+		return "", nil
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+		api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
+		if pef.FilterExpression == nil {
+			return "", nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported process event type %s",
+			pef.Type)
+	}
+
+	expr, err := expression.NewExpression(pef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
 func registerProcessEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.ProcessEventFilter) {
 	forkFilter := false
 	execFilters := make(map[string]bool)
@@ -164,48 +324,27 @@ func registerProcessEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 	exitWildcard := false
 
 	for _, pef := range events {
-		// Translate deprecated fields into an expression
-		rewriteProcessEventFilter(pef)
+		s, err := processEventFilterString(pef)
+		if err != nil {
+			glog.V(1).Infof("Invalid process event filter: %s", err)
+			continue
+		}
 
 		switch pef.Type {
 		case api.ProcessEventType_PROCESS_EVENT_TYPE_FORK:
 			forkFilter = true
 		case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
-			if pef.FilterExpression == nil {
+			if len(s) == 0 {
 				execWildcard = true
 			} else {
-				expr, err := expression.NewExpression(pef.FilterExpression)
-				if err != nil {
-					glog.V(1).Infof("Invalid process event filter: %s", err)
-					continue
-				}
-				err = expr.ValidateKernelFilter()
-				if err != nil {
-					glog.V(1).Infof("Invalid process event filter as kernel filter: %s", err)
-					continue
-				}
-				s := expr.KernelFilterString()
 				execFilters[s] = true
 			}
 		case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
-			if pef.FilterExpression == nil {
+			if len(s) == 0 {
 				exitWildcard = true
 			} else {
-				expr, err := expression.NewExpression(pef.FilterExpression)
-				if err != nil {
-					glog.V(1).Infof("Invalid process event filter: %s", err)
-					continue
-				}
-				err = expr.ValidateKernelFilter()
-				if err != nil {
-					glog.V(1).Infof("Invalid process event filter as kernel filter: %s", err)
-					continue
-				}
-				s := expr.KernelFilterString()
 				exitFilters[s] = true
 			}
-		default:
-			continue
 		}
 	}
 
@@ -215,12 +354,13 @@ func registerProcessEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 
 	if forkFilter {
 		eventName := "sched/sched_process_fork"
-		eventID, err := sensor.monitor.RegisterTracepoint(eventName,
+		eventID, err := sensor.registerTracepoint(eventName,
 			f.decodeSchedProcessFork)
 
 		if err != nil {
 			glog.V(1).Infof("Couldn't get %s event id: %v",
 				eventName, err)
+			sensor.probeFailed(eventName, err)
 		} else {
 			eventMap[eventID] = &subscription{}
 		}
@@ -230,11 +370,12 @@ func registerProcessEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 		filterString := processFilterString(execWildcard, execFilters)
 
 		eventName := "sched/sched_process_exec"
-		eventID, err := sensor.monitor.RegisterTracepoint(eventName,
+		eventID, err := sensor.registerTracepoint(eventName,
 			f.decodeSchedProcessExec, perf.WithFilter(filterString))
 		if err != nil {
 			glog.V(1).Infof("Couldn't get %s event id: %v",
 				eventName, err)
+			sensor.probeFailed(eventName, err)
 		} else {
 			eventMap[eventID] = &subscription{}
 		}
@@ -243,12 +384,13 @@ func registerProcessEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 	if exitWildcard || len(exitFilters) > 0 {
 		filterString := processFilterString(exitWildcard, exitFilters)
 
-		eventID, err := sensor.monitor.RegisterKprobe(exitSymbol,
+		eventID, err := sensor.registerKprobe(exitSymbol,
 			false, exitFetchargs, f.decodeDoExit,
 			perf.WithFilter(filterString))
 		if err != nil {
 			glog.Errorf("Couldn't register kprobe for %s: %s",
 				exitSymbol, err)
+			sensor.probeFailed(exitSymbol, err)
 		} else {
 			eventMap[eventID] = &subscription{}
 		}
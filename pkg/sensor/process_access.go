@@ -0,0 +1,246 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	ptraceTracepoint         = "syscalls/sys_enter_ptrace"
+	processVMReadTracepoint  = "syscalls/sys_enter_process_vm_readv"
+	processVMWriteTracepoint = "syscalls/sys_enter_process_vm_writev"
+
+	// The maximum number of processes whose PID namespaces are
+	// remembered by a processAccessFilter before they are forgotten.
+	processAccessNamespaceCacheSize = 1024
+)
+
+// The fields of each process access tracepoint that may be used in filters
+var processAccessEventTypes = map[api.ProcessAccessEventType]expression.FieldTypeMap{
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE: expression.FieldTypeMap{
+		"request": int32(api.ValueType_UINT64),
+		"pid":     int32(api.ValueType_UINT64),
+	},
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ: expression.FieldTypeMap{
+		"pid": int32(api.ValueType_UINT64),
+	},
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE: expression.FieldTypeMap{
+		"pid": int32(api.ValueType_UINT64),
+	},
+}
+
+var processAccessEventTracepoints = map[api.ProcessAccessEventType]string{
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE:   ptraceTracepoint,
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ:  processVMReadTracepoint,
+	api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE: processVMWriteTracepoint,
+}
+
+func processAccessEventValues(pev *api.ProcessAccessEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"pid": uint64(pev.TargetPid),
+	}
+	if pev.Type == api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE {
+		values["request"] = pev.PtraceRequest
+	}
+	return values
+}
+
+type processAccessFilter struct {
+	sync.Mutex
+	sensor *Sensor
+
+	// Whether calling processes are in the host's PID namespace, which
+	// never changes for a process
+	hostPIDNamespace map[string]bool // process ID : in host's namespace
+}
+
+// inHostPIDNamespace returns true if the calling process of an event is in
+// the host's PID namespace, comparing its namespace in procfs against the
+// host's, and remembering the result for the process.
+func (f *processAccessFilter) inHostPIDNamespace(ev *api.TelemetryEvent) bool {
+	f.Lock()
+	host, ok := f.hostPIDNamespace[ev.ProcessId]
+	f.Unlock()
+	if ok {
+		return host
+	}
+
+	ns := f.sensor.readNamespace(fmt.Sprintf("%d/ns/pid", ev.ProcessPid))
+	if ns == nil {
+		return false
+	}
+	if len(ev.ProcessId) > 0 {
+		f.Lock()
+		if f.hostPIDNamespace == nil ||
+			len(f.hostPIDNamespace) >= processAccessNamespaceCacheSize {
+			f.hostPIDNamespace = make(map[string]bool)
+		}
+		f.hostPIDNamespace[ev.ProcessId] = ns.Host
+		f.Unlock()
+	}
+	return ns.Host
+}
+
+// newProcessAccessEvent returns a process access event for a sample, with
+// the target process identified if possible.
+func (f *processAccessFilter) newProcessAccessEvent(
+	eventType api.ProcessAccessEventType,
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (*api.TelemetryEvent, *api.ProcessAccessEvent) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+	pev := &api.ProcessAccessEvent{
+		Type:      eventType,
+		TargetPid: int32(data["pid"].(uint64)),
+	}
+
+	// The target PID is in the caller's PID namespace, so it can only be
+	// looked up in the cache when that is the host's.
+	if f.inHostPIDNamespace(ev) {
+		pc := &f.sensor.processCache
+		if id, ok := pc.ProcessID(int(pev.TargetPid)); ok {
+			pev.TargetId = id
+		}
+		if id, ok := pc.ProcessContainerID(int(pev.TargetPid)); ok {
+			pev.TargetContainerId = id
+		}
+	}
+
+	ev.Event = &api.TelemetryEvent_ProcessAccess{
+		ProcessAccess: pev,
+	}
+
+	return ev, pev
+}
+
+func (f *processAccessFilter) decodeSysEnterPtrace(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev, pev := f.newProcessAccessEvent(
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE,
+		sample, data)
+	pev.PtraceRequest = data["request"].(uint64)
+	pev.PtraceAddr = data["addr"].(uint64)
+	pev.PtraceData = data["data"].(uint64)
+
+	return ev, nil
+}
+
+func (f *processAccessFilter) decodeSysEnterProcessVMReadv(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev, pev := f.newProcessAccessEvent(
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ,
+		sample, data)
+	pev.RemoteIovcnt = data["riovcnt"].(uint64)
+
+	return ev, nil
+}
+
+func (f *processAccessFilter) decodeSysEnterProcessVMWritev(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev, pev := f.newProcessAccessEvent(
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE,
+		sample, data)
+	pev.RemoteIovcnt = data["riovcnt"].(uint64)
+
+	return ev, nil
+}
+
+// processAccessEventFilterString returns the kernel filter string for a
+// process access event filter, or an error if the filter is invalid. An
+// empty string is returned for filters that match all events.
+func processAccessEventFilterString(pef *api.ProcessAccessEventFilter) (string, error) {
+	types, ok := processAccessEventTypes[pef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported process access event type %s",
+			pef.Type)
+	}
+
+	if pef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(pef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// processAccessEventProbes returns the names of the tracepoints that process
+// access events of the given type are collected from.
+func processAccessEventProbes(t api.ProcessAccessEventType) []string {
+	if name, ok := processAccessEventTracepoints[t]; ok {
+		return []string{name}
+	}
+	return nil
+}
+
+func registerProcessAccessEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.ProcessAccessEventFilter) {
+	filters := make(map[api.ProcessAccessEventType]map[string]int)
+	for _, pef := range events {
+		s, err := processAccessEventFilterString(pef)
+		if err != nil {
+			glog.V(1).Infof("Invalid process access event filter: %s", err)
+			continue
+		}
+		if filters[pef.Type] == nil {
+			filters[pef.Type] = make(map[string]int)
+		}
+		filters[pef.Type][s]++
+	}
+
+	f := &processAccessFilter{
+		sensor: sensor,
+	}
+
+	decoders := map[api.ProcessAccessEventType]perf.TraceEventDecoderFn{
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE:   f.decodeSysEnterPtrace,
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ:  f.decodeSysEnterProcessVMReadv,
+		api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE: f.decodeSysEnterProcessVMWritev,
+	}
+
+	for t, m := range filters {
+		filterString, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+
+		eventName := processAccessEventTracepoints[t]
+		eventID, err := sensor.registerTracepoint(eventName,
+			decoders[t], perf.WithFilter(filterString))
+		if err != nil {
+			glog.V(1).Infof("Couldn't get %s event id: %v",
+				eventName, err)
+			sensor.probeFailed(eventName, err)
+			continue
+		}
+
+		eventMap[eventID] = &subscription{}
+	}
+}
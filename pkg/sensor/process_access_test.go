@@ -0,0 +1,140 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"os"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestProcessAccessEvents(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+	}
+	f := processAccessFilter{
+		sensor: s,
+	}
+
+	cID := "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"
+	s.processCache.cache.InsertTask(200, task{pid: 200, tgid: 200,
+		containerID: cID})
+
+	const ptraceAttach = 16
+	i, err := f.decodeSysEnterPtrace(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(os.Getpid()),
+			"request":    uint64(ptraceAttach),
+			"pid":        uint64(200),
+			"addr":       uint64(0),
+			"data":       uint64(0),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pev := i.(*api.TelemetryEvent).GetProcessAccess()
+	if pev == nil ||
+		pev.Type != api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE ||
+		pev.TargetPid != 200 || pev.PtraceRequest != ptraceAttach {
+		t.Fatalf("Unexpected ptrace event %+v", pev)
+	}
+
+	// The target is only identified when this process is in the host's
+	// PID namespace.
+	if len(pev.TargetId) > 0 && pev.TargetContainerId != cID {
+		t.Errorf("Expected target container %s, got %s",
+			cID, pev.TargetContainerId)
+	}
+
+	i, err = f.decodeSysEnterProcessVMReadv(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(os.Getpid()),
+			"pid":        uint64(200),
+			"lvec":       uint64(0),
+			"liovcnt":    uint64(1),
+			"rvec":       uint64(0),
+			"riovcnt":    uint64(2),
+			"flags":      uint64(0),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pev = i.(*api.TelemetryEvent).GetProcessAccess()
+	if pev == nil ||
+		pev.Type != api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_READ ||
+		pev.TargetPid != 200 || pev.RemoteIovcnt != 2 {
+		t.Errorf("Unexpected process_vm_readv event %+v", pev)
+	}
+}
+
+func TestInHostPIDNamespace(t *testing.T) {
+	link, err := os.Readlink("/proc/self/ns/pid")
+	if err != nil {
+		t.Skip(err)
+	}
+	_, inode, _ := parseNamespaceLink(link)
+
+	s := &Sensor{
+		hostNamespaces: map[string]uint64{"pid": inode},
+	}
+	f := processAccessFilter{
+		sensor: s,
+	}
+	ev := &api.TelemetryEvent{
+		ProcessPid: int32(os.Getpid()),
+		ProcessId:  "b5ac8d4a6a1cd3c3f1a1ae2a4e4ac7d0d3e6f1e1f8a6e6b1c1c4e1a6b5c5d4e3",
+	}
+	if !f.inHostPIDNamespace(ev) {
+		t.Error("Expected process to be in host PID namespace")
+	}
+
+	// A process's PID namespace is only read once
+	s.hostNamespaces["pid"] = inode + 1
+	if !f.inHostPIDNamespace(ev) {
+		t.Error("Expected remembered PID namespace")
+	}
+	ev.ProcessId = ""
+	if f.inHostPIDNamespace(ev) {
+		t.Error("Expected process not to be in host PID namespace")
+	}
+}
+
+func TestProcessAccessEventFilterString(t *testing.T) {
+	s, err := processAccessEventFilterString(&api.ProcessAccessEventFilter{
+		Type: api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_PTRACE,
+		FilterExpression: expression.Equal(
+			expression.Identifier("request"),
+			expression.Value(uint64(16))),
+	})
+	if err != nil || s != "request == 16" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+
+	_, err = processAccessEventFilterString(&api.ProcessAccessEventFilter{
+		Type: api.ProcessAccessEventType_PROCESS_ACCESS_EVENT_TYPE_VM_WRITE,
+		FilterExpression: expression.Equal(
+			expression.Identifier("request"),
+			expression.Value(uint64(16))),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
@@ -29,7 +29,9 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 
+	api "github.com/capsule8/capsule8/api/v0"
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
@@ -39,9 +41,12 @@ import (
 
 const (
 	commitCredsAddress = "commit_creds"
-	commitCredsArgs    = "usage=+0(%di):u64 uid=+8(%di):u32 gid=+12(%di):u32"
+	commitCredsArgs    = "usage=+0(%di):u64 "
 
-	execveArgCount = 6
+	// Elements of argv and envp passed to execve() are fetched in chunks
+	// by a chain of kprobes attached to the same function, because the
+	// amount of data that a single kprobe can fetch is limited.
+	execveArgsPerProbe = 8
 
 	doExecveAddress         = "do_execve"
 	doExecveatAddress       = "do_execveat"
@@ -56,11 +61,13 @@ var (
 )
 
 type taskCache interface {
+	Size() int
 	LookupTask(int, *task) bool
 	InsertTask(int, task)
 	SetTaskContainerID(int, string)
 	SetTaskCredentials(int, cred)
 	SetTaskCommandLine(int, []string)
+	SetTaskEnvironment(int, []string)
 }
 
 type arrayTaskCache struct {
@@ -73,6 +80,16 @@ func newArrayTaskCache(size uint) *arrayTaskCache {
 	}
 }
 
+func (c *arrayTaskCache) Size() int {
+	n := 0
+	for i := range c.entries {
+		if c.entries[i].tgid != 0 {
+			n++
+		}
+	}
+	return n
+}
+
 func (c *arrayTaskCache) LookupTask(pid int, t *task) bool {
 	*t = c.entries[pid]
 	ok := t.tgid != 0
@@ -99,6 +116,7 @@ func (c *arrayTaskCache) SetTaskContainerID(pid int, cID string) {
 func (c *arrayTaskCache) SetTaskCredentials(pid int, creds cred) {
 	glog.V(10).Infof("SetTaskCredentials(%d) = %+v", pid, creds)
 
+	c.entries[pid].prevCreds = c.entries[pid].creds
 	c.entries[pid].creds = creds
 }
 
@@ -107,6 +125,12 @@ func (c *arrayTaskCache) SetTaskCommandLine(pid int, commandLine []string) {
 	c.entries[pid].commandLine = commandLine
 }
 
+func (c *arrayTaskCache) SetTaskEnvironment(pid int, environment []string) {
+	glog.V(10).Infof("SetTaskEnvironment(%d) = %d variables", pid,
+		len(environment))
+	c.entries[pid].environment = environment
+}
+
 type mapTaskCache struct {
 	sync.Mutex
 	entries map[int]task
@@ -118,6 +142,13 @@ func newMapTaskCache() *mapTaskCache {
 	}
 }
 
+func (c *mapTaskCache) Size() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.entries)
+}
+
 func (c *mapTaskCache) LookupTask(pid int, t *task) (ok bool) {
 	c.Lock()
 	defer c.Unlock()
@@ -161,6 +192,7 @@ func (c *mapTaskCache) SetTaskCredentials(pid int, creds cred) {
 	defer c.Unlock()
 	t, ok := c.entries[pid]
 	if ok {
+		t.prevCreds = t.creds
 		t.creds = creds
 		c.entries[pid] = t
 	}
@@ -178,11 +210,39 @@ func (c *mapTaskCache) SetTaskCommandLine(pid int, commandLine []string) {
 	}
 }
 
+func (c *mapTaskCache) SetTaskEnvironment(pid int, environment []string) {
+	glog.V(10).Infof("SetTaskEnvironment(%d) = %d variables", pid,
+		len(environment))
+
+	c.Lock()
+	defer c.Unlock()
+	t, ok := c.entries[pid]
+	if ok {
+		t.environment = environment
+		c.entries[pid] = t
+	}
+}
+
 // ProcessInfoCache is an object that caches process information. It is
 // maintained automatically via an existing sensor object.
 type ProcessInfoCache struct {
-	sensor *Sensor
-	cache  taskCache
+	sensor  *Sensor
+	cache   taskCache
+	lookups *processCacheLookups
+}
+
+// processCacheLookups counts the lookups of processes in the cache that
+// found the process and those that did not.
+type processCacheLookups struct {
+	hits   uint64
+	misses uint64
+}
+
+// processCacheStats describes the size and effectiveness of the cache.
+type processCacheStats struct {
+	size   int
+	hits   uint64
+	misses uint64
 }
 
 // NewProcessInfoCache creates a new process information cache object. An
@@ -197,7 +257,8 @@ func NewProcessInfoCache(sensor *Sensor) ProcessInfoCache {
 	})
 
 	cache := ProcessInfoCache{
-		sensor: sensor,
+		sensor:  sensor,
+		lookups: &processCacheLookups{},
 	}
 
 	maxPid := proc.MaxPid()
@@ -209,21 +270,27 @@ func NewProcessInfoCache(sensor *Sensor) ProcessInfoCache {
 
 	// Register with the sensor's global event monitor...
 	eventName := "task/task_newtask"
-	_, err := sensor.monitor.RegisterTracepoint(eventName,
+	_, err := sensor.registerTracepoint(eventName,
 		cache.decodeNewTask)
 	if err != nil {
 		glog.Fatalf("Couldn't register event %s: %s", eventName, err)
 	}
 
 	// Attach kprobe on commit_creds to capture task privileges
-	_, err = sensor.monitor.RegisterKprobe(commitCredsAddress, false,
-		commitCredsArgs, cache.decodeCommitCreds)
+	_, err = sensor.registerKprobe(commitCredsAddress, false,
+		commitCredsArgs+credentialsFetchargs(runningKernelVersion()),
+		cache.decodeCommitCreds)
+	if err != nil {
+		glog.Warningf("Couldn't register kprobe %s: %s",
+			commitCredsAddress, err)
+		sensor.probeFailed(commitCredsAddress, err)
+	}
 
 	// Attach a probe for task_renamse involving the runc
 	// init processes to trigger containerID lookups
 	f := "oldcomm == exe || oldcomm == runc:[2:INIT]"
 	eventName = "task/task_rename"
-	_, err = sensor.monitor.RegisterTracepoint(eventName,
+	_, err = sensor.registerTracepoint(eventName,
 		cache.decodeRuncTaskRename, perf.WithFilter(f))
 	if err != nil {
 		glog.Fatalf("Couldn't register event %s: %s", eventName, err)
@@ -235,37 +302,148 @@ func NewProcessInfoCache(sensor *Sensor) ProcessInfoCache {
 	// if that succeeds, it's the only one we need. Otherwise, we need a
 	// bunch of others to try to hit everything. We may end up getting
 	// duplicate events, which is ok.
-	_, err = sensor.monitor.RegisterKprobe(doExecveatCommonAddress, false,
-		makeExecveFetchArgs("dx"), cache.decodeExecve)
+	err = cache.registerExecveProbes(sensor, doExecveatCommonAddress,
+		"dx", "cx")
 	if err != nil {
-		_, err = sensor.monitor.RegisterKprobe(sysExecveAddress, false,
-			makeExecveFetchArgs("si"), cache.decodeExecve)
+		err = cache.registerExecveProbes(sensor, sysExecveAddress,
+			"si", "dx")
 		if err != nil {
 			glog.Fatalf("Couldn't register event %s: %s",
 				sysExecveAddress, err)
 		}
-		_, _ = sensor.monitor.RegisterKprobe(doExecveAddress, false,
-			makeExecveFetchArgs("si"), cache.decodeExecve)
-
-		_, err = sensor.monitor.RegisterKprobe(sysExecveatAddress, false,
-			makeExecveFetchArgs("dx"), cache.decodeExecve)
-		if err == nil {
-			_, _ = sensor.monitor.RegisterKprobe(doExecveatAddress, false,
-				makeExecveFetchArgs("dx"), cache.decodeExecve)
+		_ = cache.registerExecveProbes(sensor, doExecveAddress,
+			"si", "dx")
+
+		err = cache.registerExecveProbes(sensor, sysExecveatAddress,
+			"dx", "cx")
+		if err != nil {
+			sensor.probeFailed(sysExecveatAddress, err)
+		} else {
+			_ = cache.registerExecveProbes(sensor,
+				doExecveatAddress, "dx", "cx")
 		}
 	}
 
 	return cache
 }
 
-func makeExecveFetchArgs(reg string) string {
-	parts := make([]string, execveArgCount)
-	for i := 0; i < execveArgCount; i++ {
-		parts[i] = fmt.Sprintf("argv%d=+0(+%d(%%%s)):string", i, i*8, reg)
+// execveArgCount returns the maximum number of elements of argv captured
+// from execve().
+func execveArgCount() int {
+	if config.Sensor.ExecArgCount < 1 {
+		return 1
+	}
+	return config.Sensor.ExecArgCount
+}
+
+// commandLineTruncated returns true if a command-line captured from execve()
+// may be missing elements of argv, either because it reached the configured
+// limit or because a kprobe fetching a later chunk of argv did not fire.
+func commandLineTruncated(commandLine []string) bool {
+	n := len(commandLine)
+	return n >= execveArgCount() || (n > 0 && n%execveArgsPerProbe == 0)
+}
+
+// execveEnvironmentCount returns the maximum number of elements of envp
+// captured from execve().
+func execveEnvironmentCount() int {
+	if config.Sensor.ExecEnvironmentCount < 1 {
+		return 1
+	}
+	return config.Sensor.ExecEnvironmentCount
+}
+
+// registerExecveProbes registers the chains of kprobes on symbol that fetch
+// argv and envp, which are passed to symbol in registers argvReg and
+// envpReg. envp is only fetched if the environment is captured in exec
+// events. Only an error registering the first kprobe fetching argv is
+// returned; if a later one fails, long command-lines are completed from
+// procfs instead, and environments are cut short.
+func (pc *ProcessInfoCache) registerExecveProbes(
+	sensor *Sensor,
+	symbol, argvReg, envpReg string,
+) error {
+	err := registerExecveChain(sensor, symbol, "argv", argvReg,
+		execveArgCount(), pc.makeExecveDecoder)
+	if err != nil {
+		return err
+	}
+
+	if config.Sensor.ExecEnvironment {
+		err = registerExecveChain(sensor, symbol, "envp", envpReg,
+			execveEnvironmentCount(), pc.makeExecveEnvironmentDecoder)
+		if err != nil {
+			glog.Warningf("Couldn't register kprobe for envp on %s: %s",
+				symbol, err)
+		}
+	}
+
+	return nil
+}
+
+// registerExecveChain registers the chain of kprobes on symbol that fetch up
+// to n elements of the array named name, which is passed to symbol in
+// register reg. Only an error registering the first kprobe in the chain is
+// returned.
+func registerExecveChain(
+	sensor *Sensor,
+	symbol, name, reg string,
+	n int,
+	makeDecoder func(first, count int) perf.TraceEventDecoderFn,
+) error {
+	for first := 0; first < n; first += execveArgsPerProbe {
+		count := n - first
+		if count > execveArgsPerProbe {
+			count = execveArgsPerProbe
+		}
+		_, err := sensor.registerKprobe(symbol, false,
+			makeExecveFetchArgs(name, reg, first, count),
+			makeDecoder(first, count))
+		if err != nil {
+			if first == 0 {
+				return err
+			}
+			glog.Warningf("Couldn't register kprobe for %s[%d] on %s: %s",
+				name, first, symbol, err)
+			break
+		}
+	}
+
+	return nil
+}
+
+func makeExecveFetchArgs(name, reg string, first, count int) string {
+	parts := make([]string, count)
+	for i := 0; i < count; i++ {
+		parts[i] = fmt.Sprintf("%s%d=+0(+%d(%%%s)):string",
+			name, i, (first+i)*8, reg)
 	}
 	return strings.Join(parts, " ")
 }
 
+func (pc *ProcessInfoCache) countLookup(ok bool) {
+	if pc.lookups == nil {
+		return
+	}
+	if ok {
+		atomic.AddUint64(&pc.lookups.hits, 1)
+	} else {
+		atomic.AddUint64(&pc.lookups.misses, 1)
+	}
+}
+
+func (pc *ProcessInfoCache) stats() processCacheStats {
+	var stats processCacheStats
+	if pc.cache != nil {
+		stats.size = pc.cache.Size()
+	}
+	if pc.lookups != nil {
+		stats.hits = atomic.LoadUint64(&pc.lookups.hits)
+		stats.misses = atomic.LoadUint64(&pc.lookups.misses)
+	}
+	return stats
+}
+
 // lookupLeader finds the task info for the thread group leader of the given pid
 func (pc *ProcessInfoCache) lookupLeader(pid int) (task, bool) {
 	var t task
@@ -282,6 +460,7 @@ func (pc *ProcessInfoCache) lookupLeader(pid int) (task, bool) {
 // is derived inside or outside a container.
 func (pc *ProcessInfoCache) ProcessID(pid int) (string, bool) {
 	leader, ok := pc.lookupLeader(pid)
+	pc.countLookup(ok && leader.pid != 0)
 	if ok {
 		return proc.DeriveUniqueID(leader.pid, leader.ppid), true
 	}
@@ -293,24 +472,97 @@ func (pc *ProcessInfoCache) ProcessID(pid int) (string, bool) {
 // indicated by the given host PID.
 func (pc *ProcessInfoCache) ProcessContainerID(pid int) (string, bool) {
 	var t task
-	for p := pid; pc.cache.LookupTask(p, &t); p = t.ppid {
+	ok := pc.cache.LookupTask(pid, &t)
+	pc.countLookup(ok)
+	for ok {
 		if len(t.containerID) > 0 {
 			return t.containerID, true
 		}
+		ok = pc.cache.LookupTask(t.ppid, &t)
 	}
 
 	return "", false
 }
 
+// ProcessLineage returns the process lineage for the process indicated by the
+// given PID, starting with the process itself and ending with the init
+// process of its container or of the host, whichever comes first. No more
+// than maxDepth processes are returned, and the lineage is cut short at the
+// first process that is not in the cache.
+func (pc *ProcessInfoCache) ProcessLineage(pid int, maxDepth int) []*api.Process {
+	var lineage []*api.Process
+
+	for p := pid; len(lineage) < maxDepth; {
+		t, ok := pc.lookupLeader(p)
+		if len(lineage) == 0 {
+			pc.countLookup(ok && t.pid != 0)
+		}
+		if !ok || t.pid == 0 {
+			break
+		}
+
+		lineage = append(lineage, &api.Process{
+			Pid:     int32(t.pid),
+			Command: t.processCommand(),
+		})
+
+		if t.pid == 1 || t.ppid == 0 {
+			break
+		}
+
+		// The container init process is the first process with a
+		// container ID whose parent does not share it.
+		if len(t.containerID) > 0 {
+			parent, ok := pc.lookupLeader(t.ppid)
+			if !ok || parent.containerID != t.containerID {
+				break
+			}
+		}
+
+		p = t.ppid
+	}
+
+	return lineage
+}
+
+// previousCredentials returns the credentials that the process indicated by
+// the given PID had before it committed the given credentials. The cache is
+// updated by its own probe on commit_creds, which may be handled before or
+// after the caller's, so if the cache already has the new credentials, the
+// credentials before them are returned.
+func (pc *ProcessInfoCache) previousCredentials(pid int, c cred) (cred, bool) {
+	var t task
+	if !pc.cache.LookupTask(pid, &t) {
+		return cred{}, false
+	}
+
+	if t.creds == c {
+		return t.prevCreds, t.prevCreds.initialized
+	}
+	return t.creds, t.creds.initialized
+}
+
 // ProcessCommandLine returns the command-line for a process. The command-line
-// is constructed from argv passed to execve(), but is limited to a fixed number
-// of elements of argv; therefore, it may not be complete.
+// is constructed from argv passed to execve(), but is limited to a configured
+// number of elements of argv; therefore, it may not be complete.
 func (pc *ProcessInfoCache) ProcessCommandLine(pid int) ([]string, bool) {
 	var t task
 	ok := pc.cache.LookupTask(pid, &t)
+	pc.countLookup(ok)
 	return t.commandLine, ok
 }
 
+// ProcessEnvironment returns the environment for a process. The environment
+// is constructed from envp passed to execve(), but is limited to a
+// configured number of elements of envp; therefore, it may not be complete.
+// It is only captured if the environment is captured in exec events.
+func (pc *ProcessInfoCache) ProcessEnvironment(pid int) ([]string, bool) {
+	var t task
+	ok := pc.cache.LookupTask(pid, &t)
+	pc.countLookup(ok)
+	return t.environment, ok
+}
+
 //
 // task represents a schedulable task. All Linux tasks are uniquely
 // identified at a given time by their PID, but those PIDs may be
@@ -341,18 +593,36 @@ type task struct {
 	command string
 
 	// This is the command-line used when the process was exec'd via
-	// execve(). It is composed of the first 6 elements of argv. It may
-	// not be complete if argv contained more than 6 elements.
+	// execve(). It is composed of the first elements of argv, up to the
+	// configured ExecArgCount. It may not be complete if argv contained
+	// more elements than that.
 	commandLine []string
 
-	// Process credentials (uid, gid). This is kept up-to-date by
-	// recording changes observed via a probe on commit_creds().
-	creds cred
+	// This is the environment passed to execve(), composed of the first
+	// elements of envp, up to the configured ExecEnvironmentCount. It is
+	// only captured if ExecEnvironment is set.
+	environment []string
+
+	// Process credentials. This is kept up-to-date by recording changes
+	// observed via a probe on commit_creds(). The credentials before the
+	// most recent change are also kept.
+	creds     cred
+	prevCreds cred
 
 	// Unique ID for the container instance
 	containerID string
 }
 
+// processCommand returns the command for a task. The command-line captured
+// from execve() is preferred, because the kernel's comm field recorded when
+// the task was created is the parent's until the task calls execve().
+func (t *task) processCommand() string {
+	if len(t.commandLine) > 0 {
+		return strings.Join(t.commandLine, " ")
+	}
+	return t.command
+}
+
 type cred struct {
 	// Set to true when this struct has been initialized. This
 	// helps differentiate from processes running as root (all
@@ -361,6 +631,10 @@ type cred struct {
 
 	// Record uid and gid to have symmetry with eBPF get_current_uid_gid()
 	uid, gid uint32
+
+	euid, egid, fsuid uint32
+
+	capInheritable, capPermitted, capEffective, capBounding uint64
 }
 
 //
@@ -400,12 +674,17 @@ func (pc *ProcessInfoCache) decodeNewTask(
 	// Inherit containerID from parent
 	containerID, _ := pc.ProcessContainerID(parentPid)
 
+	// Inherit credentials from parent
+	var parent task
+	pc.cache.LookupTask(parentPid, &parent)
+
 	t := task{
 		pid:         childPid,
 		ppid:        parentPid,
 		tgid:        tgid,
 		cloneFlags:  cloneFlags,
 		command:     command,
+		creds:       parent.creds,
 		containerID: containerID,
 	}
 
@@ -429,16 +708,7 @@ func (pc *ProcessInfoCache) decodeCommitCreds(
 		glog.Fatal("Received commit_creds with zero usage")
 	}
 
-	uid := data["uid"].(uint32)
-	gid := data["gid"].(uint32)
-
-	c := cred{
-		initialized: true,
-		uid:         uid,
-		gid:         gid,
-	}
-
-	pc.cache.SetTaskCredentials(pid, c)
+	pc.cache.SetTaskCredentials(pid, newCred(data))
 
 	return nil, nil
 }
@@ -481,23 +751,106 @@ func (pc *ProcessInfoCache) decodeRuncTaskRename(
 	return nil, nil
 }
 
-// decodeDoExecve decodes sys_execve() and sys_execveat() events to obtain the
-// command-line for the process.
+// makeExecveDecoder returns a decoder for the kprobe fetching count elements
+// of argv starting at first.
+func (pc *ProcessInfoCache) makeExecveDecoder(
+	first, count int,
+) perf.TraceEventDecoderFn {
+	return func(
+		sample *perf.SampleRecord,
+		data perf.TraceEventSampleData,
+	) (interface{}, error) {
+		return pc.decodeExecve(first, count, data)
+	}
+}
+
+// decodeExecve decodes sys_execve() and sys_execveat() events to obtain the
+// command-line for the process. The kprobe fetching the first chunk of argv
+// replaces the command-line, and each later chunk is appended only if the
+// command-line holds every element of argv before it. Elements fetched past
+// the end of argv would otherwise come from envp.
 func (pc *ProcessInfoCache) decodeExecve(
-	sample *perf.SampleRecord,
+	first, count int,
 	data perf.TraceEventSampleData,
 ) (interface{}, error) {
-	commandLine := make([]string, 0, execveArgCount)
-	for i := 0; i < execveArgCount; i++ {
-		s := data[fmt.Sprintf("argv%d", i)].(string)
+	args := execveChunk("argv", count, data)
+
+	pid := int(data["common_pid"].(int32))
+	if first == 0 {
+		pc.cache.SetTaskCommandLine(pid, args)
+		return nil, nil
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	var t task
+	if pc.cache.LookupTask(pid, &t) && len(t.commandLine) == first {
+		commandLine := make([]string, 0, first+len(args))
+		commandLine = append(commandLine, t.commandLine...)
+		commandLine = append(commandLine, args...)
+		pc.cache.SetTaskCommandLine(pid, commandLine)
+	}
+
+	return nil, nil
+}
+
+// execveChunk returns the elements of argv or envp fetched by a kprobe, up
+// to the first empty one.
+func execveChunk(
+	name string,
+	count int,
+	data perf.TraceEventSampleData,
+) []string {
+	elements := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		s := data[fmt.Sprintf("%s%d", name, i)].(string)
 		if len(s) == 0 {
 			break
 		}
-		commandLine = append(commandLine, s)
+		elements = append(elements, s)
 	}
+	return elements
+}
+
+// makeExecveEnvironmentDecoder returns a decoder for the kprobe fetching
+// count elements of envp starting at first.
+func (pc *ProcessInfoCache) makeExecveEnvironmentDecoder(
+	first, count int,
+) perf.TraceEventDecoderFn {
+	return func(
+		sample *perf.SampleRecord,
+		data perf.TraceEventSampleData,
+	) (interface{}, error) {
+		return pc.decodeExecveEnvironment(first, count, data)
+	}
+}
+
+// decodeExecveEnvironment decodes sys_execve() and sys_execveat() events to
+// obtain the environment for the process. Chunks of envp are combined in the
+// same way as chunks of argv are for the command-line.
+func (pc *ProcessInfoCache) decodeExecveEnvironment(
+	first, count int,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	environment := execveChunk("envp", count, data)
 
 	pid := int(data["common_pid"].(int32))
-	pc.cache.SetTaskCommandLine(pid, commandLine)
+	if first == 0 {
+		pc.cache.SetTaskEnvironment(pid, environment)
+		return nil, nil
+	}
+	if len(environment) == 0 {
+		return nil, nil
+	}
+
+	var t task
+	if pc.cache.LookupTask(pid, &t) && len(t.environment) == first {
+		e := make([]string, 0, first+len(environment))
+		e = append(e, t.environment...)
+		e = append(e, environment...)
+		pc.cache.SetTaskEnvironment(pid, e)
+	}
 
 	return nil, nil
 }
@@ -15,8 +15,12 @@
 package sensor
 
 import (
+	"fmt"
 	"os"
+	"reflect"
 	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
 )
 
 /*
@@ -37,10 +41,10 @@ BenchmarkContainerCacheMissParallel-8   	  300000	      5789 ns/op
 const arrayTaskCacheSize = 32768
 
 var values = []task{
-	{1, 2, 3, 0x120011, "foo", nil, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
-	{1, 2, 3, 0x120011, "bar", nil, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
-	{1, 2, 3, 0x120011, "baz", nil, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
-	{1, 2, 3, 0x120011, "qux", nil, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
+	{1, 2, 3, 0x120011, "foo", nil, nil, cred{}, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
+	{1, 2, 3, 0x120011, "bar", nil, nil, cred{}, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
+	{1, 2, 3, 0x120011, "baz", nil, nil, cred{}, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
+	{1, 2, 3, 0x120011, "qux", nil, nil, cred{}, cred{}, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"},
 }
 
 func TestCaches(t *testing.T) {
@@ -77,6 +81,160 @@ func TestCaches(t *testing.T) {
 	}
 }
 
+func TestProcessLineage(t *testing.T) {
+	pc := ProcessInfoCache{
+		cache: newMapTaskCache(),
+	}
+
+	cID := "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2"
+	tasks := []task{
+		{pid: 1, tgid: 1, command: "systemd"},
+		{pid: 100, tgid: 100, ppid: 1, command: "dockerd"},
+		{pid: 200, tgid: 200, ppid: 100, command: "nginx",
+			commandLine: []string{"nginx", "-g", "daemon off;"},
+			containerID: cID},
+		{pid: 201, tgid: 200, ppid: 200, command: "nginx",
+			containerID: cID},
+		{pid: 300, tgid: 300, ppid: 201, command: "nginx",
+			commandLine: []string{"/bin/bash"}, containerID: cID},
+		{pid: 400, tgid: 400, ppid: 100, command: "sshd"},
+		{pid: 500, tgid: 500, ppid: 400, command: "sshd",
+			commandLine: []string{"/bin/bash"}},
+	}
+	for _, tk := range tasks {
+		pc.cache.InsertTask(tk.pid, tk)
+	}
+
+	check := func(pid, depth int, expected []int32, commands []string) {
+		lineage := pc.ProcessLineage(pid, depth)
+		if len(lineage) != len(expected) {
+			t.Fatalf("Expected %d processes for pid %d, got %+v",
+				len(expected), pid, lineage)
+		}
+		for i, p := range lineage {
+			if p.Pid != expected[i] {
+				t.Errorf("Expected pid %d at %d, got %d",
+					expected[i], i, p.Pid)
+			}
+			if commands != nil && p.Command != commands[i] {
+				t.Errorf("Expected command %q at %d, got %q",
+					commands[i], i, p.Command)
+			}
+		}
+	}
+
+	check(300, 32, []int32{300, 200},
+		[]string{"/bin/bash", "nginx -g daemon off;"})
+	check(500, 32, []int32{500, 400, 100, 1},
+		[]string{"/bin/bash", "sshd", "dockerd", "systemd"})
+	check(500, 2, []int32{500, 400}, nil)
+	check(500, 0, nil, nil)
+	check(600, 32, nil, nil)
+}
+
+func execveSampleData(pid int32, args ...string) perf.TraceEventSampleData {
+	data := perf.TraceEventSampleData{
+		"common_pid": pid,
+	}
+	for i := 0; i < execveArgsPerProbe; i++ {
+		data[fmt.Sprintf("argv%d", i)] = ""
+		if i < len(args) {
+			data[fmt.Sprintf("argv%d", i)] = args[i]
+		}
+	}
+	return data
+}
+
+func TestDecodeExecve(t *testing.T) {
+	pc := ProcessInfoCache{
+		cache: newMapTaskCache(),
+	}
+	pc.cache.InsertTask(100, task{pid: 100, tgid: 100})
+
+	var args []string
+	for i := 0; i < 2*execveArgsPerProbe+2; i++ {
+		args = append(args, fmt.Sprintf("arg%d", i))
+	}
+
+	check := func(expected []string) {
+		commandLine, _ := pc.ProcessCommandLine(100)
+		if !reflect.DeepEqual(commandLine, expected) {
+			t.Errorf("Expected command-line %v, got %v",
+				expected, commandLine)
+		}
+	}
+
+	n := execveArgsPerProbe
+	pc.decodeExecve(0, n, execveSampleData(100, args[:n]...))
+	pc.decodeExecve(n, n, execveSampleData(100, args[n:2*n]...))
+	pc.decodeExecve(2*n, n, execveSampleData(100, args[2*n:]...))
+	check(args)
+
+	// A chunk that doesn't follow the command-line is ignored, because
+	// it would be fetched from beyond the end of argv.
+	pc.decodeExecve(0, n, execveSampleData(100, "/bin/true"))
+	pc.decodeExecve(n, n, execveSampleData(100, "HOME=/root"))
+	check([]string{"/bin/true"})
+
+	// The first chunk always replaces the command-line.
+	pc.decodeExecve(0, n, execveSampleData(100, args[:n]...))
+	check(args[:n])
+	if !commandLineTruncated(args[:n]) {
+		t.Error("Expected command-line filling a chunk to be truncated")
+	}
+	if commandLineTruncated(args[:n-1]) {
+		t.Error("Expected short command-line not to be truncated")
+	}
+}
+
+func TestDecodeExecveEnvironment(t *testing.T) {
+	pc := ProcessInfoCache{
+		cache: newMapTaskCache(),
+	}
+	pc.cache.InsertTask(100, task{pid: 100, tgid: 100})
+
+	envpSampleData := func(environment ...string) perf.TraceEventSampleData {
+		data := perf.TraceEventSampleData{
+			"common_pid": int32(100),
+		}
+		for i := 0; i < execveArgsPerProbe; i++ {
+			data[fmt.Sprintf("envp%d", i)] = ""
+			if i < len(environment) {
+				data[fmt.Sprintf("envp%d", i)] = environment[i]
+			}
+		}
+		return data
+	}
+
+	var environment []string
+	for i := 0; i < execveArgsPerProbe+2; i++ {
+		environment = append(environment, fmt.Sprintf("VAR%d=%d", i, i))
+	}
+
+	check := func(expected []string) {
+		e, _ := pc.ProcessEnvironment(100)
+		if !reflect.DeepEqual(e, expected) {
+			t.Errorf("Expected environment %v, got %v", expected, e)
+		}
+	}
+
+	n := execveArgsPerProbe
+	pc.decodeExecveEnvironment(0, n, envpSampleData(environment[:n]...))
+	pc.decodeExecveEnvironment(n, n, envpSampleData(environment[n:]...))
+	check(environment)
+
+	// The first chunk of the next exec replaces the environment, and
+	// chunks that don't follow it are ignored
+	pc.decodeExecveEnvironment(0, n, envpSampleData("HOME=/root"))
+	pc.decodeExecveEnvironment(n, n, envpSampleData("PATH=/bin"))
+	check([]string{"HOME=/root"})
+
+	if f := makeExecveFetchArgs("envp", "cx", 8, 2); f !=
+		"envp0=+0(+64(%cx)):string envp1=+0(+72(%cx)):string" {
+		t.Errorf("Unexpected envp fetchargs %q", f)
+	}
+}
+
 func BenchmarkArrayCache(b *testing.B) {
 	cache := newArrayTaskCache(arrayTaskCacheSize)
 	var tk task
@@ -0,0 +1,69 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnvironmentFilter(t *testing.T) {
+	environment := []string{
+		"HOME=/root",
+		"PATH=/usr/bin:/bin",
+		"AWS_SECRET_ACCESS_KEY=abc123",
+		"DB_PASSWORD=hunter2",
+		"LANG=C=UTF-8",
+	}
+
+	f := newEnvironmentFilter(nil, []string{"*SECRET*", "*PASSWORD*"})
+	expected := []string{
+		"HOME=/root",
+		"PATH=/usr/bin:/bin",
+		"AWS_SECRET_ACCESS_KEY=" + redactedValue,
+		"DB_PASSWORD=" + redactedValue,
+		"LANG=C=UTF-8",
+	}
+	if got := f.filter(environment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected environment %v, got %v", expected, got)
+	}
+
+	// Secrets are redacted regardless of the case of their names
+	f = newEnvironmentFilter(nil, []string{"*TOKEN*", "*SECRET*", "*key*"})
+	expected = []string{
+		"github_token=" + redactedValue,
+		"aws_secret_access_key=" + redactedValue,
+		"Api_Key=" + redactedValue,
+		"home=/root",
+	}
+	got := f.filter([]string{
+		"github_token=ghp_abc123",
+		"aws_secret_access_key=abc123",
+		"Api_Key=abc123",
+		"home=/root",
+	})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected environment %v, got %v", expected, got)
+	}
+
+	f = newEnvironmentFilter([]string{"PATH", "DB_*"}, []string{"*PASSWORD*"})
+	expected = []string{
+		"PATH=/usr/bin:/bin",
+		"DB_PASSWORD=" + redactedValue,
+	}
+	if got := f.filter(environment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected environment %v, got %v", expected, got)
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
@@ -33,6 +35,7 @@ import (
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 
 	"golang.org/x/sys/unix"
 )
@@ -57,6 +60,13 @@ type Sensor struct {
 	// Metrics counters for this sensor
 	Metrics MetricsCounters
 
+	// Events emitted by event type, and unacknowledged events discarded
+	// from full delivery spools by delivery ID. Spools are the only place
+	// that events are discarded; other subscriptions apply backpressure,
+	// which shows up as perf samples lost by the kernel.
+	emittedEvents      labeledCounters
+	spoolDroppedEvents labeledCounters
+
 	// Repeater used for container event subscriptions
 	containerEventRepeater *containerEventRepeater
 
@@ -68,12 +78,45 @@ type Sensor struct {
 	// caching process information
 	monitor *perf.EventMonitor
 
+	// Used by the metrics service, which may still be running when the
+	// sensor is stopped, to read monitor
+	monitorLock sync.Mutex
+
 	// Per-sensor process cache.
 	processCache ProcessInfoCache
 
+	// Per-sensor cache of the processes owning TCP sockets
+	tcpSockets *tcpSocketCache
+
+	// Per-sensor cache of the processes bound to unix domain sockets
+	unixSockets *unixSocketCache
+
+	// Per-sensor tracking of the data sent and received on sockets,
+	// shared by all subscriptions with flow event filters
+	flows *flowTracker
+
+	// Per-sensor cache of the memory mappings of processes
+	memoryMappings *memoryMappingCache
+
+	// Per-sensor queue of events waiting on information from procfs
+	deferredEvents *deferredEvents
+
+	// The inode numbers of the host's namespaces by type, as named in
+	// /proc/PID/ns
+	hostNamespaces map[string]uint64
+
 	// Mapping of event ids to data streams (subscriptions)
 	eventMap *safeSubscriptionMap
 
+	// Recent events retained for subscriptions requesting historic events
+	eventHistory *eventHistory
+
+	// Kernel probes and tracepoints that could not be registered
+	probeFailures probeFailures
+
+	// Subscriptions that are currently active
+	activeSubscriptions activeSubscriptions
+
 	// Used by syscall events to handle syscall enter events with
 	// argument filters
 	dummySyscallEventID    uint64
@@ -94,6 +137,8 @@ func NewSensor() (*Sensor, error) {
 		ID:                sensorID,
 		bootMonotimeNanos: bootMonotimeNanos,
 		eventMap:          newSafeSubscriptionMap(),
+		eventHistory: newEventHistory(config.Sensor.EventHistoryLength,
+			config.Sensor.EventHistoryDuration),
 	}
 
 	cer, err := newContainerEventRepeater(s)
@@ -143,6 +188,8 @@ func (s *Sensor) Start() error {
 		}
 	}
 
+	s.hostNamespaces = readHostNamespaces(sys.HostProcFS().Readlink)
+
 	// Create the sensor-global event monitor. This EventMonitor instance
 	// will be used for all perf_event events
 	err = s.createEventMonitor()
@@ -151,7 +198,13 @@ func (s *Sensor) Start() error {
 		return err
 	}
 
+	s.deferredEvents = newDeferredEvents(s.dispatchSample)
+
 	s.processCache = NewProcessInfoCache(s)
+	s.tcpSockets = newTCPSocketCache(s)
+	s.unixSockets = newUnixSocketCache(s)
+	s.flows = newFlowTracker(s)
+	s.memoryMappings = newMemoryMappingCache()
 
 	// Make sure that all events registered with the sensor's event monitor
 	// are active
@@ -165,10 +218,24 @@ func (s *Sensor) Stop() {
 	if s.monitor != nil {
 		glog.V(2).Info("Stopping sensor-global EventMonitor")
 		s.monitor.Close(true)
+		s.monitorLock.Lock()
 		s.monitor = nil
+		s.monitorLock.Unlock()
 		glog.V(2).Info("Sensor-global EventMonitor stopped successfully")
 	}
 
+	if s.unixSockets != nil {
+		s.unixSockets.close()
+	}
+
+	if s.memoryMappings != nil {
+		s.memoryMappings.close()
+	}
+
+	if s.deferredEvents != nil {
+		s.deferredEvents.close()
+	}
+
 	if len(s.traceFSMountPoint) > 0 {
 		s.unmountTraceFS()
 	}
@@ -183,16 +250,47 @@ func (s *Sensor) dispatchSample(eventID uint64, sample interface{}, err error) {
 		glog.Warning(err)
 	}
 
+	if ls, ok := sample.(*perf.LostSamples); ok {
+		s.dispatchLostSamples(ls)
+		return
+	}
+
 	if event, ok := sample.(*api.TelemetryEvent); ok && event != nil {
 		eventMap := s.eventMap.getMap()
 		if sub, ok := eventMap[eventID]; ok && sub != nil {
+			s.recordEventHistory(eventID, event)
+			if sub.copies != nil && sub.copies.isCopy(eventID, event) {
+				return
+			}
 			if sub.data != nil {
+				if sub.lineageDepth > 0 {
+					// Events in the history must not be
+					// modified, so add lineage to a copy
+					e := *event
+					s.addProcessLineage(&e, sub.lineageDepth)
+					event = &e
+				}
 				sub.data <- event
 			}
 		}
 	}
 }
 
+// recordEventHistory records an event decoded from a sample of the
+// registered event with the given id in the event history, unless it is a
+// copy of a kernel event that has already been recorded. The process lineage
+// is captured now to the maximum depth, because the processes involved may
+// have exited by the time that the event is replayed.
+func (s *Sensor) recordEventHistory(eventID uint64, event *api.TelemetryEvent) {
+	if !s.eventHistory.enabled() || s.eventHistory.isCopy(eventID, event) {
+		return
+	}
+
+	e := *event
+	s.addProcessLineage(&e, int(config.Sensor.ProcessLineageMaxDepth))
+	s.eventHistory.add(&e)
+}
+
 func (s *Sensor) mountTraceFS() error {
 	dir := filepath.Join(config.Global.RunDir, "tracing")
 	err := sys.MountTempFS("tracefs", dir, "tracefs", 0, "")
@@ -261,7 +359,7 @@ func (s *Sensor) NewEvent() *api.TelemetryEvent {
 	h := sha256.Sum256(buf.Bytes())
 	eventID := hex.EncodeToString(h[:])
 
-	s.Metrics.Events++
+	atomic.AddUint64(&s.Metrics.Events, 1)
 
 	return &api.TelemetryEvent{
 		Id:                   eventID,
@@ -284,14 +382,33 @@ func (s *Sensor) NewEventFromContainer(containerID string) *api.TelemetryEvent {
 func (s *Sensor) NewEventFromSample(sample *perf.SampleRecord,
 	data perf.TraceEventSampleData) *api.TelemetryEvent {
 
-	e := s.NewEvent()
-	e.SensorMonotimeNanos = int64(sample.Time) - s.bootMonotimeNanos
-
 	// When both the sensor and the process generating the sample are in
 	// containers, the sample.Pid and sample.Tid fields will be zero.
 	// Use "common_pid" from the trace event data instead.
-	e.ProcessPid = data["common_pid"].(int32)
+	return s.newEventFromPid(sample, data["common_pid"].(int32))
+}
+
+// newEventFromPid creates a new TelemetryEvent from a sample that is
+// attributed to the specified process rather than the process that was
+// running when the sample was taken. A pid of 0 means that the process is
+// not known, and the event has no process or container information.
+func (s *Sensor) newEventFromPid(sample *perf.SampleRecord, pid int32) *api.TelemetryEvent {
+	e := s.NewEvent()
+	e.SensorMonotimeNanos = int64(sample.Time) - s.bootMonotimeNanos
 	e.Cpu = int32(sample.CPU)
+	s.setEventProcess(e, pid)
+
+	return e
+}
+
+// setEventProcess attributes an event to a process, adding the process and
+// container information known for it. A pid of 0 means that the process is
+// not known.
+func (s *Sensor) setEventProcess(e *api.TelemetryEvent, pid int32) {
+	e.ProcessPid = pid
+	if pid == 0 {
+		return
+	}
 
 	processID, ok := s.processCache.ProcessID(int(e.ProcessPid))
 	if ok {
@@ -312,8 +429,25 @@ func (s *Sensor) NewEventFromSample(sample *perf.SampleRecord,
 			e.ImageName = containerInfo.ImageName
 		}
 	}
+}
 
-	return e
+// processLineageDepth returns the number of processes to include in the
+// process lineage of events for the given subscription.
+func processLineageDepth(sub *api.Subscription) int {
+	depth := sub.ProcessLineageDepth
+	if depth > config.Sensor.ProcessLineageMaxDepth {
+		depth = config.Sensor.ProcessLineageMaxDepth
+	}
+	return int(depth)
+}
+
+// addProcessLineage populates the process lineage of an event using the
+// sensor's process info cache.
+func (s *Sensor) addProcessLineage(e *api.TelemetryEvent, depth int) {
+	if e.ProcessPid != 0 {
+		e.ProcessLineage = s.processCache.ProcessLineage(
+			int(e.ProcessPid), depth)
+	}
 }
 
 func (s *Sensor) buildMonitorGroups() ([]string, []int, error) {
@@ -416,14 +550,26 @@ func (s *Sensor) createEventMonitor() error {
 	return nil
 }
 
-func (s *Sensor) createPerfEventStream(sub *api.Subscription) (*stream.Stream, error) {
+func (s *Sensor) createPerfEventStream(sub *api.Subscription, copies *eventCopies) (*stream.Stream, error) {
 	eventMap := newSubscriptionMap()
 
+	// Tracepoints are registered first, so that nothing else needs to be
+	// unregistered if one of them can't be.
+	err := registerTracepointEvents(s, eventMap, sub.EventFilter.TracepointEvents)
+	if err != nil {
+		return nil, err
+	}
 	registerFileEvents(s, eventMap, sub.EventFilter.FileEvents)
 	registerKernelEvents(s, eventMap, sub.EventFilter.KernelEvents)
 	registerNetworkEvents(s, eventMap, sub.EventFilter.NetworkEvents)
 	registerProcessEvents(s, eventMap, sub.EventFilter.ProcessEvents)
 	registerSyscallEvents(s, eventMap, sub.EventFilter.SyscallEvents)
+	registerCredentialsEvents(s, eventMap, sub.EventFilter.CredentialsEvents)
+	registerSignalEvents(s, eventMap, sub.EventFilter.SignalEvents)
+	registerNamespaceEvents(s, eventMap, sub.EventFilter.NamespaceEvents)
+	registerKernelModuleEvents(s, eventMap, sub.EventFilter.KernelModuleEvents)
+	registerProcessAccessEvents(s, eventMap, sub.EventFilter.ProcessAccessEvents)
+	registerMemoryEvents(s, eventMap, sub.EventFilter.MemoryEvents)
 
 	if len(eventMap) == 0 {
 		return nil, nil
@@ -432,9 +578,12 @@ func (s *Sensor) createPerfEventStream(sub *api.Subscription) (*stream.Stream, e
 	ctrl := make(chan interface{})
 	data := make(chan interface{}, config.Sensor.ChannelBufferLength)
 
+	lineageDepth := processLineageDepth(sub)
 	for eventID := range eventMap {
 		eventSub := eventMap[eventID]
 		eventSub.data = data
+		eventSub.lineageDepth = lineageDepth
+		eventSub.copies = copies
 	}
 
 	go func() {
@@ -481,57 +630,183 @@ func (s *Sensor) applyModifiers(eventStream *stream.Stream, modifier api.Modifie
 	return eventStream
 }
 
-// NewSubscription creates a new telemetry subscription from the given
-// api.Subscription descriptor. NewSubscription returns a stream.Stream of
-// api.Events matching the specified filters. Closing the Stream cancels the
-// subscription.
-func (s *Sensor) NewSubscription(sub *api.Subscription) (*stream.Stream, error) {
-	glog.V(1).Infof("Subscribing to %+v", sub)
+// eventSources creates the streams of events matching the event filters in a
+// subscription. Kernel events that have already been sent for other
+// registered events sharing copies are discarded; copies may be nil.
+func (s *Sensor) eventSources(sub *api.Subscription, copies *eventCopies) ([]*stream.Stream, error) {
+	var sources []*stream.Stream
 
-	eventStream, joiner := stream.NewJoiner()
-	joiner.Off()
+	closeSources := func() {
+		for _, source := range sources {
+			source.Close()
+		}
+	}
 
 	if len(sub.EventFilter.FileEvents) > 0 ||
 		len(sub.EventFilter.KernelEvents) > 0 ||
+		len(sub.EventFilter.TracepointEvents) > 0 ||
 		len(sub.EventFilter.NetworkEvents) > 0 ||
 		len(sub.EventFilter.ProcessEvents) > 0 ||
-		len(sub.EventFilter.SyscallEvents) > 0 {
-
-		pes, err := s.createPerfEventStream(sub)
+		len(sub.EventFilter.SyscallEvents) > 0 ||
+		len(sub.EventFilter.CredentialsEvents) > 0 ||
+		len(sub.EventFilter.SignalEvents) > 0 ||
+		len(sub.EventFilter.NamespaceEvents) > 0 ||
+		len(sub.EventFilter.KernelModuleEvents) > 0 ||
+		len(sub.EventFilter.ProcessAccessEvents) > 0 ||
+		len(sub.EventFilter.MemoryEvents) > 0 {
+
+		pes, err := s.createPerfEventStream(sub, copies)
 		if err != nil {
-			joiner.Close()
+			closeSources()
 			return nil, err
 		}
 		if pes != nil {
-			joiner.Add(pes)
+			sources = append(sources, pes)
 		}
 	}
 
 	if len(sub.EventFilter.ContainerEvents) > 0 {
 		ces, err := s.containerEventRepeater.newEventStream(sub)
 		if err != nil {
-			joiner.Close()
+			closeSources()
 			return nil, err
 		}
-		joiner.Add(ces)
+		if ces != nil {
+			sources = append(sources, ces)
+		}
+	}
+
+	for _, ff := range sub.EventFilter.FlowEvents {
+		fs, err := newFlowSource(s, ff)
+		if err != nil {
+			closeSources()
+			return nil, err
+		}
+		sources = append(sources, fs)
 	}
 
 	for _, cf := range sub.EventFilter.ChargenEvents {
 		cs, err := newChargenSource(s, cf)
 		if err != nil {
-			joiner.Close()
+			closeSources()
 			return nil, err
 		}
-		joiner.Add(cs)
+		sources = append(sources, cs)
 	}
 
 	for _, tf := range sub.EventFilter.TickerEvents {
 		ts, err := newTickerSource(s, tf)
+		if err != nil {
+			closeSources()
+			return nil, err
+		}
+		sources = append(sources, ts)
+	}
+
+	return sources, nil
+}
+
+func (s *Sensor) addEventSources(joiner *stream.Joiner, sub *api.Subscription) error {
+	sources, err := s.eventSources(sub, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		joiner.Add(source)
+	}
+
+	return nil
+}
+
+// subscriptionWindow returns the range of the event history to replay for a
+// subscription and how long after now the subscription expires. The range is
+// empty if no history is requested.
+func subscriptionWindow(sub *api.Subscription, now int64) (int64, int64, time.Duration) {
+	// Events that occurred before now are replayed from the event history.
+	// Live events for this subscription can't occur before the events for
+	// it are enabled, so there is neither a gap nor an overlap between the
+	// two.
+	var historyStart, historyEnd int64
+	if sub.SinceDuration != nil && sub.SinceDuration.Value > 0 {
+		historyStart = now - sub.SinceDuration.Value
+		historyEnd = now
+	}
+
+	// The for_duration is relative to the start of the history requested
+	// by since_duration if there is one; otherwise, it's relative to now.
+	// If it ends in the past, only historic events are returned.
+	var expiry time.Duration
+	if sub.ForDuration != nil {
+		end := now + sub.ForDuration.Value
+		if historyEnd != 0 {
+			end = historyStart + sub.ForDuration.Value
+			if end < historyEnd {
+				historyEnd = end
+			}
+		}
+		expiry = time.Duration(end - now)
+	}
+
+	return historyStart, historyEnd, expiry
+}
+
+// historyEvents returns the events in the event history between start and
+// end that match the event filters of a subscription.
+func (s *Sensor) historyEvents(sub *api.Subscription, start, end int64) []interface{} {
+	hf := newHistoryFilter(sub.EventFilter)
+	lineageDepth := processLineageDepth(sub)
+
+	var events []interface{}
+	for _, e := range s.eventHistory.between(start, end) {
+		// Copy events from the history, because events in the
+		// history are shared and filters may modify them.
+		e = proto.Clone(e).(*api.TelemetryEvent)
+		if hf.filter(e) {
+			// The lineage was captured when the event was
+			// recorded, to the maximum depth
+			if len(e.ProcessLineage) > lineageDepth {
+				e.ProcessLineage = e.ProcessLineage[:lineageDepth]
+			}
+			if len(e.ProcessLineage) == 0 {
+				e.ProcessLineage = nil
+			}
+			events = append(events, e)
+		}
+	}
+	glog.V(2).Infof("Replaying %d events from event history",
+		len(events))
+
+	return events
+}
+
+// NewSubscription creates a new telemetry subscription from the given
+// api.Subscription descriptor. NewSubscription returns a stream.Stream of
+// api.Events matching the specified filters. Closing the Stream cancels the
+// subscription. If the subscription specifies a for_duration, the Stream is
+// closed when that duration has elapsed.
+func (s *Sensor) NewSubscription(sub *api.Subscription) (*stream.Stream, error) {
+	glog.V(1).Infof("Subscribing to %+v", sub)
+
+	original := proto.Clone(sub).(*api.Subscription)
+
+	now := s.currentMonotimeNanos()
+	historyStart, historyEnd, expiry := subscriptionWindow(sub, now)
+
+	eventStream, joiner := stream.NewJoiner()
+	joiner.Off()
+
+	if sub.ForDuration == nil || expiry > 0 {
+		err := s.addEventSources(joiner, sub)
 		if err != nil {
 			joiner.Close()
 			return nil, err
 		}
-		joiner.Add(ts)
+	}
+
+	if historyEnd != 0 {
+		events := s.historyEvents(sub, historyStart, historyEnd)
+		eventStream = stream.Prepend(eventStream, events)
 	}
 
 	if sub.ContainerFilter != nil {
@@ -548,9 +823,25 @@ func (s *Sensor) NewSubscription(sub *api.Subscription) (*stream.Stream, error)
 		eventStream = s.applyModifiers(eventStream, *sub.Modifier)
 	}
 
-	s.Metrics.Subscriptions++
+	eventStream = stream.Do(eventStream, s.countEmittedEvent)
+
+	// Track the subscription while it is active. NewSubscription has
+	// rewritten parts of the subscription, so record the original.
+	id := s.activeSubscriptions.add(original)
+	eventStream = stream.Finally(eventStream, func() {
+		s.activeSubscriptions.remove(id)
+	})
+
+	atomic.AddInt32(&s.Metrics.Subscriptions, 1)
 	joiner.On()
 
+	if sub.ForDuration != nil {
+		// Closing the stream unregisters the subscription's events
+		// from the EventMonitor and closes its data channel.
+		glog.V(2).Infof("Subscription expires in %s", expiry)
+		eventStream = stream.Expire(eventStream, expiry)
+	}
+
 	return eventStream, nil
 }
 
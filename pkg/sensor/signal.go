@@ -0,0 +1,208 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	signalGenerateTracepoint = "signal/signal_generate"
+	signalDeliverTracepoint  = "signal/signal_deliver"
+)
+
+// The fields of each signal tracepoint that may be used in filters
+var signalEventTypes = map[api.SignalEventType]expression.FieldTypeMap{
+	api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE: expression.FieldTypeMap{
+		"sig":    int32(api.ValueType_SINT32),
+		"errno":  int32(api.ValueType_SINT32),
+		"code":   int32(api.ValueType_SINT32),
+		"pid":    int32(api.ValueType_SINT32),
+		"group":  int32(api.ValueType_SINT32),
+		"result": int32(api.ValueType_SINT32),
+	},
+	api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER: expression.FieldTypeMap{
+		"sig":        int32(api.ValueType_SINT32),
+		"errno":      int32(api.ValueType_SINT32),
+		"code":       int32(api.ValueType_SINT32),
+		"sa_handler": int32(api.ValueType_UINT64),
+		"sa_flags":   int32(api.ValueType_UINT64),
+	},
+}
+
+var signalEventTracepoints = map[api.SignalEventType]string{
+	api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE: signalGenerateTracepoint,
+	api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER:  signalDeliverTracepoint,
+}
+
+func signalEventValues(sev *api.SignalEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"sig":   int32(sev.Signal),
+		"errno": sev.Errno,
+		"code":  sev.Code,
+	}
+
+	switch sev.Type {
+	case api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE:
+		values["pid"] = sev.TargetPid
+		values["result"] = sev.Result
+		if sev.Group {
+			values["group"] = int32(1)
+		} else {
+			values["group"] = int32(0)
+		}
+	case api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER:
+		values["sa_handler"] = sev.Handler
+		values["sa_flags"] = sev.HandlerFlags
+	}
+
+	return values
+}
+
+type signalFilter struct {
+	sensor *Sensor
+}
+
+func (f *signalFilter) decodeSignalGenerate(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+
+	sev := &api.SignalEvent{
+		Type:      api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+		Signal:    uint32(data["sig"].(int32)),
+		SenderPid: ev.ProcessPid,
+		SenderId:  ev.ProcessId,
+		TargetPid: data["pid"].(int32),
+		Code:      data["code"].(int32),
+		Errno:     data["errno"].(int32),
+		Group:     data["group"].(int32) != 0,
+		Result:    data["result"].(int32),
+	}
+	if id, ok := f.sensor.processCache.ProcessID(int(sev.TargetPid)); ok {
+		sev.TargetId = id
+	}
+
+	ev.Event = &api.TelemetryEvent_Signal{
+		Signal: sev,
+	}
+
+	return ev, nil
+}
+
+func (f *signalFilter) decodeSignalDeliver(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+
+	ev.Event = &api.TelemetryEvent_Signal{
+		Signal: &api.SignalEvent{
+			Type:         api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+			Signal:       uint32(data["sig"].(int32)),
+			TargetPid:    ev.ProcessPid,
+			TargetId:     ev.ProcessId,
+			Code:         data["code"].(int32),
+			Errno:        data["errno"].(int32),
+			Handler:      data["sa_handler"].(uint64),
+			HandlerFlags: data["sa_flags"].(uint64),
+		},
+	}
+
+	return ev, nil
+}
+
+// signalEventFilterString returns the kernel filter string for a signal
+// event filter, or an error if the filter is invalid. An empty string is
+// returned for filters that match all events.
+func signalEventFilterString(sef *api.SignalEventFilter) (string, error) {
+	types, ok := signalEventTypes[sef.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported signal event type %s",
+			sef.Type)
+	}
+
+	if sef.FilterExpression == nil {
+		return "", nil
+	}
+
+	expr, err := expression.NewExpression(sef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.Validate(types)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
+// signalEventProbes returns the names of the tracepoints that signal events
+// of the given type are collected from.
+func signalEventProbes(t api.SignalEventType) []string {
+	if name, ok := signalEventTracepoints[t]; ok {
+		return []string{name}
+	}
+	return nil
+}
+
+func registerSignalEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.SignalEventFilter) {
+	filters := make(map[api.SignalEventType]map[string]int)
+	for _, sef := range events {
+		s, err := signalEventFilterString(sef)
+		if err != nil {
+			glog.V(1).Infof("Invalid signal event filter: %s", err)
+			continue
+		}
+		if filters[sef.Type] == nil {
+			filters[sef.Type] = make(map[string]int)
+		}
+		filters[sef.Type][s]++
+	}
+
+	f := signalFilter{
+		sensor: sensor,
+	}
+
+	decoders := map[api.SignalEventType]perf.TraceEventDecoderFn{
+		api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE: f.decodeSignalGenerate,
+		api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER:  f.decodeSignalDeliver,
+	}
+
+	for t, m := range filters {
+		filterString, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+
+		eventName := signalEventTracepoints[t]
+		eventID, err := sensor.registerTracepoint(eventName,
+			decoders[t], perf.WithFilter(filterString))
+		if err != nil {
+			glog.V(1).Infof("Couldn't get %s event id: %v",
+				eventName, err)
+			sensor.probeFailed(eventName, err)
+			continue
+		}
+
+		eventMap[eventID] = &subscription{}
+	}
+}
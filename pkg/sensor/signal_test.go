@@ -0,0 +1,110 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestSignalEvents(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+	}
+	f := signalFilter{
+		sensor: s,
+	}
+
+	i, err := f.decodeSignalGenerate(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"sig":        int32(9),
+			"errno":      int32(0),
+			"code":       int32(0),
+			"pid":        int32(200),
+			"group":      int32(1),
+			"result":     int32(0),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sev := i.(*api.TelemetryEvent).GetSignal()
+	if sev == nil ||
+		sev.Type != api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE ||
+		sev.Signal != 9 || sev.SenderPid != 100 ||
+		sev.TargetPid != 200 || !sev.Group {
+		t.Errorf("Unexpected signal generate event %+v", sev)
+	}
+
+	values := signalEventValues(sev)
+	if values["pid"] != int32(200) || values["group"] != int32(1) {
+		t.Errorf("Unexpected signal event values %v", values)
+	}
+
+	i, err = f.decodeSignalDeliver(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(200),
+			"sig":        int32(10),
+			"errno":      int32(0),
+			"code":       int32(-6),
+			"sa_handler": uint64(0x400500),
+			"sa_flags":   uint64(0x4000000),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sev = i.(*api.TelemetryEvent).GetSignal()
+	if sev == nil ||
+		sev.Type != api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER ||
+		sev.Signal != 10 || sev.TargetPid != 200 ||
+		sev.SenderPid != 0 || sev.Code != -6 ||
+		sev.Handler != 0x400500 {
+		t.Errorf("Unexpected signal deliver event %+v", sev)
+	}
+}
+
+func TestSignalEventFilterString(t *testing.T) {
+	s, err := signalEventFilterString(&api.SignalEventFilter{
+		Type: api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+		FilterExpression: expression.Equal(
+			expression.Identifier("sig"),
+			expression.Value(int32(9))),
+	})
+	if err != nil || s != "sig == 9" {
+		t.Errorf("Unexpected filter string %q (%v)", s, err)
+	}
+
+	// The target pid is not a field of deliver events
+	_, err = signalEventFilterString(&api.SignalEventFilter{
+		Type: api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+		FilterExpression: expression.Equal(
+			expression.Identifier("pid"),
+			expression.Value(int32(1))),
+	})
+	if err == nil {
+		t.Error("Expected error for unknown field")
+	}
+
+	_, err = signalEventFilterString(&api.SignalEventFilter{})
+	if err == nil {
+		t.Error("Expected error for unknown event type")
+	}
+}
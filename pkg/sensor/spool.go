@@ -0,0 +1,473 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+//
+// This file implements the spools used for reliable (at-least-once) delivery
+// of telemetry events. A spool owns the event stream for a subscription and
+// retains each event from it until the event is acknowledged by the client.
+// Spools outlive the GetEvents streams that they're attached to, so that a
+// client that reconnects doesn't lose events.
+//
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/stream"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// Acks are encoded as the spool generation and event sequence number
+	// followed by the delivery ID of the spool.
+	ackHeaderLength = 16
+
+	// The minimum interval between warnings about events discarded from a
+	// full spool
+	deliverySpoolWarnInterval = time.Minute
+)
+
+var (
+	errSpoolAttached = errors.New("delivery_id is in use by another stream")
+	errSpoolMismatch = errors.New("delivery_id is in use with a different subscription")
+	errInvalidAck    = errors.New("invalid ack")
+)
+
+type spooledEvent struct {
+	seq    uint64
+	event  *api.TelemetryEvent
+	sentAt time.Time
+}
+
+// deliverySpool holds the events for a reliable delivery subscription until
+// they are acknowledged.
+type deliverySpool struct {
+	sync.Mutex
+
+	id         string
+	generation uint64
+	sub        *api.Subscription
+
+	// Unacknowledged events ordered by sequence number
+	events     []*spooledEvent
+	nextSeq    uint64
+	maxLength  int
+	ackTimeout time.Duration
+
+	// The unacknowledged lost event reporting the events discarded when
+	// the spool is full. It is sent before any other event and does not
+	// count towards maxLength.
+	lost *spooledEvent
+
+	// The number of events discarded since the last warning about them
+	unwarned int
+	warnedAt time.Time
+
+	// notify receives a value whenever events are added to the spool or
+	// the spool's event stream ends.
+	notify chan struct{}
+
+	// Used to create lost events and count the events discarded when the
+	// spool is full
+	sensor *Sensor
+
+	eventStream *stream.Stream
+	attached    bool
+	closed      bool
+	idleTimer   *time.Timer
+}
+
+func newDeliverySpool(id string, maxLength int, ackTimeout time.Duration) *deliverySpool {
+	return &deliverySpool{
+		id:         id,
+		generation: uint64(time.Now().UnixNano()),
+		maxLength:  maxLength,
+		ackTimeout: ackTimeout,
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+func (sp *deliverySpool) signal() {
+	select {
+	case sp.notify <- struct{}{}:
+	default:
+	}
+}
+
+// run adds the events from the spool's event stream to the spool until the
+// stream ends.
+func (sp *deliverySpool) run() {
+	for e := range sp.eventStream.Data {
+		sp.add(e.(*api.TelemetryEvent))
+	}
+
+	sp.Lock()
+	sp.closed = true
+	sp.Unlock()
+	sp.signal()
+}
+
+// add appends an event to the spool. If the spool is full, the oldest
+// unacknowledged event is discarded and reported in the spool's lost event.
+func (sp *deliverySpool) add(e *api.TelemetryEvent) {
+	sp.Lock()
+	sp.events = append(sp.events, &spooledEvent{
+		seq:   sp.nextSeq,
+		event: e,
+	})
+	sp.nextSeq++
+
+	if n := len(sp.events) - sp.maxLength; n > 0 {
+		sp.discard(sp.events[:n])
+		for i := 0; i < n; i++ {
+			sp.events[i] = nil
+		}
+		sp.events = sp.events[n:]
+	}
+	sp.Unlock()
+
+	sp.signal()
+}
+
+// discard reports events discarded from the spool in its lost event. The
+// lost event is replaced rather than modified, because it may be in the
+// middle of being sent. Once the lost event has been sent, its replacement
+// gets a new sequence number, so that an ack for the old one doesn't
+// acknowledge the report of events that the client hasn't been told about.
+// discard must be called with the spool locked.
+func (sp *deliverySpool) discard(discarded []*spooledEvent) {
+	first := discarded[0].event
+	last := discarded[len(discarded)-1].event
+	lost := &api.LostEvent{
+		Type:                api.LostEventType_LOST_EVENT_TYPE_DELIVERY_SPOOL,
+		Lost:                uint64(len(discarded)),
+		StartMonotimeNanos:  first.SensorMonotimeNanos,
+		EndMonotimeNanos:    last.SensorMonotimeNanos,
+		FirstSequenceNumber: first.SensorSequenceNumber,
+		LastSequenceNumber:  last.SensorSequenceNumber,
+	}
+
+	var seq uint64
+	if sp.lost != nil {
+		prev := sp.lost.event.GetLost()
+		lost.Lost += prev.Lost
+		lost.StartMonotimeNanos = prev.StartMonotimeNanos
+		lost.FirstSequenceNumber = prev.FirstSequenceNumber
+	}
+	if sp.lost != nil && sp.lost.sentAt.IsZero() {
+		seq = sp.lost.seq
+	} else {
+		seq = sp.nextSeq
+		sp.nextSeq++
+	}
+
+	var e *api.TelemetryEvent
+	if sp.sensor != nil {
+		e = sp.sensor.NewEvent()
+		sp.sensor.spoolDroppedEvents.add(sp.id, uint64(len(discarded)))
+	} else {
+		e = &api.TelemetryEvent{}
+	}
+	e.Event = &api.TelemetryEvent_Lost{
+		Lost: lost,
+	}
+	sp.lost = &spooledEvent{
+		seq:   seq,
+		event: e,
+	}
+
+	sp.unwarned += len(discarded)
+	if now := time.Now(); now.Sub(sp.warnedAt) >= deliverySpoolWarnInterval {
+		glog.Warningf("Delivery spool %s is full, discarded %d events",
+			sp.id, sp.unwarned)
+		sp.unwarned = 0
+		sp.warnedAt = now
+	}
+}
+
+// empty returns whether the spool has no unacknowledged events. It must be
+// called with the spool locked.
+func (sp *deliverySpool) empty() bool {
+	return len(sp.events) == 0 && sp.lost == nil
+}
+
+// ack removes the event with the given sequence number from the spool.
+func (sp *deliverySpool) ack(seq uint64) bool {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if sp.lost != nil && sp.lost.seq == seq {
+		sp.lost = nil
+		return true
+	}
+
+	i := sort.Search(len(sp.events), func(i int) bool {
+		return sp.events[i].seq >= seq
+	})
+	if i >= len(sp.events) || sp.events[i].seq != seq {
+		return false
+	}
+
+	copy(sp.events[i:], sp.events[i+1:])
+	sp.events[len(sp.events)-1] = nil
+	sp.events = sp.events[:len(sp.events)-1]
+
+	return true
+}
+
+// due returns the events that need to be sent, either because they haven't
+// been sent yet or because they haven't been acknowledged in time, and marks
+// them as sent. It also returns how long to wait before events will need to
+// be re-transmitted, and whether the spool is finished because its event
+// stream has ended and all of its events have been acknowledged.
+func (sp *deliverySpool) due(now time.Time) ([]*api.ReceivedTelemetryEvent, time.Duration, bool) {
+	sp.Lock()
+	defer sp.Unlock()
+
+	var (
+		events []*api.ReceivedTelemetryEvent
+		wait   time.Duration
+	)
+
+	publishTime := now.UnixNano() / int64(time.Microsecond)
+	send := func(se *spooledEvent) {
+		if !se.sentAt.IsZero() {
+			d := se.sentAt.Add(sp.ackTimeout).Sub(now)
+			if d > 0 {
+				if wait == 0 || d < wait {
+					wait = d
+				}
+				return
+			}
+		}
+
+		se.sentAt = now
+		events = append(events, &api.ReceivedTelemetryEvent{
+			PublishTimeMicros: publishTime,
+			Event:             se.event,
+			Ack:               sp.ackToken(se.seq),
+		})
+	}
+
+	// Tell the client about discarded events before sending any more
+	if sp.lost != nil {
+		send(sp.lost)
+	}
+	for _, se := range sp.events {
+		send(se)
+	}
+	if len(events) > 0 && (wait == 0 || sp.ackTimeout < wait) {
+		wait = sp.ackTimeout
+	}
+
+	return events, wait, sp.closed && sp.empty()
+}
+
+func (sp *deliverySpool) ackToken(seq uint64) []byte {
+	b := make([]byte, ackHeaderLength+len(sp.id))
+	binary.BigEndian.PutUint64(b[0:], sp.generation)
+	binary.BigEndian.PutUint64(b[8:], seq)
+	copy(b[ackHeaderLength:], sp.id)
+	return b
+}
+
+func parseAckToken(b []byte) (id string, generation uint64, seq uint64, err error) {
+	if len(b) <= ackHeaderLength {
+		err = errInvalidAck
+		return
+	}
+
+	generation = binary.BigEndian.Uint64(b[0:])
+	seq = binary.BigEndian.Uint64(b[8:])
+	id = string(b[ackHeaderLength:])
+	return
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// deliverySpoolMap holds the delivery spools for a telemetry service, indexed
+// by delivery ID.
+type deliverySpoolMap struct {
+	sync.Mutex
+	spools map[string]*deliverySpool
+
+	maxLength   int
+	ackTimeout  time.Duration
+	idleTimeout time.Duration
+}
+
+func newDeliverySpoolMap(maxLength int, ackTimeout, idleTimeout time.Duration) *deliverySpoolMap {
+	return &deliverySpoolMap{
+		spools:      make(map[string]*deliverySpool),
+		maxLength:   maxLength,
+		ackTimeout:  ackTimeout,
+		idleTimeout: idleTimeout,
+	}
+}
+
+// reattach attaches a new stream to an existing spool.
+func (sp *deliverySpool) reattach(sub *api.Subscription) error {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if sp.attached {
+		return errSpoolAttached
+	}
+	if !proto.Equal(sp.sub, sub) {
+		return errSpoolMismatch
+	}
+	if sp.idleTimer != nil {
+		sp.idleTimer.Stop()
+		sp.idleTimer = nil
+	}
+	sp.attached = true
+
+	// Re-transmit all unacknowledged events to the new stream
+	if sp.lost != nil {
+		sp.lost.sentAt = time.Time{}
+	}
+	for _, se := range sp.events {
+		se.sentAt = time.Time{}
+	}
+
+	glog.V(1).Infof("Reattached to delivery spool %s with %d events",
+		sp.id, len(sp.events))
+	return nil
+}
+
+// attach returns the spool for the given delivery ID, creating it and
+// subscribing to events if it doesn't already exist. Only one stream may be
+// attached to a spool at a time.
+func (m *deliverySpoolMap) attach(s *Sensor, id string, sub *api.Subscription) (*deliverySpool, error) {
+	m.Lock()
+	if sp, ok := m.spools[id]; ok {
+		defer m.Unlock()
+		if err := sp.reattach(sub); err != nil {
+			return nil, err
+		}
+		return sp, nil
+	}
+	m.Unlock()
+
+	// Keep a pristine copy of the subscription to compare against when
+	// a client reconnects, because subscribing may rewrite its filters.
+	pristine := proto.Clone(sub).(*api.Subscription)
+
+	// Subscribing registers events with the sensor, which may take a
+	// while, so don't hold up the other spools while doing it.
+	eventStream, err := s.NewSubscription(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Lock()
+	sp, ok := m.spools[id]
+	if ok {
+		// Another stream created the spool while subscribing
+		err = sp.reattach(pristine)
+	} else {
+		sp = newDeliverySpool(id, m.maxLength, m.ackTimeout)
+		sp.sub = pristine
+		sp.sensor = s
+		sp.eventStream = eventStream
+		sp.attached = true
+		m.spools[id] = sp
+	}
+	m.Unlock()
+
+	if ok {
+		eventStream.Close()
+		if err != nil {
+			return nil, err
+		}
+		return sp, nil
+	}
+
+	go sp.run()
+	return sp, nil
+}
+
+// detach detaches a stream from a spool. If the spool is finished, it is
+// removed; otherwise, it is removed after it has been idle for too long.
+func (m *deliverySpoolMap) detach(sp *deliverySpool) {
+	m.Lock()
+	defer m.Unlock()
+
+	sp.Lock()
+	defer sp.Unlock()
+
+	sp.attached = false
+	if sp.closed && sp.empty() {
+		delete(m.spools, sp.id)
+		sp.sensor.spoolDroppedEvents.remove(sp.id)
+		return
+	}
+
+	glog.V(1).Infof("Detached from delivery spool %s with %d events",
+		sp.id, len(sp.events))
+	sp.idleTimer = time.AfterFunc(m.idleTimeout, func() {
+		m.expire(sp)
+	})
+}
+
+func (m *deliverySpoolMap) expire(sp *deliverySpool) {
+	m.Lock()
+	sp.Lock()
+
+	if sp.attached || m.spools[sp.id] != sp {
+		sp.Unlock()
+		m.Unlock()
+		return
+	}
+
+	glog.V(1).Infof("Delivery spool %s expired with %d events",
+		sp.id, len(sp.events))
+	delete(m.spools, sp.id)
+	sp.sensor.spoolDroppedEvents.remove(sp.id)
+	sp.events = nil
+	sp.lost = nil
+
+	sp.Unlock()
+	m.Unlock()
+
+	sp.eventStream.Close()
+}
+
+// ack acknowledges the event identified by an ack. Acks for spools that no
+// longer exist are ignored.
+func (m *deliverySpoolMap) ack(b []byte) error {
+	id, generation, seq, err := parseAckToken(b)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	sp, ok := m.spools[id]
+	m.Unlock()
+
+	if ok && sp.generation == generation {
+		sp.ack(seq)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestDeliverySpool(t *testing.T) {
+	sp := newDeliverySpool("test", 3, time.Second)
+	now := time.Now()
+
+	for i, id := range []string{"a", "b", "c", "d"} {
+		sp.add(&api.TelemetryEvent{
+			Id:                   id,
+			SensorSequenceNumber: uint64(i + 1),
+		})
+	}
+
+	// The oldest event should have been discarded and reported first
+	events, wait, finished := sp.due(now)
+	if len(events) != 4 || events[1].Event.Id != "b" {
+		t.Fatalf("Expected lost event and events b, c, and d, got %v",
+			events)
+	}
+	lost := events[0].Event.GetLost()
+	if lost == nil || lost.Lost != 1 ||
+		lost.Type != api.LostEventType_LOST_EVENT_TYPE_DELIVERY_SPOOL ||
+		lost.FirstSequenceNumber != 1 || lost.LastSequenceNumber != 1 {
+		t.Fatalf("Unexpected lost event %v", events[0].Event)
+	}
+	_, _, lostSeq, _ := parseAckToken(events[0].Ack)
+	if !sp.ack(lostSeq) {
+		t.Errorf("Failed to ack lost event %d", lostSeq)
+	}
+	events = events[1:]
+	if wait != time.Second {
+		t.Errorf("Expected to wait %s, got %s", time.Second, wait)
+	}
+	if finished {
+		t.Error("Unexpected finished spool")
+	}
+
+	if events, _, _ = sp.due(now); len(events) != 0 {
+		t.Errorf("Unexpected re-transmission of %v", events)
+	}
+
+	events, _, _ = sp.due(now.Add(time.Second))
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 re-transmitted events, got %d", len(events))
+	}
+
+	for _, te := range events[:2] {
+		id, generation, seq, err := parseAckToken(te.Ack)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "test" || generation != sp.generation {
+			t.Errorf("Unexpected ack %v", te.Ack)
+		}
+		if !sp.ack(seq) {
+			t.Errorf("Failed to ack %d", seq)
+		}
+		if sp.ack(seq) {
+			t.Errorf("Unexpected second ack of %d", seq)
+		}
+	}
+
+	events, _, _ = sp.due(now.Add(2 * time.Second))
+	if len(events) != 1 || events[0].Event.Id != "d" {
+		t.Fatalf("Expected only event d, got %v", events)
+	}
+
+	sp.closed = true
+	_, _, seq, _ := parseAckToken(events[0].Ack)
+	sp.ack(seq)
+	if _, _, finished = sp.due(now.Add(3 * time.Second)); !finished {
+		t.Error("Expected finished spool")
+	}
+
+	if _, _, _, err := parseAckToken([]byte("short")); err == nil {
+		t.Error("Expected error for invalid ack")
+	}
+}
+
+func TestDeliverySpoolLost(t *testing.T) {
+	sp := newDeliverySpool("test", 1, time.Second)
+	now := time.Now()
+
+	add := func(n uint64) {
+		sp.add(&api.TelemetryEvent{SensorSequenceNumber: n})
+	}
+	lost := func(events []*api.ReceivedTelemetryEvent) (*api.LostEvent, uint64) {
+		if len(events) == 0 || events[0].Event.GetLost() == nil {
+			t.Fatalf("Expected lost event first, got %v", events)
+		}
+		_, _, seq, _ := parseAckToken(events[0].Ack)
+		return events[0].Event.GetLost(), seq
+	}
+
+	add(1)
+	add(2)
+	add(3)
+
+	// Unsent lost events are extended in place
+	events, _, _ := sp.due(now)
+	l, seq := lost(events)
+	if l.Lost != 2 || l.FirstSequenceNumber != 1 || l.LastSequenceNumber != 2 {
+		t.Fatalf("Unexpected lost event %v", l)
+	}
+
+	// Sent lost events are replaced, so that acks for them don't cover
+	// the events discarded since
+	add(4)
+	if sp.ack(seq) {
+		t.Errorf("Unexpected ack of replaced lost event %d", seq)
+	}
+	events, _, _ = sp.due(now)
+	l, seq = lost(events)
+	if l.Lost != 3 || l.FirstSequenceNumber != 1 || l.LastSequenceNumber != 3 {
+		t.Fatalf("Unexpected lost event %v", l)
+	}
+	if !sp.ack(seq) {
+		t.Errorf("Failed to ack lost event %d", seq)
+	}
+
+	sp.closed = true
+	events, _, _ = sp.due(now.Add(time.Second))
+	if len(events) != 1 || events[0].Event.GetLost() != nil {
+		t.Fatalf("Expected only event 4, got %v", events)
+	}
+	_, _, seq, _ = parseAckToken(events[0].Ack)
+	sp.ack(seq)
+	if _, _, finished := sp.due(now.Add(2 * time.Second)); !finished {
+		t.Error("Expected finished spool")
+	}
+}
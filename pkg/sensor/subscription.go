@@ -22,8 +22,15 @@ import (
 type subscriptionUnregisterFn func(eventID uint64, sub *subscription)
 
 type subscription struct {
-	data       chan interface{}
-	unregister subscriptionUnregisterFn
+	data         chan interface{}
+	unregister   subscriptionUnregisterFn
+	lineageDepth int
+
+	// Shared by all of the registered events of a modifiable
+	// subscription, whose event filters are registered separately, so
+	// that a kernel event matching more than one of them is only sent
+	// once.
+	copies *eventCopies
 }
 
 //
@@ -15,6 +15,7 @@
 package sensor
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -27,6 +28,36 @@ import (
 	"github.com/golang/glog"
 )
 
+var syscallEventTypes = expression.FieldTypeMap{
+	"id":   int32(api.ValueType_SINT64),
+	"arg0": int32(api.ValueType_UINT64),
+	"arg1": int32(api.ValueType_UINT64),
+	"arg2": int32(api.ValueType_UINT64),
+	"arg3": int32(api.ValueType_UINT64),
+	"arg4": int32(api.ValueType_UINT64),
+	"arg5": int32(api.ValueType_UINT64),
+	"ret":  int32(api.ValueType_SINT64),
+}
+
+func syscallEventValues(sev *api.SyscallEvent) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"id": sev.Id,
+	}
+
+	switch sev.Type {
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER:
+		values["arg0"] = sev.Arg0
+		values["arg1"] = sev.Arg1
+		values["arg2"] = sev.Arg2
+		values["arg3"] = sev.Arg3
+		values["arg4"] = sev.Arg4
+		values["arg5"] = sev.Arg5
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
+		values["ret"] = sev.Ret
+	}
+	return values
+}
+
 type syscallFilter struct {
 	sensor *Sensor
 }
@@ -184,38 +215,68 @@ const (
 		"arg5=+64(%di):u64" // r9
 )
 
+// syscallEventProbes returns the names of the tracepoints and kprobes that
+// syscall events of the given type are collected from.
+func syscallEventProbes(t api.SyscallEventType) []string {
+	switch t {
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER:
+		return []string{
+			syscallNewEnterKprobeAddress,
+			syscallOldEnterKprobeAddress,
+		}
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
+		return []string{"raw_syscalls/sys_exit"}
+	}
+	return nil
+}
+
+// syscallEventFilterString returns the kernel filter string for a syscall
+// event filter, or an error if the filter is invalid.
+func syscallEventFilterString(sef *api.SyscallEventFilter) (string, error) {
+	// Translate deprecated fields into an expression
+	rewriteSyscallEventFilter(sef)
+
+	switch sef.Type {
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
+	default:
+		return "", fmt.Errorf("unsupported syscall event type %s",
+			sef.Type)
+	}
+
+	if !containsIDFilter(sef.FilterExpression) {
+		// No wildcard filters for now
+		return "", errors.New("syscall event filter must match on id")
+	}
+
+	expr, err := expression.NewExpression(sef.FilterExpression)
+	if err != nil {
+		return "", err
+	}
+	err = expr.ValidateKernelFilter()
+	if err != nil {
+		return "", fmt.Errorf("invalid kernel filter: %s", err)
+	}
+
+	return expr.KernelFilterString(), nil
+}
+
 func registerSyscallEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.SyscallEventFilter) {
 	enterFilters := make(map[string]bool)
 	exitFilters := make(map[string]bool)
 
 	for _, sef := range events {
-		// Translate deprecated fields into an expression
-		rewriteSyscallEventFilter(sef)
-
-		if !containsIDFilter(sef.FilterExpression) {
-			// No wildcard filters for now
-			continue
-		}
-
-		expr, err := expression.NewExpression(sef.FilterExpression)
+		s, err := syscallEventFilterString(sef)
 		if err != nil {
 			glog.V(1).Infof("Invalid syscall event filter: %s", err)
 			continue
 		}
-		err = expr.ValidateKernelFilter()
-		if err != nil {
-			glog.V(1).Infof("Invalid syscall event filter as kernel filter: %s", err)
-			continue
-		}
-		s := expr.KernelFilterString()
 
 		switch sef.Type {
 		case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER:
 			enterFilters[s] = true
 		case api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
 			exitFilters[s] = true
-		default:
-			continue
 		}
 	}
 
@@ -238,11 +299,12 @@ func registerSyscallEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 			// adds events into the ringbuffer by using a filter
 			// that will never evaluate true.
 			eventName := "raw_syscalls/sys_enter"
-			eventID, err := sensor.monitor.RegisterTracepoint(
+			eventID, err := sensor.registerTracepoint(
 				eventName, f.decodeDummySysEnter,
 				perf.WithFilter("id == 0x7fffffff"))
 			if err != nil {
 				glog.V(1).Infof("Couldn't register dummy syscall event %s: %v", eventName, err)
+				sensor.probeFailed(eventName, err)
 				atomic.AddInt64(&sensor.dummySyscallEventCount, -1)
 			} else {
 				sensor.dummySyscallEventID = eventID
@@ -256,13 +318,13 @@ func registerSyscallEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 		// fetchargs doesn't have to change. Try the new probe first,
 		// because the old probe will also set in the newer kernels,
 		// but it won't fire.
-		eventID, err := sensor.monitor.RegisterKprobe(
+		eventID, err := sensor.registerKprobe(
 			syscallNewEnterKprobeAddress, false,
 			syscallEnterKprobeFetchargs,
 			f.decodeSyscallTraceEnter,
 			perf.WithFilter(filter))
 		if err != nil {
-			eventID, err = sensor.monitor.RegisterKprobe(
+			eventID, err = sensor.registerKprobe(
 				syscallOldEnterKprobeAddress, false,
 				syscallEnterKprobeFetchargs,
 				f.decodeSyscallTraceEnter,
@@ -270,7 +332,9 @@ func registerSyscallEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 		}
 		if err != nil {
 			glog.V(1).Infof("Couldn't register syscall enter kprobe: %v", err)
+			sensor.probeFailed(syscallOldEnterKprobeAddress, err)
 		} else {
+			sensor.probeSucceeded(syscallOldEnterKprobeAddress)
 			eventMap[eventID] = &subscription{
 				unregister: func(uint64, *subscription) {
 					eventID := sensor.dummySyscallEventID
@@ -290,10 +354,11 @@ func registerSyscallEvents(sensor *Sensor, eventMap subscriptionMap, events []*a
 		filter := strings.Join(filters, " || ")
 
 		eventName := "raw_syscalls/sys_exit"
-		eventID, err := sensor.monitor.RegisterTracepoint(eventName, f.decodeSysExit,
+		eventID, err := sensor.registerTracepoint(eventName, f.decodeSysExit,
 			perf.WithFilter(filter))
 		if err != nil {
 			glog.V(1).Infof("Couldn't get %s event id: %v", eventName, err)
+			sensor.probeFailed(eventName, err)
 		} else {
 			eventMap[eventID] = &subscription{}
 		}
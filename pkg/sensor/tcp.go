@@ -0,0 +1,429 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	tcpSetStateTracepoint = "sock/inet_sock_set_state"
+
+	tcpEstablished = 1
+	tcpSynSent     = 2
+	tcpSynRecv     = 3
+	tcpFinWait1    = 4
+	tcpClose       = 7
+	tcpCloseWait   = 8
+	tcpLastAck     = 9
+	tcpListen      = 10
+
+	ipprotoTCP = 6
+
+	afInet  = 2
+	afInet6 = 10
+
+	// The maximum number of sockets tracked by a tcpSocketCache. Owners
+	// are kept after sockets close so that events for the close can be
+	// attributed, and replaced when a socket address is reused, so the
+	// cache is reset if it grows this large.
+	tcpSocketCacheSize = 65536
+
+	// The transitions that a tcpSocketCache needs to see to learn the
+	// owners of sockets: connect(), listen(), accepting a connection and
+	// closing a listening socket.
+	tcpSocketCacheFilter = "(oldstate == 7 && (newstate == 2 || newstate == 10)) || (oldstate == 3 && newstate == 1) || (oldstate == 10 && newstate == 7)"
+)
+
+// The fields of the inet_sock_set_state tracepoint that may be used in
+// filters for TCP network events. Ports are in host byte order.
+var tcpEventTypes = expression.FieldTypeMap{
+	"oldstate": int32(api.ValueType_SINT32),
+	"newstate": int32(api.ValueType_SINT32),
+	"sport":    int32(api.ValueType_UINT16),
+	"dport":    int32(api.ValueType_UINT16),
+}
+
+// The kernel filters selecting the transitions reported by each type of TCP
+// network event.
+var tcpEventStateFilters = map[api.NetworkEventType]string{
+	api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED:  "newstate == 1",
+	api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED:       "newstate == 7",
+	api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_STATE_CHANGE: "newstate != 1 && newstate != 7",
+}
+
+func isTCPEventType(t api.NetworkEventType) bool {
+	_, ok := tcpEventStateFilters[t]
+	return ok
+}
+
+func tcpEventType(newState int32) api.NetworkEventType {
+	switch newState {
+	case tcpEstablished:
+		return api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED
+	case tcpClose:
+		return api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED
+	}
+	return api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_STATE_CHANGE
+}
+
+func tcpEventValues(nev *api.NetworkEvent) expression.FieldValueMap {
+	return expression.FieldValueMap{
+		"oldstate": int32(nev.OldTcpState),
+		"newstate": int32(nev.NewTcpState),
+		"sport":    networkToHost16(networkAddressPort(nev.LocalAddress)),
+		"dport":    networkToHost16(networkAddressPort(nev.RemoteAddress)),
+	}
+}
+
+// networkToHost16 swaps the bytes of a port in network byte order read as a
+// little endian integer, as ports are reported in network events.
+func networkToHost16(port uint16) uint16 {
+	return port<<8 | port>>8
+}
+
+func networkAddressPort(addr *api.NetworkAddress) uint16 {
+	switch addr.GetFamily() {
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET:
+		return uint16(addr.GetIpv4Address().GetPort())
+	case api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6:
+		return uint16(addr.GetIpv6Address().GetPort())
+	}
+	return 0
+}
+
+// tcpEventFilterString restricts a kernel filter string for
+// inet_sock_set_state to the transitions reported by a type of TCP network
+// event.
+func tcpEventFilterString(t api.NetworkEventType, filterString string) string {
+	s := tcpEventStateFilters[t]
+	if len(filterString) == 0 {
+		return s
+	}
+	return fmt.Sprintf("%s && (%s)", s, filterString)
+}
+
+// tcpFilterString returns the kernel filter string for the single
+// registration of inet_sock_set_state that collects all of the types of TCP
+// network events requested by a subscription.
+func tcpFilterString(filters map[api.NetworkEventType]map[string]int) (string, bool) {
+	var parts []string
+	for t, m := range filters {
+		s, active := fullFilterString(m)
+		if !active {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("(%s)", tcpEventFilterString(t, s)))
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, " || "), true
+}
+
+// sampleBytes converts an array field of a trace event sample to bytes.
+func sampleBytes(data perf.TraceEventSampleData, name string) []byte {
+	array, ok := data[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	b := make([]byte, len(array))
+	for i, v := range array {
+		b[i], _ = v.(uint8)
+	}
+	return b
+}
+
+func isIPv4MappedAddress(addr []byte) bool {
+	for i := 0; i < 10; i++ {
+		if addr[i] != 0 {
+			return false
+		}
+	}
+	return addr[10] == 0xff && addr[11] == 0xff
+}
+
+// inetSockAddress returns a network address from the addresses and port of
+// an inet_sock_set_state sample. Older kernels do not report the address
+// family, in which case IPv4 sockets are recognized by their IPv4-mapped
+// IPv6 addresses.
+func inetSockAddress(data perf.TraceEventSampleData, addrName string, portName string) *api.NetworkAddress {
+	addr := sampleBytes(data, addrName)
+	addr6 := sampleBytes(data, addrName+"_v6")
+	if len(addr) != 4 || len(addr6) != 16 {
+		return nil
+	}
+
+	family, ok := data["family"].(uint16)
+	if !ok {
+		if isIPv4MappedAddress(addr6) {
+			family = afInet
+		} else {
+			family = afInet6
+		}
+	}
+
+	// Ports are reported in network byte order, consistent with the
+	// addresses fetched from sockaddrs by the other network events.
+	port := uint32(networkToHost16(data[portName].(uint16)))
+
+	switch family {
+	case afInet:
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+			Address: &api.NetworkAddress_Ipv4Address{
+				Ipv4Address: &api.IPv4AddressAndPort{
+					Address: &api.IPv4Address{
+						Address: binary.LittleEndian.Uint32(addr),
+					},
+					Port: port,
+				},
+			},
+		}
+	case afInet6:
+		return &api.NetworkAddress{
+			Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+			Address: &api.NetworkAddress_Ipv6Address{
+				Ipv6Address: &api.IPv6AddressAndPort{
+					Address: &api.IPv6Address{
+						High: binary.LittleEndian.Uint64(addr6[0:8]),
+						Low:  binary.LittleEndian.Uint64(addr6[8:16]),
+					},
+					Port: port,
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// isTCPSample returns false if an inet_sock_set_state sample is known to be
+// for a protocol other than TCP. Depending on the kernel version, the
+// protocol field may be absent, or a u8 or u16.
+func isTCPSample(data perf.TraceEventSampleData) bool {
+	switch protocol := data["protocol"].(type) {
+	case uint8:
+		return protocol == ipprotoTCP
+	case uint16:
+		return protocol == ipprotoTCP
+	}
+	return true
+}
+
+func (f *networkFilter) decodeInetSockSetState(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	if !isTCPSample(data) {
+		return nil, nil
+	}
+
+	local := inetSockAddress(data, "saddr", "sport")
+	remote := inetSockAddress(data, "daddr", "dport")
+	if local == nil || remote == nil {
+		return nil, nil
+	}
+
+	oldState := data["oldstate"].(int32)
+	newState := data["newstate"].(int32)
+
+	var pid int32
+	if f.sensor.tcpSockets != nil {
+		pid = f.sensor.tcpSockets.owner(data["skaddr"].(uint64),
+			oldState, newState, data["sport"].(uint16),
+			data["common_pid"].(int32))
+	}
+
+	event := f.sensor.newEventFromPid(sample, pid)
+	event.Event = &api.TelemetryEvent_Network{
+		Network: &api.NetworkEvent{
+			Type:          tcpEventType(newState),
+			LocalAddress:  local,
+			RemoteAddress: remote,
+			OldTcpState:   api.TCPState(oldState),
+			NewTcpState:   api.TCPState(newState),
+		},
+	}
+
+	return event, nil
+}
+
+// tcpSocketCache tracks the processes that own TCP sockets. Most TCP state
+// transitions happen while handling packets in softirq context, where the
+// process that happens to be running has nothing to do with the socket, so
+// the owners of sockets are learned from the transitions made by connect(),
+// listen(), and accepting connections on listening sockets.
+//
+// Accepted connections only report the port they were accepted on, so they
+// are attributed to the process owning the listening sockets on that port.
+// Sockets in different network namespaces may listen on the same port, and
+// connections accepted on a port with listeners owned by more than one
+// process are not attributed.
+type tcpSocketCache struct {
+	sync.Mutex
+	sensor *Sensor
+
+	// Registration is serialized separately so that samples can be
+	// decoded while the tracepoint is registered or unregistered. The
+	// tracepoint is registered while any subscription has registered TCP
+	// network events.
+	registerLock sync.Mutex
+	refs         int
+	eventID      uint64
+
+	owners    map[uint64]int32            // socket address : pid
+	listeners map[uint16]map[uint64]int32 // local port : socket address : pid
+}
+
+func newTCPSocketCache(sensor *Sensor) *tcpSocketCache {
+	return &tcpSocketCache{
+		sensor: sensor,
+	}
+}
+
+// register starts tracking socket owners for a subscription, returning false
+// if they can't be tracked. inet_sock_set_state was added in Linux 4.16, so
+// it is only registered while subscriptions ask for TCP network events;
+// sockets created before then are not attributed. Failures are reported by
+// the subscription's own registration of the tracepoint.
+func (c *tcpSocketCache) register() bool {
+	c.registerLock.Lock()
+	defer c.registerLock.Unlock()
+
+	if c.refs == 0 {
+		eventID, err := c.sensor.registerTracepoint(
+			tcpSetStateTracepoint, c.decodeInetSockSetState,
+			perf.WithFilter(tcpSocketCacheFilter))
+		if err != nil {
+			glog.V(1).Infof("Couldn't register event %s: %s",
+				tcpSetStateTracepoint, err)
+			return false
+		}
+		c.eventID = eventID
+	}
+	c.refs++
+	return true
+}
+
+// unregister stops tracking socket owners for a subscription that was
+// registered. Once no subscriptions remain, the tracepoint is unregistered
+// and the owners that were learned are forgotten.
+func (c *tcpSocketCache) unregister() {
+	c.registerLock.Lock()
+	defer c.registerLock.Unlock()
+
+	c.refs--
+	if c.refs > 0 {
+		return
+	}
+	c.sensor.monitor.UnregisterEvent(c.eventID)
+
+	c.Lock()
+	c.owners = nil
+	c.listeners = nil
+	c.Unlock()
+}
+
+func (c *tcpSocketCache) decodeInetSockSetState(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	if isTCPSample(data) {
+		c.update(data["skaddr"].(uint64), data["oldstate"].(int32),
+			data["newstate"].(int32), data["sport"].(uint16),
+			data["common_pid"].(int32))
+	}
+	return nil, nil
+}
+
+func (c *tcpSocketCache) update(skaddr uint64, oldState, newState int32, sport uint16, pid int32) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.owners == nil || len(c.owners) >= tcpSocketCacheSize {
+		c.owners = make(map[uint64]int32)
+	}
+	if c.listeners == nil {
+		c.listeners = make(map[uint16]map[uint64]int32)
+	}
+
+	switch {
+	case oldState == tcpClose && newState == tcpSynSent:
+		c.owners[skaddr] = pid
+	case oldState == tcpClose && newState == tcpListen:
+		c.owners[skaddr] = pid
+		if c.listeners[sport] == nil {
+			c.listeners[sport] = make(map[uint64]int32)
+		}
+		c.listeners[sport][skaddr] = pid
+	case oldState == tcpSynRecv && newState == tcpEstablished:
+		if owner, ok := c.listener(sport); ok {
+			c.owners[skaddr] = owner
+		}
+	case oldState == tcpListen && newState == tcpClose:
+		delete(c.listeners[sport], skaddr)
+		if len(c.listeners[sport]) == 0 {
+			delete(c.listeners, sport)
+		}
+	}
+}
+
+// listener returns the pid of the process owning the sockets listening on a
+// port, if they are all owned by the same process. It must be called with
+// the cache locked.
+func (c *tcpSocketCache) listener(sport uint16) (int32, bool) {
+	var (
+		owner int32
+		found bool
+	)
+	for _, pid := range c.listeners[sport] {
+		if found && pid != owner {
+			return 0, false
+		}
+		owner, found = pid, true
+	}
+	return owner, found
+}
+
+// owner returns the pid of the process that owns a socket undergoing a state
+// transition, or 0 if it is not known. Connections are closed by processes
+// in process context, so the running process is used for those transitions
+// if the owner is not otherwise known.
+func (c *tcpSocketCache) owner(skaddr uint64, oldState, newState int32, sport uint16, pid int32) int32 {
+	c.Lock()
+	defer c.Unlock()
+
+	if oldState == tcpSynRecv && newState == tcpEstablished {
+		if owner, ok := c.listener(sport); ok {
+			return owner
+		}
+	}
+	if owner, ok := c.owners[skaddr]; ok {
+		return owner
+	}
+
+	switch {
+	case oldState == tcpClose && (newState == tcpSynSent || newState == tcpListen):
+		return pid
+	case oldState == tcpEstablished && newState == tcpFinWait1:
+		return pid
+	case oldState == tcpCloseWait && newState == tcpLastAck:
+		return pid
+	}
+	return 0
+}
@@ -0,0 +1,217 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func sampleArray(b ...uint8) []interface{} {
+	array := make([]interface{}, len(b))
+	for i, v := range b {
+		array[i] = v
+	}
+	return array
+}
+
+func inetSockSetStateSample(pid int32, skaddr uint64, oldState, newState int32, sport, dport uint16) perf.TraceEventSampleData {
+	return perf.TraceEventSampleData{
+		"common_pid": pid,
+		"skaddr":     skaddr,
+		"oldstate":   oldState,
+		"newstate":   newState,
+		"sport":      sport,
+		"dport":      dport,
+		"family":     uint16(afInet),
+		"protocol":   uint16(ipprotoTCP),
+		"saddr":      sampleArray(10, 0, 0, 1),
+		"daddr":      sampleArray(10, 0, 0, 2),
+		"saddr_v6": sampleArray(0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0xff, 0xff, 10, 0, 0, 1),
+		"daddr_v6": sampleArray(0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0xff, 0xff, 10, 0, 0, 2),
+	}
+}
+
+func TestInetSockAddress(t *testing.T) {
+	data := inetSockSetStateSample(0, 0, tcpSynSent, tcpEstablished,
+		43210, 80)
+	delete(data, "family")
+
+	addr := inetSockAddress(data, "daddr", "dport")
+	a := addr.GetIpv4Address()
+	if a == nil || a.Address.Address != 0x0200000a || a.Port != 0x5000 {
+		t.Errorf("Unexpected IPv4 address %+v", addr)
+	}
+
+	data["saddr_v6"] = sampleArray(0xfe, 0x80, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 1)
+	addr = inetSockAddress(data, "saddr", "sport")
+	a6 := addr.GetIpv6Address()
+	if a6 == nil || a6.Address.High != 0x80fe ||
+		a6.Address.Low != 0x0100000000000000 || a6.Port != 0xcaa8 {
+		t.Errorf("Unexpected IPv6 address %+v", addr)
+	}
+
+	data["family"] = uint16(1)
+	if addr = inetSockAddress(data, "saddr", "sport"); addr != nil {
+		t.Errorf("Expected no address for AF_LOCAL, got %+v", addr)
+	}
+}
+
+func TestTCPSocketCache(t *testing.T) {
+	c := &tcpSocketCache{}
+
+	// A connection made by pid 100 and established in softirq context
+	c.update(0x1000, tcpClose, tcpSynSent, 43210, 100)
+	if pid := c.owner(0x1000, tcpSynSent, tcpEstablished, 43210, 7); pid != 100 {
+		t.Errorf("Expected owner 100 for connection, got %d", pid)
+	}
+
+	// A connection accepted on a socket listened on by pid 200
+	c.update(0x2000, tcpClose, tcpListen, 80, 200)
+	if pid := c.owner(0x3000, tcpSynRecv, tcpEstablished, 80, 7); pid != 200 {
+		t.Errorf("Expected owner 200 for accepted connection, got %d", pid)
+	}
+	c.update(0x3000, tcpSynRecv, tcpEstablished, 80, 7)
+	if pid := c.owner(0x3000, tcpEstablished, tcpCloseWait, 80, 7); pid != 200 {
+		t.Errorf("Expected owner 200 after accept, got %d", pid)
+	}
+
+	// Unknown sockets are attributed to the running process only for
+	// transitions made in process context
+	if pid := c.owner(0x4000, tcpEstablished, tcpFinWait1, 22, 300); pid != 300 {
+		t.Errorf("Expected owner 300 for close, got %d", pid)
+	}
+	if pid := c.owner(0x4000, tcpFinWait1, 5, 22, 7); pid != 0 {
+		t.Errorf("Expected unknown owner, got %d", pid)
+	}
+
+	// Closing the listening socket forgets the listener
+	c.update(0x2000, tcpListen, tcpClose, 80, 200)
+	if pid := c.owner(0x5000, tcpSynRecv, tcpEstablished, 80, 7); pid != 0 {
+		t.Errorf("Expected unknown owner after listener closed, got %d", pid)
+	}
+
+	// Closing one of several sockets listening on a port keeps the others
+	c.update(0x6000, tcpClose, tcpListen, 443, 400)
+	c.update(0x7000, tcpClose, tcpListen, 443, 400)
+	c.update(0x6000, tcpListen, tcpClose, 443, 400)
+	if pid := c.owner(0x8000, tcpSynRecv, tcpEstablished, 443, 7); pid != 400 {
+		t.Errorf("Expected owner 400 for remaining listener, got %d", pid)
+	}
+
+	// Listeners on the same port owned by different processes, such as
+	// in different network namespaces, are ambiguous
+	c.update(0x9000, tcpClose, tcpListen, 443, 500)
+	if pid := c.owner(0xa000, tcpSynRecv, tcpEstablished, 443, 7); pid != 0 {
+		t.Errorf("Expected unknown owner for ambiguous listeners, got %d", pid)
+	}
+	c.update(0x7000, tcpListen, tcpClose, 443, 400)
+	if pid := c.owner(0xb000, tcpSynRecv, tcpEstablished, 443, 7); pid != 500 {
+		t.Errorf("Expected owner 500 for remaining listener, got %d", pid)
+	}
+}
+
+func TestTCPFilterString(t *testing.T) {
+	filters := map[api.NetworkEventType]map[string]int{
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CLOSED: map[string]int{
+			"": 1,
+		},
+	}
+	s, active := tcpFilterString(filters)
+	if !active || s != "(newstate == 7)" {
+		t.Errorf("Unexpected filter %q", s)
+	}
+
+	filters = map[api.NetworkEventType]map[string]int{
+		api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED: map[string]int{
+			"dport == 443": 1,
+		},
+	}
+	s, active = tcpFilterString(filters)
+	if !active || s != "(newstate == 1 && ((dport == 443)))" {
+		t.Errorf("Unexpected filter %q", s)
+	}
+
+	if _, active = tcpFilterString(nil); active {
+		t.Errorf("Expected no filter")
+	}
+
+	nef := &api.NetworkEventFilter{
+		Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED,
+		FilterExpression: expression.Equal(
+			expression.Identifier("sin_port"),
+			expression.Value(uint16(80))),
+	}
+	if _, err := networkEventFilterString(nef); err == nil {
+		t.Errorf("Expected error for unknown TCP event field")
+	}
+}
+
+func TestTCPEvents(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+		tcpSockets: &tcpSocketCache{},
+	}
+	f := networkFilter{
+		sensor: s,
+	}
+
+	s.tcpSockets.update(0x1000, tcpClose, tcpListen, 80, 100)
+
+	i, err := f.decodeInetSockSetState(&perf.SampleRecord{},
+		inetSockSetStateSample(0, 0x2000, tcpSynRecv, tcpEstablished,
+			80, 43210))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := i.(*api.TelemetryEvent)
+	nev := ev.GetNetwork()
+	if nev == nil ||
+		nev.Type != api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ESTABLISHED ||
+		nev.OldTcpState != api.TCPState_TCP_STATE_SYN_RECV ||
+		nev.NewTcpState != api.TCPState_TCP_STATE_ESTABLISHED ||
+		nev.LocalAddress.GetIpv4Address().GetPort() != 0x5000 ||
+		nev.RemoteAddress.GetIpv4Address().GetAddress().GetAddress() != 0x0200000a {
+		t.Errorf("Unexpected TCP event %+v", nev)
+	}
+	if ev.ProcessPid != 100 {
+		t.Errorf("Expected event for pid 100, got %d", ev.ProcessPid)
+	}
+
+	values := networkEventValues(nev)
+	if values["sport"] != uint16(80) || values["dport"] != uint16(43210) ||
+		values["newstate"] != int32(tcpEstablished) {
+		t.Errorf("Unexpected TCP event values %+v", values)
+	}
+
+	data := inetSockSetStateSample(0, 0x3000, tcpClose, tcpSynSent, 0, 53)
+	data["protocol"] = uint16(17)
+	i, err = f.decodeInetSockSetState(&perf.SampleRecord{}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i != nil {
+		t.Errorf("Expected no event for non-TCP socket, got %+v", i)
+	}
+}
@@ -19,19 +19,24 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
 	"strings"
+	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/golang/glog"
 
+	"golang.org/x/net/context"
 	"golang.org/x/sys/unix"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 // TelemetryService is a service that can be used with the ServiceManager to
@@ -58,18 +63,15 @@ func (ts *TelemetryService) Name() string {
 	return "gRPC Telemetry Server"
 }
 
-// Serve is the main entrypoint for the TelemetryService. It is normally called
-// by the ServiceManager. It will service requests indefinitely from the calling
-// Goroutine.
-func (ts *TelemetryService) Serve() error {
+// listen creates a listener for a service address, which may be specified as
+// unix:/path/to/socket or as a TCP address.
+func listen(address string) (net.Listener, error) {
 	var (
 		err error
 		lis net.Listener
 	)
 
-	glog.V(1).Info("Serving gRPC API on ", ts.address)
-
-	parts := strings.Split(ts.address, ":")
+	parts := strings.Split(address, ":")
 	if len(parts) > 1 && parts[0] == "unix" {
 		socketPath := parts[1]
 
@@ -101,9 +103,54 @@ func (ts *TelemetryService) Serve() error {
 		lis, err = net.Listen("unix", socketPath)
 		unix.Umask(oldMask)
 	} else {
-		lis, err = net.Listen("tcp", ts.address)
+		lis, err = net.Listen("tcp", address)
+	}
+
+	return lis, err
+}
+
+// loadCACertPool loads the configured certificate authority certificate.
+func loadCACertPool() (*x509.CertPool, error) {
+	certPool := x509.NewCertPool()
+	ca, err := ioutil.ReadFile(config.Sensor.TLSCACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ca certificate: %s", err)
+	}
+
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		return nil, errors.New("failed to append certs")
+	}
+
+	return certPool, nil
+}
+
+// loadTLSConfig loads the TLS configuration for the sensor's services, which
+// require clients to present a certificate signed by the configured CA.
+func loadTLSConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(config.Sensor.TLSServerCertPath, config.Sensor.TLSServerKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server key pair: %s", err)
 	}
 
+	certPool, err := loadCACertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    certPool,
+	}, nil
+}
+
+// Serve is the main entrypoint for the TelemetryService. It is normally called
+// by the ServiceManager. It will service requests indefinitely from the calling
+// Goroutine.
+func (ts *TelemetryService) Serve() error {
+	glog.V(1).Info("Serving gRPC API on ", ts.address)
+
+	lis, err := listen(ts.address)
 	if err != nil {
 		return err
 	}
@@ -113,26 +160,12 @@ func (ts *TelemetryService) Serve() error {
 	if config.Sensor.UseTLS {
 		glog.V(1).Infoln("Starting telemetry server with TLS credentials")
 
-		certificate, err := tls.LoadX509KeyPair(config.Sensor.TLSServerCertPath, config.Sensor.TLSServerKeyPath)
-		if err != nil {
-			return fmt.Errorf("could not load server key pair: %s", err)
-		}
-
-		certPool := x509.NewCertPool()
-		ca, err := ioutil.ReadFile(config.Sensor.TLSCACertPath)
+		tlsConfig, err := loadTLSConfig()
 		if err != nil {
-			return fmt.Errorf("could not read ca certificate: %s", err)
-		}
-
-		if ok := certPool.AppendCertsFromPEM(ca); !ok {
-			return errors.New("failed to append certs")
+			return err
 		}
 
-		creds := credentials.NewTLS(&tls.Config{
-			ClientAuth:   tls.RequireAndVerifyClientCert,
-			Certificates: []tls.Certificate{certificate},
-			ClientCAs:    certPool,
-		})
+		creds := credentials.NewTLS(tlsConfig)
 		ts.server = grpc.NewServer(grpc.Creds(creds))
 	} else {
 		glog.V(1).Infoln("Starting telemetry server")
@@ -141,6 +174,9 @@ func (ts *TelemetryService) Serve() error {
 
 	t := &telemetryServiceServer{
 		sensor: ts.sensor,
+		spools: newDeliverySpoolMap(config.Sensor.DeliverySpoolLength,
+			config.Sensor.DeliveryAckTimeout,
+			config.Sensor.DeliverySpoolIdleTimeout),
 	}
 	api.RegisterTelemetryServiceServer(ts.server, t)
 
@@ -154,6 +190,7 @@ func (ts *TelemetryService) Stop() {
 
 type telemetryServiceServer struct {
 	sensor *Sensor
+	spools *deliverySpoolMap
 }
 
 func (t *telemetryServiceServer) GetEvents(req *api.GetEventsRequest, stream api.TelemetryService_GetEventsServer) error {
@@ -161,6 +198,16 @@ func (t *telemetryServiceServer) GetEvents(req *api.GetEventsRequest, stream api
 
 	glog.V(1).Infof("GetEvents(%+v)", sub)
 
+	err := validationError(t.sensor.validateSubscription(sub))
+	if err != nil {
+		glog.V(1).Infof("Rejecting subscription %+v: %s", sub, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(req.DeliveryId) > 0 {
+		return t.getReliableEvents(req, stream)
+	}
+
 	eventStream, err := t.sensor.NewSubscription(sub)
 	if err != nil {
 		glog.Errorf("Failed to get events for subscription %+v: %s",
@@ -174,27 +221,272 @@ func (t *telemetryServiceServer) GetEvents(req *api.GetEventsRequest, stream api
 		eventStream.Close()
 	}()
 
-sendLoop:
+	return sendEvents(sub, eventStream.Data, stream)
+}
+
+// eventBatchLimits returns the maximum number of events to send in each
+// response and how long to wait for a batch to fill for a subscription.
+func eventBatchLimits(sub *api.Subscription) (int, time.Duration) {
+	maxSize := int(sub.MaxBatchSize)
+	if maxSize < 1 {
+		maxSize = 1
+	} else if maxSize > config.Sensor.MaxEventBatchSize {
+		maxSize = config.Sensor.MaxEventBatchSize
+	}
+
+	return maxSize, time.Duration(sub.MaxBatchLatency)
+}
+
+// nextEventBatch receives the next batch of events from data. It blocks until
+// an event is received and then collects up to maxSize events, waiting up to
+// maxLatency for more events to arrive. It returns false when data has been
+// closed and there are no more events.
+func nextEventBatch(data <-chan interface{}, maxSize int, maxLatency time.Duration) ([]interface{}, bool) {
+	ev, ok := <-data
+	if !ok {
+		return nil, false
+	}
+	batch := []interface{}{ev}
+
+	var timeout <-chan time.Time
+	if maxSize > 1 && maxLatency > 0 {
+		timer := time.NewTimer(maxLatency)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for len(batch) < maxSize {
+		if timeout == nil {
+			select {
+			case ev, ok = <-data:
+			default:
+				return batch, true
+			}
+		} else {
+			select {
+			case ev, ok = <-data:
+			case <-timeout:
+				return batch, true
+			}
+		}
+		if !ok {
+			break
+		}
+		batch = append(batch, ev)
+	}
+
+	return batch, true
+}
+
+// sendEvents sends the events received from data to the client in batches
+// until data is closed.
+func sendEvents(sub *api.Subscription, data <-chan interface{}, stream api.TelemetryService_GetEventsServer) error {
+	maxSize, maxLatency := eventBatchLimits(sub)
+
 	for {
-		ev, ok := <-eventStream.Data
+		batch, ok := nextEventBatch(data, maxSize, maxLatency)
 		if !ok {
-			break sendLoop
+			break
 		}
 
-		// Send back events right away
-		te := &api.ReceivedTelemetryEvent{
-			Event: ev.(*api.TelemetryEvent),
+		publishTime := time.Now().UnixNano() / int64(time.Microsecond)
+		events := make([]*api.ReceivedTelemetryEvent, len(batch))
+		for i, ev := range batch {
+			events[i] = &api.ReceivedTelemetryEvent{
+				PublishTimeMicros: publishTime,
+				Event:             ev.(*api.TelemetryEvent),
+			}
 		}
 
-		err = stream.Send(&api.GetEventsResponse{
-			Events: []*api.ReceivedTelemetryEvent{
-				te,
-			},
+		err := stream.Send(&api.GetEventsResponse{
+			Events: events,
 		})
 		if err != nil {
-			break sendLoop
+			return nil
 		}
 	}
 
+	// A subscription reaching the end of its for_duration ends the
+	// stream normally, the same as the client disconnecting
+	if sub.ForDuration != nil && stream.Context().Err() == nil {
+		glog.V(1).Infof("Subscription expired after %d ns",
+			sub.ForDuration.Value)
+	}
+
 	return nil
 }
+
+// getReliableEvents streams the events from the delivery spool for the
+// requested delivery ID, re-transmitting events that are not acknowledged in
+// time.
+func (t *telemetryServiceServer) getReliableEvents(req *api.GetEventsRequest, stream api.TelemetryService_GetEventsServer) error {
+	sub := req.Subscription
+
+	sp, err := t.spools.attach(t.sensor, req.DeliveryId, sub)
+	if err != nil {
+		glog.Errorf("Failed to get events for delivery ID %s: %s",
+			req.DeliveryId, err.Error())
+		if err == errSpoolAttached || err == errSpoolMismatch {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return err
+	}
+	defer t.spools.detach(sp)
+
+	maxSize, maxLatency := eventBatchLimits(sub)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		events, wait, finished := sp.due(time.Now())
+		for len(events) > 0 {
+			n := len(events)
+			if n > maxSize {
+				n = maxSize
+			}
+			err = stream.Send(&api.GetEventsResponse{
+				Events: events[:n],
+			})
+			if err != nil {
+				return nil
+			}
+			events = events[n:]
+		}
+
+		if finished {
+			if sub.ForDuration != nil {
+				glog.V(1).Infof("Subscription expired after %d ns",
+					sub.ForDuration.Value)
+			}
+			return nil
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var timeout <-chan time.Time
+		if wait > 0 {
+			timer.Reset(wait)
+			timeout = timer.C
+		}
+
+		select {
+		case <-stream.Context().Done():
+			glog.V(1).Infof("Client disconnected from delivery spool %s",
+				req.DeliveryId)
+			return nil
+		case <-sp.notify:
+			if maxSize > 1 && maxLatency > 0 {
+				// Give more events a chance to arrive so
+				// that they can be sent together
+				select {
+				case <-stream.Context().Done():
+				case <-time.After(maxLatency):
+				}
+			}
+		case <-timeout:
+		}
+	}
+}
+
+func (t *telemetryServiceServer) Acknowledge(ctx context.Context, req *api.AcknowledgeRequest) (*api.AcknowledgeResponse, error) {
+	for _, ack := range req.Acks {
+		err := t.spools.ack(ack)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return &api.AcknowledgeResponse{}, nil
+}
+
+func (t *telemetryServiceServer) GetSensorInfo(ctx context.Context, req *api.GetSensorInfoRequest) (*api.GetSensorInfoResponse, error) {
+	return t.sensor.Info(), nil
+}
+
+func (t *telemetryServiceServer) ValidateSubscription(ctx context.Context, req *api.ValidateSubscriptionRequest) (*api.ValidateSubscriptionResponse, error) {
+	return t.sensor.validateSubscription(req.Subscription), nil
+}
+
+func (t *telemetryServiceServer) Subscribe(stream api.TelemetryService_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	sub := req.Subscription
+
+	glog.V(1).Infof("Subscribe(%+v)", sub)
+
+	err = validationError(t.sensor.validateSubscription(sub))
+	if err != nil {
+		glog.V(1).Infof("Rejecting subscription %+v: %s", sub, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	eventStream, ms, err := t.sensor.NewModifiableSubscription(sub)
+	if err != nil {
+		glog.Errorf("Failed to get events for subscription %+v: %s",
+			sub, err.Error())
+		return err
+	}
+	// Modifications are received while events are sent. An invalid
+	// modification ends the stream.
+	modifyErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				// The client may close its side of the stream
+				// and keep receiving events.
+				if err != io.EOF {
+					modifyErr <- nil
+				}
+				return
+			}
+			if req.Modification == nil {
+				modifyErr <- status.Error(codes.InvalidArgument,
+					"modification is required")
+				return
+			}
+			err = ms.Modify(req.Modification)
+			if err != nil {
+				glog.V(1).Infof("Rejecting modification %+v: %s",
+					req.Modification, err)
+				modifyErr <- status.Error(codes.InvalidArgument,
+					err.Error())
+				return
+			}
+		}
+	}()
+
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- sendEvents(sub, eventStream.Data, stream)
+	}()
+
+	select {
+	case err = <-modifyErr:
+		// Stop sending events before ending the stream
+		eventStream.Close()
+		<-sendErr
+	case err = <-sendErr:
+		eventStream.Close()
+	case <-stream.Context().Done():
+		// The client may have closed its side of the stream, so
+		// nothing else notices that it has gone away.
+		glog.V(1).Infof("Client disconnected, closing stream")
+		eventStream.Close()
+		err = <-sendErr
+	}
+
+	// Modifications may be waiting for events to be received, so discard
+	// any that remain.
+	for range eventStream.Data {
+	}
+
+	return err
+}
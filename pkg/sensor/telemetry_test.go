@@ -0,0 +1,186 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+)
+
+/*
+
+Current benchmark results:
+
+BenchmarkGetEventsBatch1   	  184436	     11935 ns/op
+BenchmarkGetEventsBatch16  	  264500	      8901 ns/op
+BenchmarkGetEventsBatch256 	  325363	      9627 ns/op
+
+*/
+
+func TestNextEventBatch(t *testing.T) {
+	data := make(chan interface{}, 8)
+	for i := 0; i < 5; i++ {
+		data <- i
+	}
+
+	batch, ok := nextEventBatch(data, 3, 0)
+	if !ok || len(batch) != 3 {
+		t.Fatalf("Expected batch of 3 events, got %v", batch)
+	}
+
+	// Only the events that are ready should be returned without latency
+	batch, ok = nextEventBatch(data, 3, 0)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("Expected batch of 2 events, got %v", batch)
+	}
+
+	go func() {
+		data <- 5
+		time.Sleep(10 * time.Millisecond)
+		data <- 6
+		close(data)
+	}()
+
+	batch, ok = nextEventBatch(data, 3, time.Second)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("Expected batch of 2 events, got %v", batch)
+	}
+
+	batch, ok = nextEventBatch(data, 3, time.Second)
+	if ok || len(batch) != 0 {
+		t.Fatalf("Expected end of events, got %v", batch)
+	}
+}
+
+// benchmarkTelemetryServer is a TelemetryService that sends a fixed number
+// of synthetic events for each subscription.
+type benchmarkTelemetryServer struct {
+	events int
+}
+
+func (b *benchmarkTelemetryServer) GetEvents(req *api.GetEventsRequest, stream api.TelemetryService_GetEventsServer) error {
+	data := make(chan interface{}, 1024)
+	go func() {
+		defer close(data)
+		for i := 0; i < b.events; i++ {
+			data <- &api.TelemetryEvent{
+				Id:                  "3f5ab1d1b0ba0e2cfe57e8e3e3fe0e7d3d6b71f4a9e6b28fbd2dc3d5bd2d5e9a",
+				SensorId:            "b7ff8a1c4c1e5f0a0f2b4a2ae6b5b9e5b1c1e0f8d1a5e6b9c1d8e2f3a4b5c6d7",
+				SensorMonotimeNanos: int64(i),
+				ProcessPid:          int32(i),
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{
+						Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+						Id:   2,
+						Ret:  int64(i),
+					},
+				},
+			}
+		}
+	}()
+
+	return sendEvents(req.Subscription, data, stream)
+}
+
+func (b *benchmarkTelemetryServer) Acknowledge(ctx context.Context, req *api.AcknowledgeRequest) (*api.AcknowledgeResponse, error) {
+	return &api.AcknowledgeResponse{}, nil
+}
+
+func (b *benchmarkTelemetryServer) GetSensorInfo(ctx context.Context, req *api.GetSensorInfoRequest) (*api.GetSensorInfoResponse, error) {
+	return &api.GetSensorInfoResponse{}, nil
+}
+
+func (b *benchmarkTelemetryServer) ValidateSubscription(ctx context.Context, req *api.ValidateSubscriptionRequest) (*api.ValidateSubscriptionResponse, error) {
+	return &api.ValidateSubscriptionResponse{Valid: true}, nil
+}
+
+func (b *benchmarkTelemetryServer) Subscribe(stream api.TelemetryService_SubscribeServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	return b.GetEvents(&api.GetEventsRequest{
+		Subscription: req.Subscription,
+	}, stream)
+}
+
+func benchmarkGetEvents(b *testing.B, batchSize uint32) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	api.RegisterTelemetryServiceServer(server, &benchmarkTelemetryServer{
+		events: b.N,
+	})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer conn.Close()
+
+	c := api.NewTelemetryServiceClient(conn)
+
+	b.ResetTimer()
+
+	stream, err := c.GetEvents(context.Background(), &api.GetEventsRequest{
+		Subscription: &api.Subscription{
+			MaxBatchSize:    batchSize,
+			MaxBatchLatency: int64(time.Millisecond),
+		},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	n := 0
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			b.Fatal(err)
+		}
+		n += len(response.Events)
+	}
+
+	if n != b.N {
+		b.Fatalf("Expected %d events, got %d", b.N, n)
+	}
+}
+
+func BenchmarkGetEventsBatch1(b *testing.B) {
+	benchmarkGetEvents(b, 1)
+}
+
+func BenchmarkGetEventsBatch16(b *testing.B) {
+	benchmarkGetEvents(b, 16)
+}
+
+func BenchmarkGetEventsBatch256(b *testing.B) {
+	benchmarkGetEvents(b, 256)
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"regexp"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+type tracepointFilter struct {
+	name   string
+	filter string
+	sensor *Sensor
+}
+
+// Tracepoint names are a subsystem and an event, each of which must begin
+// with [A-Za-z_] and contain only [A-Za-z0-9_]
+var validTracepointRegex = regexp.MustCompile("^[A-Za-z_]{1}[\\w]*/[A-Za-z_]{1}[\\w]*$")
+
+func newTracepointFilter(tef *api.TracepointEventFilter) (*tracepointFilter, error) {
+	if !validTracepointRegex.MatchString(tef.Name) {
+		return nil, fmt.Errorf("invalid tracepoint name %q", tef.Name)
+	}
+
+	var filterString string
+
+	if tef.FilterExpression != nil {
+		expr, err := expression.NewExpression(tef.FilterExpression)
+		if err != nil {
+			return nil, err
+		}
+		err = expr.ValidateKernelFilter()
+		if err != nil {
+			return nil, fmt.Errorf("invalid kernel filter: %s", err)
+		}
+
+		filterString = expr.KernelFilterString()
+	}
+
+	return &tracepointFilter{
+		name:   tef.Name,
+		filter: filterString,
+	}, nil
+}
+
+// expressionIdentifiers returns the identifiers used in an expression.
+func expressionIdentifiers(expr *api.Expression) []string {
+	switch expr.GetType() {
+	case api.Expression_IDENTIFIER:
+		return []string{expr.GetIdentifier()}
+	case api.Expression_IS_NULL, api.Expression_IS_NOT_NULL:
+		return expressionIdentifiers(expr.GetUnaryOp())
+	}
+	if operands := expr.GetBinaryOp(); operands != nil {
+		return append(expressionIdentifiers(operands.Lhs),
+			expressionIdentifiers(operands.Rhs)...)
+	}
+	return nil
+}
+
+// validateTracepointFilter checks that a tracepoint exists in the running
+// kernel and that its filter expression only uses fields in its format.
+// The kernel converts values to the types of the fields, so their types
+// aren't checked.
+func (s *Sensor) validateTracepointFilter(tef *api.TracepointEventFilter) error {
+	fields, err := perf.TraceEventFields(s.tracingDir(), tef.Name)
+	if err != nil {
+		glog.V(1).Infof("Couldn't read format of tracepoint %s: %s",
+			tef.Name, err)
+		return fmt.Errorf("unknown tracepoint %q", tef.Name)
+	}
+
+	for _, name := range expressionIdentifiers(tef.FilterExpression) {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("tracepoint %s has no field %q",
+				tef.Name, name)
+		}
+	}
+
+	return nil
+}
+
+func (f *tracepointFilter) decodeTracepoint(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+	ev.Event = &api.TelemetryEvent_Tracepoint{
+		Tracepoint: &api.TracepointEvent{
+			Name:   f.name,
+			Fields: sampleFieldValues(data),
+		},
+	}
+
+	return ev, nil
+}
+
+func tracepointEventValues(tev *api.TracepointEvent) (expression.FieldTypeMap, expression.FieldValueMap) {
+	return fieldValueMapValues(tev.Fields)
+}
+
+// registerTracepointEvents registers the tracepoints requested by a
+// subscription. Tracepoints are named by the subscriber, so failures to
+// register them are returned rather than recorded as probe failures, and
+// any tracepoints already registered for the subscription are unregistered.
+func registerTracepointEvents(sensor *Sensor, eventMap subscriptionMap, events []*api.TracepointEventFilter) error {
+	var eventIDs []uint64
+	for _, tef := range events {
+		f, err := newTracepointFilter(tef)
+		if err != nil {
+			glog.V(1).Infof("Invalid tracepoint filter: %s", err)
+			continue
+		}
+
+		f.sensor = sensor
+		eventID, err := sensor.registerTracepoint(f.name,
+			f.decodeTracepoint, perf.WithFilter(f.filter))
+		if err != nil {
+			glog.V(1).Infof("Couldn't register tracepoint %s: %v",
+				f.name, err)
+			for _, id := range eventIDs {
+				sensor.monitor.UnregisterEvent(id)
+				delete(eventMap, id)
+			}
+			return fmt.Errorf("couldn't register tracepoint %s: %s",
+				f.name, err)
+		}
+		eventIDs = append(eventIDs, eventID)
+		eventMap[eventID] = &subscription{}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bytes"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestNewTracepointFilter(t *testing.T) {
+	f, err := newTracepointFilter(&api.TracepointEventFilter{
+		Name: "sched/sched_switch",
+		FilterExpression: expression.Equal(
+			expression.Identifier("next_pid"),
+			expression.Value(int32(1))),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.name != "sched/sched_switch" || f.filter != "next_pid == 1" {
+		t.Errorf("Unexpected tracepoint filter %+v", f)
+	}
+
+	invalid := []*api.TracepointEventFilter{
+		&api.TracepointEventFilter{Name: "sched_switch"},
+		&api.TracepointEventFilter{Name: "sched/../../kprobe_events"},
+		&api.TracepointEventFilter{
+			Name: "sched/sched_switch",
+			FilterExpression: expression.IsNull(
+				expression.Identifier("next_comm")),
+		},
+	}
+	for _, tef := range invalid {
+		if _, err = newTracepointFilter(tef); err == nil {
+			t.Errorf("Expected error for %+v", tef)
+		}
+	}
+}
+
+func TestDecodeTracepoint(t *testing.T) {
+	f := &tracepointFilter{
+		name: "sched/sched_switch",
+		sensor: &Sensor{
+			processCache: ProcessInfoCache{
+				cache: newMapTaskCache(),
+			},
+		},
+	}
+
+	i, err := f.decodeTracepoint(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(100),
+			"prev_comm":  "bash",
+			"prev_state": int64(1),
+			"next_pid":   int32(1),
+			"mac":        sampleArray(0x02, 0x42, 0xac, 0x11, 0x00, 0x02),
+			"cpus":       []interface{}{uint64(1), uint64(2)},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tev := i.(*api.TelemetryEvent).GetTracepoint()
+	if tev == nil || tev.Name != "sched/sched_switch" {
+		t.Fatalf("Unexpected tracepoint event %+v", i)
+	}
+	if tev.Fields["prev_comm"].GetStringValue() != "bash" ||
+		tev.Fields["next_pid"].FieldType != api.KernelFunctionCallEvent_SINT32 ||
+		tev.Fields["next_pid"].GetSignedValue() != 1 {
+		t.Errorf("Unexpected tracepoint fields %+v", tev.Fields)
+	}
+	if v := tev.Fields["mac"].GetBytesValue(); !bytes.Equal(v,
+		[]byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02}) {
+		t.Errorf("Unexpected bytes field %v", v)
+	}
+	if _, ok := tev.Fields["cpus"]; ok {
+		t.Error("Unexpected field for array of u64")
+	}
+
+	hf := newHistoryFilter(&api.EventFilter{
+		TracepointEvents: []*api.TracepointEventFilter{
+			&api.TracepointEventFilter{
+				Name: "sched/sched_switch",
+				FilterExpression: expression.Equal(
+					expression.Identifier("prev_comm"),
+					expression.Value("bash")),
+			},
+		},
+	})
+	if !hf.filter(i.(*api.TelemetryEvent)) {
+		t.Error("Expected tracepoint event to match history filter")
+	}
+	tev.Name = "sched/sched_wakeup"
+	if hf.filter(i.(*api.TelemetryEvent)) {
+		t.Error("Unexpected match for other tracepoint")
+	}
+}
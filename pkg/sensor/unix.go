@@ -0,0 +1,628 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+const (
+	// Only binds of unix domain sockets are of interest
+	unixSocketBindFilter = "sa_family == 1"
+
+	// The maximum number of entries kept in each of the maps of a
+	// unixSocketCache before the map is reset.
+	unixSocketCacheSize = 65536
+
+	// How long to wait before searching procfs again for the process
+	// bound to a path that could not be found, and how long the
+	// namespaces read for a process are used before they are read again.
+	unixSocketLookupInterval = time.Minute
+
+	// The maximum number of lookups waiting for procfs to be read.
+	// Lookups are dropped while the queue is full.
+	unixSocketQueueLength = 64
+
+	// How often the file descriptors of every process may be searched
+	// for the socket bound to a path. Lookups needing a search sooner
+	// are dropped without being remembered as failures, so that they
+	// are retried by the next connection to the path.
+	unixSocketSearchInterval = time.Second
+
+	// The __SO_ACCEPTCON flag in /proc/net/unix marks listening sockets
+	unixSocketAcceptFlag = 0x10000
+)
+
+// unixSocketPath returns the path of a unix domain socket address fetched by
+// the bind and connect kprobes. Abstract names begin with a NUL byte, so
+// they are fetched separately and returned prefixed with "@", as they are
+// shown in /proc/net/unix.
+func unixSocketPath(data perf.TraceEventSampleData) string {
+	if path, _ := data["sun_path"].(string); len(path) > 0 {
+		return path
+	}
+	if name, _ := data["sun_path_abstract"].(string); len(name) > 0 {
+		return "@" + name
+	}
+	return ""
+}
+
+type unixSocketKey struct {
+	pid int32
+	fd  uint64
+}
+
+type unixSocketBind struct {
+	fd   uint64
+	path string
+}
+
+// unixSocketNamespaces identifies the mount and network namespaces of a
+// process by the targets of its /proc/PID/ns links, such as
+// "mnt:[4026531840]".
+type unixSocketNamespaces struct {
+	mnt    string
+	net    string
+	readAt time.Time
+}
+
+// of returns the namespace that a unix domain socket path is resolved in.
+// Filesystem paths are resolved in the mount namespace and abstract names
+// in the network namespace.
+func (ns unixSocketNamespaces) of(path string) string {
+	if strings.HasPrefix(path, "@") {
+		return ns.net
+	}
+	return ns.mnt
+}
+
+type unixSocketPathKey struct {
+	namespace string
+	path      string
+}
+
+// unixSocketLookup is the work queued for a unixSocketCache's procfs
+// reader: resolving the namespaces of a process that bound a path, or
+// finding the process bound to a path that a process connected to.
+type unixSocketLookup struct {
+	path  string
+	pid   int32
+	owner bool
+}
+
+// unixSocketCache tracks the processes bound to unix domain socket paths and
+// abstract names, so that connections to them can be attributed to the
+// process at the other end. Binds are tracked by thread until the result of
+// the system call is known, and listen(2) on a bound socket makes the
+// listening process the owner of its path. Paths are resolved in the
+// namespaces of the processes using them, so the owners of paths are kept
+// by namespace.
+//
+// Reading procfs is left to a background goroutine so that samples are not
+// held up: the namespaces of processes are read when they bind paths and
+// when they first connect to them, and sockets bound before the sensor
+// started are found by searching procfs. Until the namespaces of a process
+// connecting to a path are known, the owner is only reported if there is a
+// single one for the path.
+//
+// Binds and listens are only traced while subscriptions have registered
+// connect attempts, and what was learned from them is forgotten when the
+// last of those subscriptions is removed.
+type unixSocketCache struct {
+	sync.Mutex
+	sensor *Sensor
+
+	// Registration is serialized separately so that samples can be
+	// decoded while events are registered or unregistered.
+	registerLock sync.Mutex
+	refs         int
+	eventIDs     []uint64
+
+	binds      map[int32]unixSocketBind        // tid : bind in progress
+	bound      map[unixSocketKey]string        // socket : path
+	listeners  map[string]map[string]int32     // path : namespace : pid
+	misses     map[unixSocketPathKey]time.Time // namespace and path : time of failed search
+	namespaces map[int32]unixSocketNamespaces  // pid : namespaces
+	pending    map[unixSocketLookup]struct{}   // lookups in queue
+	queue      chan unixSocketLookup
+	closed     bool
+
+	// Only used by the goroutine reading procfs
+	searchedAt time.Time
+
+	// Used to read files, links, and directories in the host's procfs
+	readFile func(string) ([]byte, error)
+	readlink func(string) (string, error)
+	readDir  func(string) ([]string, error)
+}
+
+func newUnixSocketCache(sensor *Sensor) *unixSocketCache {
+	procFS := sys.HostProcFS()
+	cache := &unixSocketCache{
+		sensor:   sensor,
+		queue:    make(chan unixSocketLookup, unixSocketQueueLength),
+		readFile: procFS.ReadFile,
+		readlink: procFS.Readlink,
+		readDir: func(name string) ([]string, error) {
+			f, err := procFS.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return f.Readdirnames(-1)
+		},
+	}
+	go cache.run()
+
+	return cache
+}
+
+// register starts tracing binds and listens for a subscription, returning
+// false if they can't be traced. The events are only registered once a
+// subscription has registered connect attempts; sockets bound before then
+// are found by searching procfs.
+func (c *unixSocketCache) register() bool {
+	c.registerLock.Lock()
+	defer c.registerLock.Unlock()
+
+	if c.refs == 0 {
+		eventIDs, err := c.registerEvents()
+		if err != nil {
+			for _, eventID := range eventIDs {
+				c.sensor.monitor.UnregisterEvent(eventID)
+			}
+			return false
+		}
+		c.eventIDs = eventIDs
+	}
+	c.refs++
+	return true
+}
+
+func (c *unixSocketCache) registerEvents() ([]uint64, error) {
+	var eventIDs []uint64
+
+	eventID, err := c.sensor.registerKprobe(networkKprobeBindSymbol, false,
+		networkKprobeBindFetchargs, c.decodeSysBind,
+		perf.WithFilter(unixSocketBindFilter))
+	if err != nil {
+		glog.Warningf("Couldn't register kprobe %s: %s",
+			networkKprobeBindSymbol, err)
+		c.sensor.probeFailed(networkKprobeBindSymbol, err)
+		return eventIDs, err
+	}
+	eventIDs = append(eventIDs, eventID)
+
+	tracepoints := map[string]perf.TraceEventDecoderFn{
+		"syscalls/sys_exit_bind":    c.decodeSysExitBind,
+		"syscalls/sys_enter_listen": c.decodeSysEnterListen,
+	}
+	for name, fn := range tracepoints {
+		eventID, err = c.sensor.registerTracepoint(name, fn)
+		if err != nil {
+			glog.Warningf("Couldn't register event %s: %s", name, err)
+			c.sensor.probeFailed(name, err)
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	return eventIDs, nil
+}
+
+// unregister stops tracing binds and listens for a subscription that was
+// registered. Once no subscriptions remain, the events are unregistered and
+// the owners that were learned are forgotten.
+func (c *unixSocketCache) unregister() {
+	c.registerLock.Lock()
+	defer c.registerLock.Unlock()
+
+	c.refs--
+	if c.refs > 0 {
+		return
+	}
+	for _, eventID := range c.eventIDs {
+		c.sensor.monitor.UnregisterEvent(eventID)
+	}
+	c.eventIDs = nil
+
+	c.Lock()
+	c.binds = nil
+	c.bound = nil
+	c.listeners = nil
+	c.misses = nil
+	c.namespaces = nil
+	c.Unlock()
+}
+
+func (c *unixSocketCache) close() {
+	c.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.Unlock()
+}
+
+// run reads procfs for the queued lookups until the cache is closed.
+func (c *unixSocketCache) run() {
+	for l := range c.queue {
+		c.lookup(l)
+	}
+}
+
+// enqueue queues a lookup unless it is already queued or the queue is
+// full. It must be called with the cache locked.
+func (c *unixSocketCache) enqueue(l unixSocketLookup) {
+	if c.closed {
+		return
+	}
+	if _, ok := c.pending[l]; ok {
+		return
+	}
+	select {
+	case c.queue <- l:
+		if c.pending == nil {
+			c.pending = make(map[unixSocketLookup]struct{})
+		}
+		c.pending[l] = struct{}{}
+	default:
+	}
+}
+
+// leader returns the pid of the process that a thread belongs to.
+func (c *unixSocketCache) leader(tid int32) int32 {
+	if c.sensor != nil {
+		t, ok := c.sensor.processCache.lookupLeader(int(tid))
+		if ok && t.pid != 0 {
+			return int32(t.pid)
+		}
+	}
+	return tid
+}
+
+func (c *unixSocketCache) decodeSysBind(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	path := unixSocketPath(data)
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	c.Lock()
+	if c.binds == nil || len(c.binds) >= unixSocketCacheSize {
+		c.binds = make(map[int32]unixSocketBind)
+	}
+	c.binds[data["common_pid"].(int32)] = unixSocketBind{
+		fd:   data["fd"].(uint64),
+		path: path,
+	}
+	c.Unlock()
+
+	return nil, nil
+}
+
+func (c *unixSocketCache) decodeSysExitBind(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	tid := data["common_pid"].(int32)
+
+	c.Lock()
+	defer c.Unlock()
+
+	b, ok := c.binds[tid]
+	if !ok {
+		return nil, nil
+	}
+	delete(c.binds, tid)
+	if data["ret"].(int64) != 0 {
+		return nil, nil
+	}
+
+	pid := c.leader(tid)
+	if c.bound == nil || len(c.bound) >= unixSocketCacheSize {
+		c.bound = make(map[unixSocketKey]string)
+	}
+	c.bound[unixSocketKey{pid: pid, fd: b.fd}] = b.path
+	c.setOwner(b.path, pid)
+
+	return nil, nil
+}
+
+func (c *unixSocketCache) decodeSysEnterListen(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	key := unixSocketKey{
+		pid: c.leader(data["common_pid"].(int32)),
+		fd:  data["fd"].(uint64),
+	}
+
+	c.Lock()
+	if path, ok := c.bound[key]; ok {
+		c.setOwner(path, key.pid)
+	}
+	c.Unlock()
+
+	return nil, nil
+}
+
+// setOwner records the process bound to a path. If the namespaces of the
+// process are not known, it is recorded without a namespace until they are
+// read. It must be called with the cache locked.
+func (c *unixSocketCache) setOwner(path string, pid int32) {
+	ns, ok := c.namespaces[pid]
+	if !ok {
+		c.addListener(path, "", pid)
+		c.enqueue(unixSocketLookup{
+			path:  path,
+			pid:   pid,
+			owner: true,
+		})
+		return
+	}
+	c.addListener(path, ns.of(path), pid)
+}
+
+// addListener must be called with the cache locked.
+func (c *unixSocketCache) addListener(path, namespace string, pid int32) {
+	if c.listeners == nil || len(c.listeners) >= unixSocketCacheSize {
+		c.listeners = make(map[string]map[string]int32)
+	}
+	if c.listeners[path] == nil {
+		c.listeners[path] = make(map[string]int32)
+	}
+	c.listeners[path][namespace] = pid
+	if len(namespace) > 0 {
+		delete(c.misses, unixSocketPathKey{
+			namespace: namespace,
+			path:      path,
+		})
+	}
+}
+
+// owner returns the pid of the process bound to a unix domain socket path,
+// as seen by the process connecting to it. Anything that isn't known is
+// queued to be looked up in procfs; paths whose owners could not be found
+// there are not looked up again for unixSocketLookupInterval.
+func (c *unixSocketCache) owner(path string, pid int32) (int32, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	listeners := c.listeners[path]
+	ns, ok := c.namespaces[pid]
+	if !ok || time.Since(ns.readAt) >= unixSocketLookupInterval {
+		c.enqueue(unixSocketLookup{
+			path: path,
+			pid:  pid,
+		})
+	}
+	if !ok {
+		if len(listeners) == 1 {
+			for _, owner := range listeners {
+				return owner, true
+			}
+		}
+		return 0, false
+	}
+
+	if owner, ok := listeners[ns.of(path)]; ok {
+		return owner, true
+	}
+	if owner, ok := listeners[""]; ok {
+		return owner, true
+	}
+
+	key := unixSocketPathKey{
+		namespace: ns.of(path),
+		path:      path,
+	}
+	if t, ok := c.misses[key]; !ok || time.Since(t) >= unixSocketLookupInterval {
+		c.enqueue(unixSocketLookup{
+			path: path,
+			pid:  pid,
+		})
+	}
+	return 0, false
+}
+
+// readNamespaces returns the namespaces of a process, reading them from
+// procfs if they are not known or are stale.
+func (c *unixSocketCache) readNamespaces(pid int32) (unixSocketNamespaces, bool) {
+	c.Lock()
+	ns, ok := c.namespaces[pid]
+	c.Unlock()
+	if ok && time.Since(ns.readAt) < unixSocketLookupInterval {
+		return ns, true
+	}
+
+	mnt, err := c.readlink(fmt.Sprintf("%d/ns/mnt", pid))
+	if err != nil {
+		return unixSocketNamespaces{}, false
+	}
+	net, err := c.readlink(fmt.Sprintf("%d/ns/net", pid))
+	if err != nil {
+		return unixSocketNamespaces{}, false
+	}
+	ns = unixSocketNamespaces{
+		mnt:    mnt,
+		net:    net,
+		readAt: time.Now(),
+	}
+
+	c.Lock()
+	if c.namespaces == nil || len(c.namespaces) >= unixSocketCacheSize {
+		c.namespaces = make(map[int32]unixSocketNamespaces)
+	}
+	c.namespaces[pid] = ns
+	c.Unlock()
+
+	return ns, true
+}
+
+// lookup reads procfs for a queued lookup.
+func (c *unixSocketCache) lookup(l unixSocketLookup) {
+	defer func() {
+		c.Lock()
+		delete(c.pending, l)
+		c.Unlock()
+	}()
+
+	ns, ok := c.readNamespaces(l.pid)
+	if l.owner {
+		c.Lock()
+		if c.listeners[l.path][""] == l.pid {
+			delete(c.listeners[l.path], "")
+			if ok {
+				c.addListener(l.path, ns.of(l.path), l.pid)
+			}
+		}
+		c.Unlock()
+		return
+	}
+	if !ok {
+		return
+	}
+
+	key := unixSocketPathKey{
+		namespace: ns.of(l.path),
+		path:      l.path,
+	}
+	c.Lock()
+	_, found := c.listeners[l.path][key.namespace]
+	t, missed := c.misses[key]
+	c.Unlock()
+	if found || (missed && time.Since(t) < unixSocketLookupInterval) {
+		return
+	}
+
+	owner, ok, searched := c.findOwner(l.path, l.pid)
+	if !searched {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if ok {
+		c.addListener(l.path, key.namespace, owner)
+	} else {
+		if c.misses == nil || len(c.misses) >= unixSocketCacheSize {
+			c.misses = make(map[unixSocketPathKey]time.Time)
+		}
+		c.misses[key] = time.Now()
+	}
+}
+
+// findOwner searches procfs for the process bound to a unix domain socket
+// path in the network namespace of the given process. Finding the process
+// means reading the file descriptors of every process, so it is done at
+// most once every unixSocketSearchInterval; the last result is false if the
+// search was skipped for that reason.
+func (c *unixSocketCache) findOwner(path string, pid int32) (int32, bool, bool) {
+	data, err := c.readFile(fmt.Sprintf("%d/net/unix", pid))
+	if err != nil {
+		return 0, false, true
+	}
+	inode, ok := parseProcNetUnix(data, path)
+	if !ok {
+		return 0, false, true
+	}
+	link := fmt.Sprintf("socket:[%d]", inode)
+
+	if time.Since(c.searchedAt) < unixSocketSearchInterval {
+		return 0, false, false
+	}
+	c.searchedAt = time.Now()
+
+	names, err := c.readDir("")
+	if err != nil {
+		return 0, false, true
+	}
+	for _, name := range names {
+		p, err := strconv.ParseInt(name, 10, 32)
+		if err != nil {
+			continue
+		}
+		fds, err := c.readDir(name + "/fd")
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			l, err := c.readlink(name + "/fd/" + fd)
+			if err == nil && l == link {
+				return int32(p), true, true
+			}
+		}
+	}
+
+	return 0, false, true
+}
+
+// parseProcNetUnix returns the inode of the socket bound to a path in the
+// contents of /proc/net/unix, preferring listening sockets to sockets
+// accepted from them.
+func parseProcNetUnix(data []byte, path string) (uint64, bool) {
+	var (
+		inode uint64
+		found bool
+	)
+	for _, line := range strings.Split(string(data), "\n")[1:] {
+		// Num RefCount Protocol Flags Type St Inode Path
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[7] != path {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			continue
+		}
+		i, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			continue
+		}
+		if flags&unixSocketAcceptFlag != 0 {
+			return i, true
+		}
+		if !found {
+			inode, found = i, true
+		}
+	}
+	return inode, found
+}
+
+// setUnixSocketPeer adds the process bound to the address of a unix domain
+// socket connect attempt to its network event.
+func (f *networkFilter) setUnixSocketPeer(network *api.NetworkEvent, data perf.TraceEventSampleData) {
+	if f.sensor.unixSockets == nil ||
+		network.Address.GetFamily() != api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_LOCAL {
+		return
+	}
+	path := unixSocketPath(data)
+	if len(path) == 0 {
+		return
+	}
+
+	pid, ok := f.sensor.unixSockets.owner(path, data["common_pid"].(int32))
+	if !ok {
+		return
+	}
+	network.PeerPid = pid
+	network.PeerProcessId, _ = f.sensor.processCache.ProcessID(int(pid))
+	network.PeerContainerId, _ = f.sensor.processCache.ProcessContainerID(int(pid))
+}
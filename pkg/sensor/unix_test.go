@@ -0,0 +1,277 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+const procNetUnix = `Num       RefCount Protocol Flags    Type St Inode Path
+0000000000000000: 00000003 00000000 00000000 0001 03 21517 /var/run/docker.sock
+0000000000000000: 00000002 00000000 00010000 0001 01 18911 /var/run/docker.sock
+0000000000000000: 00000002 00000000 00010000 0001 01 16002 @/tmp/.X11-unix/X0
+0000000000000000: 00000002 00000000 00010000 0001 01 16003 /run/user/1000/bus
+0000000000000000: 00000003 00000000 00000000 0001 03 22814
+`
+
+func newTestUnixSocketCache() *unixSocketCache {
+	return &unixSocketCache{
+		queue: make(chan unixSocketLookup, unixSocketQueueLength),
+		readFile: func(string) ([]byte, error) {
+			return nil, errors.New("not found")
+		},
+		readlink: testNamespaceLink,
+		readDir: func(string) ([]string, error) {
+			return nil, errors.New("not found")
+		},
+	}
+}
+
+// testNamespaceLink puts pids from 500 in their own mount and network
+// namespaces.
+func testNamespaceLink(name string) (string, error) {
+	var (
+		pid int32
+		ns  string
+	)
+	if _, err := fmt.Sscanf(name, "%d/ns/%s", &pid, &ns); err != nil {
+		return "", errors.New("not found")
+	}
+	if pid >= 500 {
+		return fmt.Sprintf("%s:[2]", ns), nil
+	}
+	return fmt.Sprintf("%s:[1]", ns), nil
+}
+
+// drainUnixSocketLookups does the queued lookups of a cache that isn't
+// running.
+func drainUnixSocketLookups(c *unixSocketCache) {
+	for len(c.queue) > 0 {
+		c.lookup(<-c.queue)
+	}
+}
+
+func unixBindSample(tid int32, fd uint64, path, abstract string) perf.TraceEventSampleData {
+	return perf.TraceEventSampleData{
+		"common_pid":        tid,
+		"fd":                fd,
+		"sa_family":         uint16(1),
+		"sun_path":          path,
+		"sun_path_abstract": abstract,
+	}
+}
+
+func TestParseProcNetUnix(t *testing.T) {
+	if inode, ok := parseProcNetUnix([]byte(procNetUnix), "/var/run/docker.sock"); !ok || inode != 18911 {
+		t.Errorf("Expected listening socket 18911, got %d", inode)
+	}
+	if inode, ok := parseProcNetUnix([]byte(procNetUnix), "@/tmp/.X11-unix/X0"); !ok || inode != 16002 {
+		t.Errorf("Expected abstract socket 16002, got %d", inode)
+	}
+	if _, ok := parseProcNetUnix([]byte(procNetUnix), "/run/foo.sock"); ok {
+		t.Error("Expected not to find /run/foo.sock")
+	}
+}
+
+func TestUnixSocketCache(t *testing.T) {
+	c := newTestUnixSocketCache()
+	bind := func(tid int32, fd uint64, path, abstract string, ret int64) {
+		c.decodeSysBind(&perf.SampleRecord{},
+			unixBindSample(tid, fd, path, abstract))
+		c.decodeSysExitBind(&perf.SampleRecord{},
+			perf.TraceEventSampleData{
+				"common_pid": tid,
+				"ret":        ret,
+			})
+	}
+
+	// The only owner of a path is reported before any namespaces are
+	// read
+	bind(100, 3, "/var/run/docker.sock", "", 0)
+	if pid, ok := c.owner("/var/run/docker.sock", 200); !ok || pid != 100 {
+		t.Errorf("Expected owner 100, got %d", pid)
+	}
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("/var/run/docker.sock", 200); !ok || pid != 100 {
+		t.Errorf("Expected owner 100, got %d", pid)
+	}
+	if _, ok := c.listeners["/var/run/docker.sock"]["mnt:[1]"]; !ok {
+		t.Error("Expected owner 100 to be kept by mount namespace")
+	}
+
+	// A failed bind doesn't replace the owner
+	bind(101, 3, "/var/run/docker.sock", "", -98)
+	if pid, _ := c.owner("/var/run/docker.sock", 200); pid != 100 {
+		t.Errorf("Expected owner 100 after failed bind, got %d", pid)
+	}
+
+	// The same path bound in another mount namespace has its own owner
+	bind(500, 3, "/var/run/docker.sock", "", 0)
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("/var/run/docker.sock", 501); ok {
+		t.Errorf("Expected no owner before namespaces are read, got %d", pid)
+	}
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("/var/run/docker.sock", 501); !ok || pid != 500 {
+		t.Errorf("Expected owner 500, got %d", pid)
+	}
+	if pid, ok := c.owner("/var/run/docker.sock", 200); !ok || pid != 100 {
+		t.Errorf("Expected owner 100, got %d", pid)
+	}
+
+	// Abstract names are prefixed with "@"
+	bind(102, 4, "", "dbus-1234", 0)
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("@dbus-1234", 200); !ok || pid != 102 {
+		t.Errorf("Expected owner 102 for abstract name, got %d", pid)
+	}
+	if _, ok := c.listeners["@dbus-1234"]["net:[1]"]; !ok {
+		t.Error("Expected owner 102 to be kept by network namespace")
+	}
+
+	// The process that listens on a bound socket becomes its owner
+	c.bound[unixSocketKey{pid: 103, fd: 5}] = "/run/app.sock"
+	c.decodeSysEnterListen(&perf.SampleRecord{},
+		perf.TraceEventSampleData{
+			"common_pid": int32(103),
+			"fd":         uint64(5),
+		})
+	if pid, ok := c.owner("/run/app.sock", 200); !ok || pid != 103 {
+		t.Errorf("Expected owner 103 after listen, got %d", pid)
+	}
+	drainUnixSocketLookups(c)
+
+	// Sockets bound before tracking started are found in procfs, in the
+	// background
+	c.readFile = func(name string) ([]byte, error) {
+		if name == "200/net/unix" {
+			return []byte(procNetUnix), nil
+		}
+		return nil, errors.New("not found")
+	}
+	c.readDir = func(name string) ([]string, error) {
+		switch name {
+		case "":
+			return []string{"self", "1", "300"}, nil
+		case "300/fd":
+			return []string{"0", "1", "7"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+	c.readlink = func(name string) (string, error) {
+		if name == "300/fd/7" {
+			return "socket:[16002]", nil
+		}
+		if link, err := testNamespaceLink(name); err == nil {
+			return link, nil
+		}
+		return "/dev/null", nil
+	}
+	if pid, ok := c.owner("@/tmp/.X11-unix/X0", 200); ok {
+		t.Errorf("Expected no owner before searching procfs, got %d", pid)
+	}
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("@/tmp/.X11-unix/X0", 200); !ok || pid != 300 {
+		t.Errorf("Expected owner 300 from procfs, got %d", pid)
+	}
+
+	// Searches of every process are rate limited, and skipped searches
+	// are not remembered as failures
+	c.owner("/run/user/1000/bus", 200)
+	drainUnixSocketLookups(c)
+	bus := unixSocketPathKey{
+		namespace: "mnt:[1]",
+		path:      "/run/user/1000/bus",
+	}
+	if _, ok := c.misses[bus]; ok {
+		t.Error("Expected skipped search not to be remembered")
+	}
+	c.searchedAt = time.Time{}
+	c.owner("/run/user/1000/bus", 200)
+	drainUnixSocketLookups(c)
+	if _, ok := c.misses[bus]; !ok {
+		t.Error("Expected failed search to be remembered")
+	}
+
+	// Failed searches are not repeated right away
+	if _, ok := c.owner("/run/missing.sock", 200); ok {
+		t.Error("Expected no owner for /run/missing.sock")
+	}
+	drainUnixSocketLookups(c)
+	key := unixSocketPathKey{
+		namespace: "mnt:[1]",
+		path:      "/run/missing.sock",
+	}
+	if _, ok := c.misses[key]; !ok {
+		t.Error("Expected failed search to be remembered")
+	}
+	c.owner("/run/missing.sock", 200)
+	if n := len(c.queue); n != 0 {
+		t.Errorf("Expected no lookups to be queued, got %d", n)
+	}
+	bind(104, 3, "/run/missing.sock", "", 0)
+	drainUnixSocketLookups(c)
+	if pid, ok := c.owner("/run/missing.sock", 200); !ok || pid != 104 {
+		t.Errorf("Expected owner 104 after bind, got %d", pid)
+	}
+	if _, ok := c.misses[key]; ok {
+		t.Error("Expected failed search to be forgotten after bind")
+	}
+}
+
+func TestUnixSocketPeer(t *testing.T) {
+	s := &Sensor{
+		processCache: ProcessInfoCache{
+			cache: newMapTaskCache(),
+		},
+		unixSockets: newTestUnixSocketCache(),
+	}
+	s.processCache.cache.InsertTask(100, task{
+		pid:         100,
+		ppid:        1,
+		tgid:        100,
+		containerID: "c0ffee",
+	})
+	s.unixSockets.setOwner("/var/run/docker.sock", 100)
+
+	f := networkFilter{
+		sensor: s,
+	}
+	data := sockaddrSample(1, 0, 0, 0, 0)
+	data["sun_path"] = "/var/run/docker.sock"
+	i, err := f.decodeSysConnect(&perf.SampleRecord{}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nev := i.(*api.TelemetryEvent).GetNetwork()
+	if nev.PeerPid != 100 || nev.PeerContainerId != "c0ffee" ||
+		len(nev.PeerProcessId) == 0 {
+		t.Errorf("Unexpected peer for connect event %+v", nev)
+	}
+
+	// Connect events for other families have no peer
+	i, _ = f.decodeSysConnect(&perf.SampleRecord{},
+		sockaddrSample(afInet, 0x5000, 0x0100007f, 0, 0))
+	if nev = i.(*api.TelemetryEvent).GetNetwork(); nev.PeerPid != 0 {
+		t.Errorf("Unexpected peer for AF_INET connect event %+v", nev)
+	}
+}
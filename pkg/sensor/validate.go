@@ -0,0 +1,249 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func newFilterValidation(path string, kernelFilter string, probes []string, err error) *api.FilterValidation {
+	fv := &api.FilterValidation{
+		Path:         path,
+		Valid:        err == nil,
+		KernelFilter: kernelFilter,
+		Probes:       probes,
+	}
+	if err != nil {
+		fv.KernelFilter = ""
+		fv.Error = err.Error()
+	}
+
+	return fv
+}
+
+func validateContainerEventFilter(cef *api.ContainerEventFilter) error {
+	if _, ok := api.ContainerEventType_name[int32(cef.Type)]; !ok ||
+		cef.Type == api.ContainerEventType_CONTAINER_EVENT_TYPE_UNKNOWN {
+		return fmt.Errorf("unsupported container event type %s",
+			cef.Type)
+	}
+
+	if cef.FilterExpression != nil {
+		expr, err := expression.NewExpression(cef.FilterExpression)
+		if err != nil {
+			return err
+		}
+		err = expr.Validate(containerEventTypes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSubscription checks each of the filters in a subscription without
+// creating it, and explains how each of them is compiled. Filters that are
+// invalid would otherwise be ignored by NewSubscription.
+func (s *Sensor) validateSubscription(sub *api.Subscription) *api.ValidateSubscriptionResponse {
+	var filters []*api.FilterValidation
+
+	if sub == nil || sub.EventFilter == nil {
+		filters = append(filters, newFilterValidation("event_filter",
+			"", nil, errors.New("event_filter is required")))
+		return &api.ValidateSubscriptionResponse{
+			Filters: filters,
+		}
+	}
+
+	// Deprecated fields are rewritten as filters are compiled, so work on
+	// a copy of the subscription.
+	ef := proto.Clone(sub.EventFilter).(*api.EventFilter)
+
+	for i, sef := range ef.SyscallEvents {
+		filter, err := syscallEventFilterString(sef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.syscall_events[%d]", i),
+			filter, syscallEventProbes(sef.Type), err))
+	}
+
+	for i, pef := range ef.ProcessEvents {
+		filter, err := processEventFilterString(pef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.process_events[%d]", i),
+			filter, processEventProbes(pef.Type), err))
+	}
+
+	for i, fef := range ef.FileEvents {
+		filter, err := fileEventFilterString(fef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.file_events[%d]", i),
+			filter, fileEventProbes(fef.Type), err))
+	}
+
+	for i, kef := range ef.KernelEvents {
+		var (
+			filter string
+			probes []string
+		)
+		f, err := newKprobeFilter(kef)
+		if err == nil {
+			filter = f.filter
+			probes = []string{f.symbol}
+		}
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.kernel_events[%d]", i),
+			filter, probes, err))
+	}
+
+	for i, tef := range ef.TracepointEvents {
+		var (
+			filter string
+			probes []string
+		)
+		f, err := newTracepointFilter(tef)
+		if err == nil {
+			err = s.validateTracepointFilter(tef)
+		}
+		if err == nil {
+			filter = f.filter
+			probes = []string{f.name}
+		}
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.tracepoint_events[%d]", i),
+			filter, probes, err))
+	}
+
+	for i, nef := range ef.NetworkEvents {
+		filter, err := networkEventFilterString(nef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.network_events[%d]", i),
+			filter, networkEventProbes(nef.Type), err))
+	}
+
+	for i, cef := range ef.CredentialsEvents {
+		filter, err := credentialsEventFilterString(cef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.credentials_events[%d]", i),
+			filter, credentialsEventProbes(), err))
+	}
+
+	for i, sef := range ef.SignalEvents {
+		filter, err := signalEventFilterString(sef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.signal_events[%d]", i),
+			filter, signalEventProbes(sef.Type), err))
+	}
+
+	for i, nef := range ef.NamespaceEvents {
+		filter, err := namespaceEventFilterString(nef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.namespace_events[%d]", i),
+			filter, namespaceEventProbes(nef.Type), err))
+	}
+
+	for i, kef := range ef.KernelModuleEvents {
+		filter, err := kernelModuleEventFilterString(kef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.kernel_module_events[%d]", i),
+			filter, kernelModuleEventProbes(kef.Type), err))
+	}
+
+	for i, pef := range ef.ProcessAccessEvents {
+		filter, err := processAccessEventFilterString(pef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.process_access_events[%d]", i),
+			filter, processAccessEventProbes(pef.Type), err))
+	}
+
+	for i, mef := range ef.MemoryEvents {
+		filter, err := memoryEventFilterString(mef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.memory_events[%d]", i),
+			filter, memoryEventProbes(mef.Type), err))
+	}
+
+	for i, ff := range ef.FlowEvents {
+		err := validateFlowEventFilter(ff)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.flow_events[%d]", i),
+			"", flowEventProbes(), err))
+	}
+
+	for i, cef := range ef.ContainerEvents {
+		err := validateContainerEventFilter(cef)
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.container_events[%d]", i),
+			"", nil, err))
+	}
+
+	for i, cf := range ef.ChargenEvents {
+		var err error
+		if cf.Length == 0 {
+			err = errors.New("chargen length must be greater than 0")
+		}
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.chargen_events[%d]", i),
+			"", nil, err))
+	}
+
+	for i, tf := range ef.TickerEvents {
+		var err error
+		if tf.Interval <= 0 {
+			err = errors.New("ticker interval must be greater than 0")
+		}
+		filters = append(filters, newFilterValidation(
+			fmt.Sprintf("event_filter.ticker_events[%d]", i),
+			"", nil, err))
+	}
+
+	valid := true
+	for _, fv := range filters {
+		if !fv.Valid {
+			valid = false
+			break
+		}
+	}
+
+	return &api.ValidateSubscriptionResponse{
+		Valid:   valid,
+		Filters: filters,
+	}
+}
+
+// validationError returns an error describing the invalid filters in a
+// subscription validation, or nil if the subscription is valid.
+func validationError(vr *api.ValidateSubscriptionResponse) error {
+	if vr.Valid {
+		return nil
+	}
+
+	var errs []string
+	for _, fv := range vr.Filters {
+		if !fv.Valid {
+			errs = append(errs, fmt.Sprintf("%s: %s", fv.Path, fv.Error))
+		}
+	}
+
+	return fmt.Errorf("invalid subscription: %s", strings.Join(errs, "; "))
+}
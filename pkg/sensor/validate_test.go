@@ -0,0 +1,201 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+const schedProcessExecFormat = `name: sched_process_exec
+ID: 300
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:__data_loc char[] filename;	offset:8;	size:4;	signed:1;
+	field:pid_t pid;	offset:12;	size:4;	signed:1;
+	field:pid_t old_pid;	offset:16;	size:4;	signed:1;
+
+print fmt: "filename=%s pid=%d old_pid=%d", __get_str(filename), REC->pid, REC->old_pid
+`
+
+// newTestTracingDir returns a tracing directory containing the format of
+// sched/sched_process_exec.
+func newTestTracingDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "validate_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventDir := filepath.Join(dir, "events", "sched", "sched_process_exec")
+	if err = os.MkdirAll(eventDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(eventDir, "format"),
+		[]byte(schedProcessExecFormat), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestValidateSubscription(t *testing.T) {
+	dir := newTestTracingDir(t)
+	defer os.RemoveAll(dir)
+	s := &Sensor{
+		traceFSMountPoint: dir,
+	}
+
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			SyscallEvents: []*api.SyscallEventFilter{
+				&api.SyscallEventFilter{
+					Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+					Id:   &wrappers.Int64Value{Value: 59},
+				},
+				// No wildcard syscall filters
+				&api.SyscallEventFilter{
+					Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+				},
+			},
+			FileEvents: []*api.FileEventFilter{
+				&api.FileEventFilter{
+					Type: api.FileEventType_FILE_EVENT_TYPE_OPEN,
+				},
+			},
+			KernelEvents: []*api.KernelFunctionCallFilter{
+				&api.KernelFunctionCallFilter{
+					Type:   api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER,
+					Symbol: "0xffffffff81000000",
+				},
+			},
+			TracepointEvents: []*api.TracepointEventFilter{
+				&api.TracepointEventFilter{
+					Name: "sched/sched_process_exec",
+					FilterExpression: expression.Equal(
+						expression.Identifier("filename"),
+						expression.Value("/bin/sh")),
+				},
+				// Tracepoints must exist
+				&api.TracepointEventFilter{
+					Name: "sched/sched_process_fork",
+				},
+				// Fields must be in the tracepoint's format
+				&api.TracepointEventFilter{
+					Name: "sched/sched_process_exec",
+					FilterExpression: expression.Equal(
+						expression.Identifier("comm"),
+						expression.Value("sh")),
+				},
+			},
+			NetworkEvents: []*api.NetworkEventFilter{
+				&api.NetworkEventFilter{
+					Type: api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_RESULT,
+					FilterExpression: expression.Equal(
+						expression.Identifier("ret"),
+						expression.Value(int64(0))),
+				},
+				// IS NULL is not supported by kernel filters
+				&api.NetworkEventFilter{
+					Type: api.NetworkEventType_NETWORK_EVENT_TYPE_BIND_ATTEMPT,
+					FilterExpression: expression.IsNull(
+						expression.Identifier("sun_path")),
+				},
+			},
+		},
+	}
+
+	vr := s.validateSubscription(sub)
+	if vr.Valid {
+		t.Error("Expected subscription to be invalid")
+	}
+
+	expected := []struct {
+		path         string
+		valid        bool
+		kernelFilter string
+		probe        string
+	}{
+		{"event_filter.syscall_events[0]", true, "id == 59", syscallNewEnterKprobeAddress},
+		{"event_filter.syscall_events[1]", false, "", "raw_syscalls/sys_exit"},
+		{"event_filter.file_events[0]", true, "", "fs/do_sys_open"},
+		{"event_filter.kernel_events[0]", false, "", ""},
+		{"event_filter.tracepoint_events[0]", true, `filename == "/bin/sh"`, "sched/sched_process_exec"},
+		{"event_filter.tracepoint_events[1]", false, "", ""},
+		{"event_filter.tracepoint_events[2]", false, "", ""},
+		{"event_filter.network_events[0]", true, "ret == 0", "syscalls/sys_exit_connect"},
+		{"event_filter.network_events[1]", false, "", networkKprobeBindSymbol},
+	}
+	if len(vr.Filters) != len(expected) {
+		t.Fatalf("Expected %d filters, got %d", len(expected), len(vr.Filters))
+	}
+
+	for i, e := range expected {
+		fv := vr.Filters[i]
+		if fv.Path != e.path {
+			t.Errorf("Expected path %s, got %s", e.path, fv.Path)
+		}
+		if fv.Valid != e.valid {
+			t.Errorf("%s: expected valid %v, got %v (%s)",
+				e.path, e.valid, fv.Valid, fv.Error)
+		}
+		if !fv.Valid && len(fv.Error) == 0 {
+			t.Errorf("%s: expected an error", e.path)
+		}
+		if fv.KernelFilter != e.kernelFilter {
+			t.Errorf("%s: expected kernel filter %q, got %q",
+				e.path, e.kernelFilter, fv.KernelFilter)
+		}
+		if len(e.probe) > 0 &&
+			(len(fv.Probes) == 0 || fv.Probes[0] != e.probe) {
+			t.Errorf("%s: expected probe %s, got %v",
+				e.path, e.probe, fv.Probes)
+		}
+	}
+
+	// The subscription itself must not be rewritten
+	if sub.EventFilter.SyscallEvents[0].Id == nil {
+		t.Error("Subscription was modified by validation")
+	}
+
+	err := validationError(vr)
+	if err == nil {
+		t.Error("Expected a validation error")
+	}
+
+	sub.EventFilter.SyscallEvents = sub.EventFilter.SyscallEvents[:1]
+	sub.EventFilter.KernelEvents = nil
+	sub.EventFilter.TracepointEvents = sub.EventFilter.TracepointEvents[:1]
+	sub.EventFilter.NetworkEvents = sub.EventFilter.NetworkEvents[:1]
+	vr = s.validateSubscription(sub)
+	if !vr.Valid || validationError(vr) != nil {
+		t.Errorf("Expected subscription to be valid, got %+v", vr)
+	}
+
+	vr = s.validateSubscription(&api.Subscription{})
+	if vr.Valid {
+		t.Error("Expected subscription without event_filter to be invalid")
+	}
+}
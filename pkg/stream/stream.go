@@ -317,6 +317,96 @@ func Filter(in *Stream, f FilterFunc) *Stream {
 	}
 }
 
+// Prepend adds an operator in the stream that emits the given elements before
+// any elements received from the input stream. Elements from the input stream
+// are not consumed until all of the prepended elements have been emitted.
+func Prepend(in *Stream, elements []interface{}) *Stream {
+	data := make(chan interface{}, config.Sensor.ChannelBufferLength)
+
+	go func() {
+		defer close(data)
+
+		for _, e := range elements {
+			data <- e
+		}
+
+		for {
+			select {
+			case e, ok := <-in.Data:
+				if ok {
+					data <- e
+				} else {
+					return
+				}
+			}
+		}
+	}()
+
+	return &Stream{
+		Ctrl: in.Ctrl,
+		Data: data,
+	}
+}
+
+// Expire adds an operator in the stream that closes the stream once the
+// given duration has elapsed. The stream may still be closed earlier by its
+// consumer.
+func Expire(in *Stream, d time.Duration) *Stream {
+	ctrl := make(chan interface{})
+
+	go func() {
+		defer close(in.Ctrl)
+
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		for {
+			select {
+			case m, ok := <-ctrl:
+				if !ok {
+					return
+				}
+				in.Ctrl <- m
+
+			case <-timer.C:
+				return
+			}
+		}
+	}()
+
+	return &Stream{
+		Ctrl: ctrl,
+		Data: in.Data,
+	}
+}
+
+// Finally adds an operator in the stream that calls the given function once
+// the input stream has ended.
+func Finally(in *Stream, f func()) *Stream {
+	data := make(chan interface{}, config.Sensor.ChannelBufferLength)
+
+	go func() {
+		defer close(data)
+		defer f()
+
+		for {
+			select {
+			case e, ok := <-in.Data:
+				if ok {
+					data <- e
+				} else {
+					return
+				}
+			}
+		}
+	}()
+
+	return &Stream{
+		Ctrl: in.Ctrl,
+		Data: data,
+	}
+}
+
 // Join combines multiple input Streams into a single output Stream. Closing
 // the Join closes the input streams as well.
 func Join(in ...*Stream) *Stream {
@@ -606,10 +696,9 @@ func OnOffValve(in *Stream) (*Stream, chan<- bool) {
 	return s, ctrl
 }
 
-// Throttle limits the number of events emitted by the stream
-func Throttle(in *Stream, mod api.ThrottleModifier) *Stream {
-	data := make(chan interface{})
-
+// ThrottleInterval returns the time to wait after each element emitted by a
+// stream throttled with the given modifier.
+func ThrottleInterval(mod api.ThrottleModifier) time.Duration {
 	// Convert `IntervalType` to a `Duration`
 	var interval time.Duration
 	switch mod.IntervalType {
@@ -623,6 +712,14 @@ func Throttle(in *Stream, mod api.ThrottleModifier) *Stream {
 		interval = time.Hour
 	}
 
+	return time.Duration(mod.Interval) * interval
+}
+
+// Throttle limits the number of events emitted by the stream
+func Throttle(in *Stream, mod api.ThrottleModifier) *Stream {
+	data := make(chan interface{})
+	interval := ThrottleInterval(mod)
+
 	go func() {
 		defer close(data)
 
@@ -635,7 +732,7 @@ func Throttle(in *Stream, mod api.ThrottleModifier) *Stream {
 					return
 				}
 			}
-			time.Sleep(time.Duration(mod.Interval) * interval)
+			time.Sleep(interval)
 		}
 	}()
 
@@ -14,7 +14,12 @@
 
 package stream
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
 
 func TestNext(t *testing.T) {
 	s := Iota(10)
@@ -56,6 +61,91 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestPrepend(t *testing.T) {
+	s := Iota(3, 10)
+	defer s.Close()
+
+	s = Prepend(s, []interface{}{uint64(1), uint64(2)})
+
+	expected := []uint64{1, 2, 10, 11, 12}
+	for _, x := range expected {
+		e, ok := <-s.Data
+		if !ok {
+			t.Fatalf("Expected %d, got closed", x)
+		}
+		if e.(uint64) != x {
+			t.Fatalf("Expected %d, got %d", x, e.(uint64))
+		}
+	}
+
+	e, ok := <-s.Data
+	if ok {
+		t.Errorf("Expected stream closed, got element: %v", e)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	s := Expire(Null(), 10*time.Millisecond)
+
+	select {
+	case e, ok := <-s.Data:
+		if ok {
+			t.Errorf("Expected stream closed, got element: %v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected stream to be closed after expiring")
+	}
+
+	// Closing the stream after it has expired must be safe
+	s.Close()
+}
+
+func TestExpireClose(t *testing.T) {
+	s := Expire(Null(), time.Hour)
+	s.Close()
+
+	select {
+	case e, ok := <-s.Data:
+		if ok {
+			t.Errorf("Expected stream closed, got element: %v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected stream to be closed")
+	}
+}
+
+func TestFinally(t *testing.T) {
+	done := make(chan struct{})
+	s := Finally(Iota(3), func() {
+		close(done)
+	})
+
+	n := 0
+	for range s.Data {
+		n++
+	}
+
+	if n != 3 {
+		t.Errorf("Expected 3 elements, got %d", n)
+	}
+
+	select {
+	case <-done:
+	default:
+		t.Error("Expected call at the end of the stream")
+	}
+}
+
+func TestThrottleInterval(t *testing.T) {
+	d := ThrottleInterval(api.ThrottleModifier{
+		Interval:     3,
+		IntervalType: api.ThrottleModifier_SECOND,
+	})
+	if d != 3*time.Second {
+		t.Errorf("Expected %s, got %s", 3*time.Second, d)
+	}
+}
+
 func TestIota1(t *testing.T) {
 	s := Iota(1)
 
@@ -32,19 +32,17 @@ type TraceEventSampleData map[string]interface{}
 type TraceEventDecoderFn func(*SampleRecord, TraceEventSampleData) (interface{}, error)
 
 type traceEventDecoder struct {
-	fields    map[string]traceEventField
-	decoderfn TraceEventDecoderFn
+	fields map[string]traceEventField
 }
 
-func newTraceEventDecoder(tracingDir, name string, fn TraceEventDecoderFn) (*traceEventDecoder, uint16, error) {
+func newTraceEventDecoder(tracingDir, name string) (*traceEventDecoder, uint16, error) {
 	id, fields, err := getTraceEventFormat(tracingDir, name)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	decoder := &traceEventDecoder{
-		fields:    fields,
-		decoderfn: fn,
+		fields: fields,
 	}
 
 	return decoder, id, err
@@ -126,21 +124,41 @@ func (d *traceEventDecoder) decodeRawData(rawData []byte) (TraceEventSampleData,
 	return data, nil
 }
 
+// A decoderMap holds the format of each trace event that has been registered
+// along with the decoder function for each registered event. The same trace
+// event may be registered more than once, each with its own decoder function,
+// so the formats are reference counted.
 type decoderMap struct {
-	decoders map[uint16]*traceEventDecoder
-	names    map[string]uint16
+	decoders map[uint16]*traceEventDecoder  // trace event id : decoder
+	names    map[string]uint16              // name : trace event id
+	refs     map[string]int                 // name : registered event count
+	fns      map[uint64]TraceEventDecoderFn // event id : decoder function
 }
 
 func newDecoderMap() *decoderMap {
 	return &decoderMap{
 		decoders: make(map[uint16]*traceEventDecoder),
 		names:    make(map[string]uint16),
+		refs:     make(map[string]int),
+		fns:      make(map[uint64]TraceEventDecoderFn),
 	}
 }
 
-func (dm *decoderMap) add(name string, id uint16, decoder *traceEventDecoder) {
-	dm.decoders[id] = decoder
-	dm.names[name] = id
+func (dm *decoderMap) copy() *decoderMap {
+	ndm := newDecoderMap()
+	for k, v := range dm.decoders {
+		ndm.decoders[k] = v
+	}
+	for k, v := range dm.names {
+		ndm.names[k] = v
+	}
+	for k, v := range dm.refs {
+		ndm.refs[k] = v
+	}
+	for k, v := range dm.fns {
+		ndm.fns[k] = v
+	}
+	return ndm
 }
 
 type traceEventDecoderMap struct {
@@ -163,113 +181,82 @@ func newTraceEventDecoderMap(tracingDir string) *traceEventDecoderMap {
 	}
 }
 
-// Add a decoder "in-place". i.e., don't copy the decoder map before update
-// No synchronization is used. Assumes the caller has adequate protection
-func (m *traceEventDecoderMap) addDecoderInPlace(name string, fn TraceEventDecoderFn) (uint16, error) {
-	decoder, id, err := newTraceEventDecoder(m.tracingDir, name, fn)
-	if err != nil {
-		return 0, err
-	}
-
-	dm := m.getDecoderMap()
-	if dm == nil {
-		dm = newDecoderMap()
-		m.active.Store(dm)
-	}
-	dm.add(name, id, decoder)
-
-	return id, nil
-}
-
 // Add a decoder safely. Proper synchronization is used to prevent multiple
 // writers from stomping on each other while allowing readers to always
-// operate without locking
-func (m *traceEventDecoderMap) AddDecoder(name string, fn TraceEventDecoderFn) (uint16, error) {
-	decoder, id, err := newTraceEventDecoder(m.tracingDir, name, fn)
-	if err != nil {
-		return 0, err
-	}
-
+// operate without locking. The decoder function is used only for samples
+// from the event with the specified event id, so the same trace event may be
+// added any number of times with different decoder functions.
+func (m *traceEventDecoderMap) AddDecoder(name string, eventid uint64, fn TraceEventDecoderFn) (uint16, error) {
 	m.Lock()
 	defer m.Unlock()
 
-	odm := m.getDecoderMap()
-	ndm := newDecoderMap()
-	if odm != nil {
-		for k, v := range odm.decoders {
-			ndm.decoders[k] = v
-		}
-		for k, v := range odm.names {
-			ndm.names[k] = v
+	var ndm *decoderMap
+	if odm := m.getDecoderMap(); odm != nil {
+		ndm = odm.copy()
+	} else {
+		ndm = newDecoderMap()
+	}
+
+	id, ok := ndm.names[name]
+	if !ok {
+		decoder, newID, err := newTraceEventDecoder(m.tracingDir, name)
+		if err != nil {
+			return 0, err
 		}
+		id = newID
+		ndm.decoders[id] = decoder
+		ndm.names[name] = id
 	}
-	ndm.add(name, id, decoder)
+	ndm.refs[name]++
+	ndm.fns[eventid] = fn
 
 	m.active.Store(ndm)
 
 	return id, nil
 }
 
-// Remove a decoder "in-place". i.e., don't copy the decoder map before update
-// No synchronization is used. Assumes the caller has adequate protection
-func (m *traceEventDecoderMap) removeDecoderInPlace(name string) {
-	dm := m.getDecoderMap()
-	if dm == nil {
-		return
-	}
-
-	id, ok := dm.names[name]
-	if ok {
-		delete(dm.names, name)
-		delete(dm.decoders, id)
-	}
-}
-
 // Remove a decoder safely. Proper synchronization is used to prevent multiple
 // writers from stomping on each other while allowing readers to always
-// operate without locking
-func (m *traceEventDecoderMap) RemoveDecoder(name string) {
-	dm := m.getDecoderMap()
-	if dm == nil {
+// operate without locking. The format of the trace event is retained until
+// all of the events using it have been removed.
+func (m *traceEventDecoderMap) RemoveDecoder(name string, eventid uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	odm := m.getDecoderMap()
+	if odm == nil {
+		return
+	}
+	if _, ok := odm.fns[eventid]; !ok {
 		return
 	}
 
-	id, ok := dm.names[name]
-	if ok {
-		m.Lock()
-		defer m.Unlock()
-
-		odm := m.getDecoderMap()
-		if odm != nil {
-			ndm := newDecoderMap()
-			for k, v := range odm.decoders {
-				if k != id {
-					ndm.decoders[k] = v
-				}
-			}
-			for k, v := range odm.names {
-				if k != name {
-					ndm.names[k] = v
-				}
-			}
-
-			m.active.Store(ndm)
+	ndm := odm.copy()
+	delete(ndm.fns, eventid)
+	ndm.refs[name]--
+	if ndm.refs[name] <= 0 {
+		delete(ndm.refs, name)
+		if id, ok := ndm.names[name]; ok {
+			delete(ndm.names, name)
+			delete(ndm.decoders, id)
 		}
 	}
+
+	m.active.Store(ndm)
 }
 
-func (m *traceEventDecoderMap) getDecoder(eventType uint16) *traceEventDecoder {
+// DecodeSample decodes a sample using the decoder function of the event with
+// the specified event id.
+func (m *traceEventDecoderMap) DecodeSample(eventid uint64, sample *SampleRecord) (interface{}, error) {
 	dm := m.getDecoderMap()
 	if dm == nil {
-		return nil
+		return nil, nil
 	}
-	return dm.decoders[eventType]
-}
 
-func (m *traceEventDecoderMap) DecodeSample(sample *SampleRecord) (interface{}, error) {
 	eventType := uint16(binary.LittleEndian.Uint64(sample.RawData))
-	decoder := m.getDecoder(eventType)
-	if decoder == nil {
+	decoder := dm.decoders[eventType]
+	fn := dm.fns[eventid]
+	if decoder == nil || fn == nil {
 		// Not an error. There just isn't a decoder for this sample
 		return nil, nil
 	}
@@ -279,5 +266,5 @@ func (m *traceEventDecoderMap) DecodeSample(sample *SampleRecord) (interface{},
 		return nil, err
 	}
 
-	return decoder.decoderfn(sample, data)
+	return fn(sample, data)
 }
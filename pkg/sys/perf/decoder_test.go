@@ -0,0 +1,102 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testTraceEventFormat = `name: test_event
+ID: 42
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:int value;	offset:8;	size:4;	signed:1;
+
+print fmt: "value=%d", REC->value
+`
+
+func TestDecoderMapSharedTraceEvent(t *testing.T) {
+	tracingDir, err := ioutil.TempDir("", "decoder_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tracingDir)
+
+	dir := filepath.Join(tracingDir, "events", "test", "test_event")
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "format"),
+		[]byte(testTraceEventFormat), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newDecoderFn := func(result string) TraceEventDecoderFn {
+		return func(*SampleRecord, TraceEventSampleData) (interface{}, error) {
+			return result, nil
+		}
+	}
+
+	m := newTraceEventDecoderMap(tracingDir)
+	for eventid, result := range []string{"first", "second"} {
+		id, err := m.AddDecoder("test/test_event", uint64(eventid),
+			newDecoderFn(result))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 42 {
+			t.Fatalf("Expected trace event id 42, got %d", id)
+		}
+	}
+
+	sample := &SampleRecord{
+		RawData: make([]byte, 12),
+	}
+	binary.LittleEndian.PutUint16(sample.RawData, 42)
+
+	for eventid, expected := range []string{"first", "second"} {
+		s, err := m.DecodeSample(uint64(eventid), sample)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != expected {
+			t.Errorf("Expected %q for event %d, got %v",
+				expected, eventid, s)
+		}
+	}
+
+	// Removing one event must not affect the other
+	m.RemoveDecoder("test/test_event", 0)
+	if s, _ := m.DecodeSample(0, sample); s != nil {
+		t.Errorf("Expected no decoder for removed event, got %v", s)
+	}
+	if s, _ := m.DecodeSample(1, sample); s != "second" {
+		t.Errorf("Expected \"second\" after removing event 0, got %v", s)
+	}
+
+	m.RemoveDecoder("test/test_event", 1)
+	if dm := m.getDecoderMap(); len(dm.decoders) != 0 || len(dm.names) != 0 {
+		t.Errorf("Expected trace event format to be removed")
+	}
+}
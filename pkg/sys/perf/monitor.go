@@ -196,9 +196,22 @@ func (group *perfEventGroup) cleanup() {
 // SampleDispatchFn is the signature of a function called to dispatch a
 // sample. The first argument is the event ID, the second is the returned
 // value from the decoder, and the third is the error that may have been
-// returned from the decoder.
+// returned from the decoder. Samples that are not associated with any one
+// event, such as LostSamples, are dispatched with an event ID of 0.
 type SampleDispatchFn func(uint64, interface{}, error)
 
+// LostSamples is dispatched in place of a PERF_RECORD_LOST record to describe
+// samples that the kernel discarded because a ring buffer was full. The
+// samples were lost some time between StartTime, the time of the previous
+// sample read from the same ring buffer, and EndTime, the time at which the
+// loss was reported. StartTime is 0 if there was no previous sample.
+type LostSamples struct {
+	CPU       int
+	Lost      uint64
+	StartTime uint64
+	EndTime   uint64
+}
+
 // EventMonitor is a high-level interface to the Linux kernel's perf_event
 // infrastructure.
 type EventMonitor struct {
@@ -225,6 +238,7 @@ type EventMonitor struct {
 	// required.
 	samples        decodedSampleList // Used while reading from ringbuffers
 	pendingSamples decodedSampleList
+	lastSampleTime map[int]uint64 // fd : time of last sample read
 
 	// Immutable once set. Only used by the .dispatchSamples() goroutine.
 	// Load once there and cache locally to avoid cache misses on this
@@ -252,6 +266,67 @@ type EventMonitor struct {
 	// Used only once during shutdown
 	cond *sync.Cond
 	wg   sync.WaitGroup
+
+	// Updated by the monitor and dispatch goroutines, readable by others
+	stats *monitorStats
+}
+
+// EventMonitorStats contains counters describing the operation of an
+// EventMonitor since it was created.
+type EventMonitorStats struct {
+	// Number of samples that the kernel reported as lost, by CPU
+	LostSamples map[int]uint64
+
+	// Number of samples that could not be decoded
+	DecodeErrors uint64
+
+	// Number of times that ring buffers have been read after polling
+	// reported them ready, and the total time spent reading them and
+	// handing their samples off for dispatch.
+	RingBufferReads    uint64
+	RingBufferReadTime time.Duration
+}
+
+type monitorStats struct {
+	sync.Mutex
+	EventMonitorStats
+}
+
+func newMonitorStats() *monitorStats {
+	return &monitorStats{
+		EventMonitorStats: EventMonitorStats{
+			LostSamples: make(map[int]uint64),
+		},
+	}
+}
+
+func (ms *monitorStats) decodeError() {
+	ms.Lock()
+	ms.DecodeErrors++
+	ms.Unlock()
+}
+
+func (ms *monitorStats) ringBufferRead(lost map[int]uint64, d time.Duration) {
+	ms.Lock()
+	for cpu, n := range lost {
+		ms.LostSamples[cpu] += n
+	}
+	ms.RingBufferReads++
+	ms.RingBufferReadTime += d
+	ms.Unlock()
+}
+
+// Stats returns a snapshot of the EventMonitor's counters.
+func (monitor *EventMonitor) Stats() EventMonitorStats {
+	monitor.stats.Lock()
+	defer monitor.stats.Unlock()
+
+	stats := monitor.stats.EventMonitorStats
+	stats.LostSamples = make(map[int]uint64, len(stats.LostSamples))
+	for cpu, n := range monitor.stats.LostSamples {
+		stats.LostSamples[cpu] = n
+	}
+	return stats
 }
 
 func fixupEventAttr(eventAttr *EventAttr) {
@@ -373,7 +448,11 @@ func (monitor *EventMonitor) perfEventOpen(eventAttr *EventAttr, filter string)
 
 // This should be called with monitor.lock held.
 func (monitor *EventMonitor) newRegisteredEvent(name string, fn TraceEventDecoderFn, opts registerEventOptions, eventType int) (uint64, error) {
-	id, err := monitor.decoders.AddDecoder(name, fn)
+	// Choose the eventid for this event now, but don't commit to it until
+	// later when no error has occurred in registering the event.
+	eventid := monitor.nextEventID
+
+	id, err := monitor.decoders.AddDecoder(name, eventid, fn)
 	if err != nil {
 		return 0, err
 	}
@@ -391,14 +470,10 @@ func (monitor *EventMonitor) newRegisteredEvent(name string, fn TraceEventDecode
 
 	newfds, err := monitor.perfEventOpen(&attr, opts.filter)
 	if err != nil {
-		monitor.decoders.RemoveDecoder(name)
+		monitor.decoders.RemoveDecoder(name, eventid)
 		return 0, err
 	}
 
-	// Choose the eventid for this event now, but don't commit to it until
-	// later when no error has occurred in registering the event.
-	eventid := monitor.nextEventID
-
 	eventAttrMap := newEventAttrMap()
 	eventIDMap := newUInt64Map()
 	for _, fd := range newfds {
@@ -408,7 +483,7 @@ func (monitor *EventMonitor) newRegisteredEvent(name string, fn TraceEventDecode
 				unix.Close(fd)
 				delete(monitor.eventids, fd)
 			}
-			monitor.decoders.RemoveDecoder(name)
+			monitor.decoders.RemoveDecoder(name, eventid)
 			return 0, err
 		}
 		eventAttrMap[uint64(streamid)] = &attr
@@ -602,7 +677,7 @@ func (monitor *EventMonitor) resolveSymbol(bin, symbol string) (string, error) {
 }
 
 // This should be called with monitor.lock held
-func (monitor *EventMonitor) removeRegisteredEvent(event registeredEvent) {
+func (monitor *EventMonitor) removeRegisteredEvent(eventid uint64, event registeredEvent) {
 	ids := make([]uint64, 0, len(event.fds))
 	for _, fd := range event.fds {
 		delete(monitor.eventfds, fd)
@@ -631,7 +706,7 @@ func (monitor *EventMonitor) removeRegisteredEvent(event registeredEvent) {
 		monitor.removeUprobe(event.name)
 	}
 
-	monitor.decoders.RemoveDecoder(event.name)
+	monitor.decoders.RemoveDecoder(event.name, eventid)
 }
 
 // UnregisterEvent is used to remove a previously registered event from an
@@ -647,7 +722,7 @@ func (monitor *EventMonitor) UnregisterEvent(eventid uint64) error {
 		return errors.New("event is not registered")
 	}
 	delete(monitor.events, eventid)
-	monitor.removeRegisteredEvent(event)
+	monitor.removeRegisteredEvent(eventid, event)
 
 	return nil
 }
@@ -666,8 +741,8 @@ func (monitor *EventMonitor) Close(wait bool) error {
 	monitor.lock.Lock()
 	defer monitor.lock.Unlock()
 
-	for _, event := range monitor.events {
-		monitor.removeRegisteredEvent(event)
+	for eventid, event := range monitor.events {
+		monitor.removeRegisteredEvent(eventid, event)
 	}
 	monitor.events = nil
 
@@ -778,6 +853,7 @@ func (monitor *EventMonitor) stopWithSignal() {
 
 type decodedSample struct {
 	sample Sample
+	lost   *LostSamples
 	err    error
 }
 
@@ -799,6 +875,11 @@ func (monitor *EventMonitor) dispatchSortedSamples(samples decodedSampleList) {
 	dispatchFn := monitor.dispatchFn
 	eventIDMap := monitor.eventIDMap.getMap()
 	for _, ds := range samples {
+		if ds.lost != nil {
+			dispatchFn(0, ds.lost, nil)
+			continue
+		}
+
 		streamID := ds.sample.SampleID.StreamID
 		eventID, ok := eventIDMap[streamID]
 		if !ok {
@@ -818,7 +899,10 @@ func (monitor *EventMonitor) dispatchSortedSamples(samples decodedSampleList) {
 			// Adjust the sample time so that it
 			// matches the normalized timestamp.
 			record.Time = ds.sample.Time
-			s, err := monitor.decoders.DecodeSample(record)
+			s, err := monitor.decoders.DecodeSample(eventID, record)
+			if err != nil {
+				monitor.stats.decodeError()
+			}
 			dispatchFn(eventID, s, err)
 		default:
 			dispatchFn(eventID, &ds.sample, ds.err)
@@ -862,8 +946,11 @@ func (monitor *EventMonitor) readRingBuffers(readyfds []int) {
 	var (
 		lastTimestamp uint64
 		lastIndex     int
+		lost          map[int]uint64
 	)
 
+	start := time.Now()
+
 	// Group fds are created with a read_format of 0, which means that the
 	// data read from each fd will always be 8 bytes. We don't care about
 	// the data, so we can safely ignore it. Due to the way that the
@@ -876,9 +963,30 @@ func (monitor *EventMonitor) readRingBuffers(readyfds []int) {
 		first := len(monitor.samples)
 		group := monitor.groups[fd]
 		group.rb.read(monitor.readSamples)
+		lastTime := monitor.lastSampleTime[fd]
 		for i := first; i < len(monitor.samples); i++ {
-			monitor.samples[i].sample.Time = group.timeBase +
-				(monitor.samples[i].sample.Time - group.timeOffset)
+			ds := &monitor.samples[i]
+			ds.sample.Time = group.timeBase +
+				(ds.sample.Time - group.timeOffset)
+			if lr, ok := ds.sample.Record.(*LostRecord); ok {
+				if lost == nil {
+					lost = make(map[int]uint64)
+				}
+				lost[group.cpu] += lr.Lost
+				ds.lost = &LostSamples{
+					CPU:       group.cpu,
+					Lost:      lr.Lost,
+					StartTime: lastTime,
+					EndTime:   ds.sample.Time,
+				}
+			}
+			lastTime = ds.sample.Time
+		}
+		if len(monitor.samples) > first {
+			if monitor.lastSampleTime == nil {
+				monitor.lastSampleTime = make(map[int]uint64)
+			}
+			monitor.lastSampleTime[fd] = lastTime
 		}
 
 		if first == 0 {
@@ -909,6 +1017,8 @@ func (monitor *EventMonitor) readRingBuffers(readyfds []int) {
 		monitor.dispatchChan <- monitor.samples
 		monitor.samples = nil
 	}
+
+	monitor.stats.ringBufferRead(lost, time.Since(start))
 }
 
 func (monitor *EventMonitor) flushPendingSamples() {
@@ -1244,6 +1354,7 @@ func NewEventMonitor(options ...EventMonitorOption) (*EventMonitor, error) {
 		eventids:     make(map[int]uint64),
 		defaultAttr:  eventAttr,
 		tracingDir:   opts.tracingDir,
+		stats:        newMonitorStats(),
 	}
 	monitor.lock = &sync.Mutex{}
 	monitor.cond = sync.NewCond(monitor.lock)
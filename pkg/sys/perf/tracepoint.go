@@ -170,7 +170,7 @@ func (field *traceEventField) parseTypeName(s string, isArray bool, arraySize in
 		field.dataTypeSize = 1
 		return false, nil
 	case "u8", "__u8", "uint8_t", "__uint8_t":
-		field.dataType = dtS16
+		field.dataType = dtU8
 		field.dataTypeSize = 1
 		return false, nil
 	case "s16", "__s16", "int16_t", "__int16_t":
@@ -421,6 +421,39 @@ func getTraceEventFormat(tracingDir, name string) (uint16, map[string]traceEvent
 	return readTraceEventFormat(name, file)
 }
 
+// TraceEventFields returns the fields of a trace event, read from its format
+// in the tracing directory, each as the zero value of the type that it is
+// decoded as. Fixed size arrays of char are compared as strings by kernel
+// filters, so they are returned as strings; other arrays are omitted. An
+// error is returned if the trace event does not exist.
+func TraceEventFields(tracingDir, name string) (TraceEventSampleData, error) {
+	_, fields, err := getTraceEventFormat(tracingDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return traceEventFieldValues(fields), nil
+}
+
+func traceEventFieldValues(fields map[string]traceEventField) TraceEventSampleData {
+	data := make(TraceEventSampleData, len(fields))
+	for name, field := range fields {
+		switch {
+		case field.dataType == dtString:
+			data[name] = ""
+		case field.dataLocSize > 0:
+			continue
+		case field.arraySize > 0:
+			if field.TypeName == "char" {
+				data[name] = ""
+			}
+		default:
+			data[name], _ = decodeDataType(field.dataType,
+				make([]byte, 8))
+		}
+	}
+	return data
+}
+
 func readTraceEventFormat(name string, reader io.Reader) (uint16, map[string]traceEventField, error) {
 	var eventID uint16
 
@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -72,3 +73,98 @@ func TestReadTraceEventFormat(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+const inetSockSetStateFormat = `name: inet_sock_set_state
+ID: 1234
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:const void * skaddr;	offset:8;	size:8;	signed:0;
+	field:int oldstate;	offset:16;	size:4;	signed:1;
+	field:int newstate;	offset:20;	size:4;	signed:1;
+	field:__u16 sport;	offset:24;	size:2;	signed:0;
+	field:__u16 dport;	offset:26;	size:2;	signed:0;
+	field:__u8 saddr[4];	offset:28;	size:4;	signed:0;
+
+print fmt: "sport=%hu", REC->sport
+`
+
+const sysEnterUnlinkatFormat = `name: sys_enter_unlinkat
+ID: 1235
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:int __syscall_nr;	offset:8;	size:4;	signed:1;
+	field:char comm[16];	offset:12;	size:16;	signed:1;
+	field:__data_loc char[] pathname;	offset:28;	size:4;	signed:1;
+	field:__u8 saddr[4];	offset:32;	size:4;	signed:0;
+	field:int flag;	offset:40;	size:8;	signed:0;
+
+print fmt: "flag: 0x%08lx", REC->flag
+`
+
+func TestTraceEventFieldValues(t *testing.T) {
+	_, fields, err := readTraceEventFormat("syscalls/sys_enter_unlinkat",
+		strings.NewReader(sysEnterUnlinkatFormat))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := traceEventFieldValues(fields)
+	expected := TraceEventSampleData{
+		"common_type":          uint16(0),
+		"common_flags":         uint8(0),
+		"common_preempt_count": uint8(0),
+		"common_pid":           int32(0),
+		"__syscall_nr":         int32(0),
+		"comm":                 "",
+		"pathname":             "",
+		"flag":                 uint64(0),
+	}
+	if len(data) != len(expected) {
+		t.Errorf("Expected %d fields, got %+v", len(expected), data)
+	}
+	for name, value := range expected {
+		if v, ok := data[name]; !ok || v != value {
+			t.Errorf("Expected %s to be %T, got %T", name, value, v)
+		}
+	}
+}
+
+func TestDecodeUnsignedByteArray(t *testing.T) {
+	_, fields, err := readTraceEventFormat("sock/inet_sock_set_state",
+		strings.NewReader(inetSockSetStateFormat))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawData := make([]byte, 32)
+	rawData[24] = 0x50
+	copy(rawData[28:], []byte{192, 168, 0, 255})
+
+	d := &traceEventDecoder{
+		fields: fields,
+	}
+	data, err := d.decodeRawData(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sport := data["sport"].(uint16); sport != 80 {
+		t.Errorf("Expected sport 80, got %d", sport)
+	}
+	saddr := data["saddr"].([]interface{})
+	expected := []uint8{192, 168, 0, 255}
+	for i, b := range expected {
+		if saddr[i].(uint8) != b {
+			t.Errorf("Expected saddr %v, got %v", expected, saddr)
+			break
+		}
+	}
+}
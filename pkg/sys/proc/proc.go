@@ -117,6 +117,18 @@ func (fs *FileSystem) ReadFile(relativePath string) ([]byte, error) {
 	return ioutil.ReadFile(filepath.Join(fs.MountPoint, relativePath))
 }
 
+// Readlink returns the destination of the procfs symbolic link indicated
+// by the given relative path.
+func Readlink(relativePath string) (string, error) {
+	return FS().Readlink(relativePath)
+}
+
+// Readlink returns the destination of the procfs symbolic link indicated
+// by the given relative path.
+func (fs *FileSystem) Readlink(relativePath string) (string, error) {
+	return os.Readlink(filepath.Join(fs.MountPoint, relativePath))
+}
+
 // CommandLine gets the full command-line arguments for the process
 // indicated by the given PID.
 func CommandLine(pid int) []string {
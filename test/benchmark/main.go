@@ -37,9 +37,11 @@ import (
 )
 
 var config struct {
-	endpoint string
-	image    string
-	verbose  bool
+	endpoint     string
+	image        string
+	verbose      bool
+	batchSize    uint
+	batchLatency time.Duration
 }
 
 func init() {
@@ -52,6 +54,12 @@ func init() {
 
 	flag.BoolVar(&config.verbose, "verbose", false,
 		"verbose (print events received)")
+
+	flag.UintVar(&config.batchSize, "batch-size", 0,
+		"maximum number of events per response")
+
+	flag.DurationVar(&config.batchLatency, "batch-latency", 0,
+		"maximum time to wait for a batch of events to fill")
 }
 
 // Custom gRPC Dialer that understands "unix:/path/to/sock" as well as TCP addrs
@@ -101,7 +109,9 @@ func createSubscription() *api.Subscription {
 	}
 
 	sub := &api.Subscription{
-		EventFilter: eventFilter,
+		EventFilter:     eventFilter,
+		MaxBatchSize:    uint32(config.batchSize),
+		MaxBatchLatency: int64(config.batchLatency),
 	}
 
 	if config.image != "" {
@@ -121,10 +131,13 @@ func createSubscription() *api.Subscription {
 }
 
 var subscriptionEvents int64
+var subscriptionResponses int64
 
 var startSubEvents int64
+var startSubResponses int64
 var startMetrics map[string]subscription.MetricsCounters
 var startRusage map[string]unix.Rusage
+var startTime map[string]time.Time
 
 func onContainerRunning(cID string) {
 	var rusage unix.Rusage
@@ -136,6 +149,7 @@ func onContainerRunning(cID string) {
 	startMetrics[cID] = subscription.Metrics
 
 	startSubEvents = subscriptionEvents
+	startSubResponses = subscriptionResponses
 
 	err := unix.Getrusage(unix.RUSAGE_SELF, &rusage)
 	if err != nil {
@@ -146,6 +160,11 @@ func onContainerRunning(cID string) {
 		startRusage = make(map[string]unix.Rusage)
 	}
 	startRusage[cID] = rusage
+
+	if startTime == nil {
+		startTime = make(map[string]time.Time)
+	}
+	startTime[cID] = time.Now()
 }
 
 func max(x, y int64) int64 {
@@ -196,12 +215,19 @@ func onContainerExited(cID string) {
 	delta.Nivcsw = rusage.Nivcsw - start.Nivcsw
 
 	nEvents := subscriptionEvents - startSubEvents
+	nResponses := subscriptionResponses - startSubResponses
 	usecsPerEvent := deltaTotalUserUsec / nEvents
 	ssecsPerEvent := deltaTotalSysUsec / nEvents
 
-	fmt.Printf("%s Events:%d avg_user_us_per_event:%d avg_sys_us_per_event:%d %+v %+v\n",
-		cID, nEvents, usecsPerEvent, ssecsPerEvent,
-		metricsDelta, delta)
+	// Throughput as seen by the client, so that runs with and without
+	// -batch-size can be compared directly
+	elapsed := time.Since(startTime[cID])
+	eventsPerSec := float64(nEvents) / elapsed.Seconds()
+	eventsPerResponse := float64(nEvents) / float64(max(nResponses, 1))
+
+	fmt.Printf("%s Events:%d Responses:%d events_per_sec:%.0f events_per_response:%.1f avg_user_us_per_event:%d avg_sys_us_per_event:%d %+v %+v\n",
+		cID, nEvents, nResponses, eventsPerSec, eventsPerResponse,
+		usecsPerEvent, ssecsPerEvent, metricsDelta, delta)
 }
 
 func main() {
@@ -258,6 +284,8 @@ func main() {
 			os.Exit(1)
 		}
 
+		subscriptionResponses++
+
 		for _, event := range response.Events {
 			e := event.Event
 
@@ -19,6 +19,7 @@ import (
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/golang/glog"
 )
 
@@ -29,6 +30,7 @@ type signalTest struct {
 	containerExited bool
 	processID       string
 	processExited   bool
+	signalSent      bool
 }
 
 func (ct *signalTest) BuildContainer(t *testing.T) string {
@@ -81,9 +83,19 @@ func (ct *signalTest) CreateSubscription(t *testing.T) *api.Subscription {
 		},
 	}
 
+	signalEvents := []*api.SignalEventFilter{
+		&api.SignalEventFilter{
+			Type: api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+			FilterExpression: expression.Equal(
+				expression.Identifier("sig"),
+				expression.Value(int32(10))),
+		},
+	}
+
 	eventFilter := &api.EventFilter{
 		ContainerEvents: containerEvents,
 		ProcessEvents:   processEvents,
+		SignalEvents:    signalEvents,
 	}
 
 	sub := &api.Subscription{
@@ -159,9 +171,24 @@ func (ct *signalTest) HandleTelemetryEvent(t *testing.T, telemetryEvent *api.Rec
 			ct.processExited = true
 			glog.V(1).Infof("processExited = true")
 		}
+
+	case *api.TelemetryEvent_Signal:
+		if len(ct.processID) > 0 &&
+			telemetryEvent.Event.ProcessId == ct.processID {
+
+			// The process raises the signal on itself
+			if event.Signal.TargetId != ct.processID {
+				t.Errorf("Expected TargetId %s, got %s",
+					ct.processID, event.Signal.TargetId)
+				return false
+			}
+
+			ct.signalSent = true
+			glog.V(1).Infof("signalSent = true")
+		}
 	}
 
-	return !(ct.containerExited && ct.processExited)
+	return !(ct.containerExited && ct.processExited && ct.signalSent)
 }
 
 func TestSignal(t *testing.T) {